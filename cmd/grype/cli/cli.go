@@ -81,9 +81,13 @@ func SetupConfig(id clio.Identification) *clio.SetupConfig {
 			stereoscope.Cleanup()
 		}).
 		WithMapExitCode(func(err error) int {
-			// return exit code 2 to indicate when a vulnerability severity is discovered
-			// that is equal or above the given --fail-on severity value.
-			if errors.Is(err, grypeerr.ErrAboveSeverityThreshold) {
+			// return exit code 2 to indicate when a vulnerability is discovered that is equal or above
+			// the given --fail-on severity value (or the risk, KEV, and EPSS fail thresholds).
+			if grypeerr.IsAboveFailThreshold(err) {
+				return 2
+			}
+			// also return exit code 2 when a finding trips a policy rule with the "fail" action.
+			if errors.Is(err, grypeerr.ErrPolicyViolation) {
 				return 2
 			}
 			// return exit code 100 to indicate a DB upgrade is available (cmd: db check).
@@ -106,6 +110,11 @@ func create(id clio.Identification) (clio.Application, *cobra.Command) {
 		commands.DB(app),
 		commands.Completion(app),
 		commands.Explain(app),
+		commands.Diff(app),
+		commands.Rescan(app),
+		commands.Baseline(app),
+		commands.Ignore(app),
+		commands.Serve(app),
 		clio.VersionCommand(id, syftVersion, dbVersion),
 		clio.ConfigCommand(app, nil),
 	)
@@ -0,0 +1,47 @@
+package commands
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/anchore/clio"
+	"github.com/anchore/grype/cmd/grype/cli/options"
+	"github.com/anchore/grype/internal/format"
+)
+
+func Baseline(app clio.Application) *cobra.Command {
+	opts := options.DefaultGrype(app.ID())
+
+	return app.SetupCommand(&cobra.Command{
+		Use:   "baseline [IMAGE] --baseline [PATH]",
+		Short: "Write or refresh a baseline of known findings from the current scan",
+		Long: `Scan the given target and write all current findings to the baseline path as a grype JSON report.
+Subsequent scans given the same --baseline will only report findings that are not in the baseline.`,
+		Example: `  grype baseline alpine:latest --baseline .grype-baseline.json
+  grype alpine:latest --baseline .grype-baseline.json --fail-on high`,
+		Args:              validateSingleTargetArgs,
+		ValidArgsFunction: dockerImageValidArgsFunction,
+		RunE: func(_ *cobra.Command, args []string) error {
+			if opts.Baseline == "" {
+				return fmt.Errorf("a baseline path must be provided with --baseline")
+			}
+
+			userInput := ""
+			if len(args) > 0 {
+				userInput = args[0]
+			}
+
+			// the refreshed baseline describes every current finding, not only the findings that are new
+			// relative to the existing baseline, and the scan should not fail because of them
+			path := opts.Baseline
+			opts.Baseline = ""
+			opts.Outputs = []string{format.JSONFormat.String()}
+			opts.File = path
+			opts.DisableFailThresholds()
+			opts.Policy = ""
+
+			return runGrype(app, opts, userInput)
+		},
+	}, opts)
+}
@@ -19,10 +19,13 @@ func DB(app clio.Application) *cobra.Command {
 	}
 
 	db.AddCommand(
+		DBBuild(app),
 		DBCheck(app),
 		DBDelete(app),
+		DBDiff(app),
 		DBImport(app),
 		DBList(app),
+		DBMirror(app),
 		DBStatus(app),
 		DBUpdate(app),
 		DBSearch(app),
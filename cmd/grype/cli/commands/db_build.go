@@ -0,0 +1,77 @@
+package commands
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/anchore/clio"
+	"github.com/anchore/grype/grype/db/v6/osv"
+	"github.com/anchore/grype/internal/bus"
+)
+
+type dbBuildOptions struct {
+	FromOSV  []string `yaml:"from-osv" json:"from-osv"`
+	Dir      string   `yaml:"dir" json:"dir"`
+	Provider string   `yaml:"provider" json:"provider"`
+}
+
+var _ clio.FlagAdder = (*dbBuildOptions)(nil)
+
+func (d *dbBuildOptions) AddFlags(flags clio.FlagSet) {
+	flags.StringArrayVarP(&d.FromOSV, "from-osv", "", "directory of OSV JSON records to build the database from (may be given multiple times)")
+	flags.StringVarP(&d.Dir, "dir", "d", "directory to write the database (vulnerability.db) and import metadata to")
+	flags.StringVarP(&d.Provider, "provider", "", "provider name the vulnerabilities are attributed to (the prefix of their namespace)")
+}
+
+func DBBuild(app clio.Application) *cobra.Command {
+	opts := &dbBuildOptions{
+		Dir:      ".",
+		Provider: osv.DefaultProvider,
+	}
+
+	cmd := &cobra.Command{
+		Use:   "build",
+		Short: "Build a vulnerability database from OSV records",
+		Long: `Build a vulnerability database from OSV records.
+
+All OSV JSON records (and simple YAML advisories, see "Local advisories") within the given directories are written to
+a new vulnerability.db, along with import metadata. Affected packages are described by their ECOSYSTEM and SEMVER
+ranges (or explicit versions), while GIT ranges are ignored. Aliases, severities, and references of each record are
+kept. The database can be imported with "grype db import", compared with "grype db diff", and shipped like any other
+database.`,
+		Example: `  grype db build --from-osv ./advisories --dir ./private-db
+  grype db import ./private-db/vulnerability.db`,
+		Args: cobra.NoArgs,
+		RunE: func(_ *cobra.Command, _ []string) error {
+			return runDBBuild(app.ID(), opts)
+		},
+	}
+
+	// prevent from being shown in the grype config
+	type configWrapper struct {
+		Hidden *dbBuildOptions `json:"-" yaml:"-" mapstructure:"-"`
+	}
+
+	return app.SetupCommand(cmd, &configWrapper{Hidden: opts})
+}
+
+func runDBBuild(id clio.Identification, opts *dbBuildOptions) error {
+	if len(opts.FromOSV) == 0 {
+		return fmt.Errorf("at least one directory of OSV records must be given with --from-osv")
+	}
+
+	result, err := osv.Build(opts.Dir, osv.BuildConfig{
+		Dirs:      opts.FromOSV,
+		Provider:  opts.Provider,
+		Processor: fmt.Sprintf("%s@%s", id.Name, id.Version),
+	})
+	if err != nil {
+		return fmt.Errorf("unable to build vulnerability database: %w", err)
+	}
+
+	bus.Report(fmt.Sprintf("Built vulnerability database with %d vulnerabilities from provider %q at %s\nImport it with: grype db import %s\n",
+		result.Vulnerabilities, result.Provider, result.Path, result.Path))
+
+	return nil
+}
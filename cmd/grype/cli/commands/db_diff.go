@@ -0,0 +1,168 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/spf13/afero"
+	"github.com/spf13/cobra"
+	"github.com/wagoodman/go-progress"
+
+	"github.com/anchore/archiver/v3"
+	"github.com/anchore/clio"
+	"github.com/anchore/grype/cmd/grype/cli/options"
+	v6 "github.com/anchore/grype/grype/db/v6"
+	"github.com/anchore/grype/grype/db/v6/differ"
+	"github.com/anchore/grype/grype/db/v6/distribution"
+	"github.com/anchore/grype/internal/bus"
+	"github.com/anchore/grype/internal/file"
+	"github.com/anchore/grype/internal/log"
+)
+
+type dbDiffOptions struct {
+	Output                  string `yaml:"output" json:"output"`
+	options.DatabaseCommand `yaml:",inline" mapstructure:",squash"`
+}
+
+var _ clio.FlagAdder = (*dbDiffOptions)(nil)
+
+func (d *dbDiffOptions) AddFlags(flags clio.FlagSet) {
+	flags.StringVarP(&d.Output, "output", "o", "format to display results (available=[table, json])")
+}
+
+func DBDiff(app clio.Application) *cobra.Command {
+	opts := &dbDiffOptions{
+		Output:          tableOutputFormat,
+		DatabaseCommand: *options.DefaultDatabaseCommand(app.ID()),
+	}
+
+	cmd := &cobra.Command{
+		Use:   "diff OLD NEW",
+		Short: "Show the differences between two vulnerability databases",
+		Long: `Show the differences between two vulnerability databases.
+
+Each database may be a DB directory, a vulnerability.db file, a DB archive, or a URL to a DB archive. Added, removed, and
+modified vulnerability and affected package records are reported along with per-provider counts, as well as changes to
+the CISA KEV and EPSS data.`,
+		Example: `  grype db diff ~/old-db ~/.cache/grype/db/6
+  grype db diff vulnerability-db_v6.0.2_2025-01-01.tar.zst vulnerability-db_v6.0.2_2025-01-02.tar.zst -o json`,
+		Args: cobra.ExactArgs(2),
+		RunE: func(_ *cobra.Command, args []string) error {
+			return runDBDiff(opts, args[0], args[1])
+		},
+	}
+
+	// prevent from being shown in the grype config
+	type configWrapper struct {
+		Hidden                   *dbDiffOptions `json:"-" yaml:"-" mapstructure:"-"`
+		*options.DatabaseCommand `yaml:",inline" mapstructure:",squash"`
+	}
+
+	return app.SetupCommand(cmd, &configWrapper{Hidden: opts, DatabaseCommand: &opts.DatabaseCommand})
+}
+
+func runDBDiff(opts *dbDiffOptions, baseRef, targetRef string) error {
+	if opts.Output != tableOutputFormat && opts.Output != jsonOutputFormat {
+		return fmt.Errorf("unsupported output format: %s", opts.Output)
+	}
+
+	client, err := distribution.NewClient(opts.ToClientConfig())
+	if err != nil {
+		return fmt.Errorf("unable to create distribution client: %w", err)
+	}
+
+	tempDir, err := os.MkdirTemp("", "grype-db-diff")
+	if err != nil {
+		return fmt.Errorf("unable to create temp dir: %w", err)
+	}
+	defer func() {
+		if err := os.RemoveAll(tempDir); err != nil {
+			log.WithFields("error", err, "dir", tempDir).Warn("unable to remove temp dir")
+		}
+	}()
+
+	base, err := openDiffDatabase(client, baseRef, filepath.Join(tempDir, "base"))
+	if err != nil {
+		return fmt.Errorf("unable to open base database: %w", err)
+	}
+	defer log.CloseAndLogError(base, baseRef)
+
+	target, err := openDiffDatabase(client, targetRef, filepath.Join(tempDir, "target"))
+	if err != nil {
+		return fmt.Errorf("unable to open target database: %w", err)
+	}
+	defer log.CloseAndLogError(target, targetRef)
+
+	diff, err := differ.Compare(base, target)
+	if err != nil {
+		return fmt.Errorf("unable to diff databases: %w", err)
+	}
+
+	sb := &strings.Builder{}
+	if err := differ.Present(opts.Output, diff, sb); err != nil {
+		return err
+	}
+
+	bus.Report(sb.String())
+	return nil
+}
+
+var dbDiffURLPattern = regexp.MustCompile("^[a-zA-Z]+://")
+
+// openDiffDatabase opens a reader for the DB directory, DB file, DB archive, or DB archive URL, using the given
+// directory for any downloaded, copied, or extracted files.
+func openDiffDatabase(client distribution.Client, reference, workDir string) (v6.Reader, error) {
+	dir, err := resolveDiffDatabase(client, reference, workDir)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := os.Stat(filepath.Join(dir, v6.VulnerabilityDBFileName)); err != nil {
+		return nil, fmt.Errorf("no %s found for %q: %w", v6.VulnerabilityDBFileName, reference, err)
+	}
+
+	return v6.NewReader(v6.Config{DBDirPath: dir})
+}
+
+func resolveDiffDatabase(client distribution.Client, reference, workDir string) (string, error) {
+	if dbDiffURLPattern.MatchString(reference) {
+		if err := os.MkdirAll(workDir, 0o700); err != nil {
+			return "", fmt.Errorf("unable to create download dir: %w", err)
+		}
+		log.WithFields("url", reference).Info("downloading vulnerability database to diff")
+		return client.Download(reference, workDir, progress.NewManual(-1))
+	}
+
+	info, err := os.Stat(reference)
+	if err != nil {
+		return "", err
+	}
+
+	if info.IsDir() {
+		return reference, nil
+	}
+
+	if err := os.MkdirAll(workDir, 0o700); err != nil {
+		return "", fmt.Errorf("unable to create temp dir: %w", err)
+	}
+
+	if strings.HasSuffix(reference, ".db") {
+		if filepath.Base(reference) == v6.VulnerabilityDBFileName {
+			return filepath.Dir(reference), nil
+		}
+		// the store expects the DB file to be named vulnerability.db
+		if err := file.CopyFile(afero.NewOsFs(), reference, filepath.Join(workDir, v6.VulnerabilityDBFileName)); err != nil {
+			return "", fmt.Errorf("unable to copy DB file: %w", err)
+		}
+		return workDir, nil
+	}
+
+	// assume it is an archive
+	if err := archiver.Unarchive(reference, workDir); err != nil {
+		return "", fmt.Errorf("unable to unarchive DB: %w", err)
+	}
+	return workDir, nil
+}
@@ -0,0 +1,77 @@
+package commands
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	v6 "github.com/anchore/grype/grype/db/v6"
+)
+
+func TestResolveDiffDatabase(t *testing.T) {
+	dbDir := t.TempDir()
+	w, err := v6.NewWriter(v6.Config{DBDirPath: dbDir})
+	require.NoError(t, err)
+	require.NoError(t, w.AddProvider(v6.Provider{ID: "alpine", Version: "1"}))
+	require.NoError(t, w.Close())
+
+	dbFile := filepath.Join(dbDir, v6.VulnerabilityDBFileName)
+
+	renamed := filepath.Join(t.TempDir(), "old.db")
+	contents, err := os.ReadFile(dbFile)
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(renamed, contents, 0600))
+
+	tests := []struct {
+		name      string
+		reference string
+		wantDir   func(workDir string) string
+		wantErr   string
+	}{
+		{
+			name:      "directory",
+			reference: dbDir,
+			wantDir:   func(string) string { return dbDir },
+		},
+		{
+			name:      "vulnerability.db file",
+			reference: dbFile,
+			wantDir:   func(string) string { return dbDir },
+		},
+		{
+			name:      "renamed DB file is copied",
+			reference: renamed,
+			wantDir:   func(workDir string) string { return workDir },
+		},
+		{
+			name:      "missing reference",
+			reference: filepath.Join(t.TempDir(), "missing"),
+			wantErr:   "no such file or directory",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			workDir := filepath.Join(t.TempDir(), "base")
+
+			got, err := resolveDiffDatabase(nil, tt.reference, workDir)
+			if tt.wantErr != "" {
+				require.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantDir(workDir), got)
+
+			r, err := openDiffDatabase(nil, tt.reference, workDir)
+			require.NoError(t, err)
+			defer r.Close()
+
+			providers, err := r.AllProviders()
+			require.NoError(t, err)
+			require.Len(t, providers, 1)
+			assert.Equal(t, "alpine", providers[0].ID)
+		})
+	}
+}
@@ -0,0 +1,90 @@
+package commands
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+	"github.com/wagoodman/go-partybus"
+	"github.com/wagoodman/go-progress"
+
+	"github.com/anchore/clio"
+	"github.com/anchore/grype/cmd/grype/cli/options"
+	"github.com/anchore/grype/grype/db/v6/distribution"
+	"github.com/anchore/grype/grype/event"
+	"github.com/anchore/grype/internal/bus"
+	"github.com/anchore/grype/internal/log"
+)
+
+func DBMirror(app clio.Application) *cobra.Command {
+	opts := options.DefaultDatabaseCommand(app.ID())
+
+	cmd := &cobra.Command{
+		Use:   "mirror DIR",
+		Short: "Download the latest vulnerability database archive and listing into a directory for use as an update URL",
+		Long: `Download the latest vulnerability database archive and listing into a directory for use as an update URL.
+
+The directory has the same layout as the database distribution endpoint, with the listing rewritten to reference the
+archive by a relative path. It can be copied to an air-gapped environment and used directly as the update URL
+(e.g. GRYPE_DB_UPDATE_URL=file:///mnt/grype-mirror) or served by any static file server. Running the command again
+refreshes the mirror with the latest database.
+
+Detached signatures (".sig" files) of the archive are mirrored as well, and are verified when a db.signature-key is
+configured.`,
+		Example: `  grype db mirror /mnt/grype-mirror
+  GRYPE_DB_UPDATE_URL=file:///mnt/grype-mirror grype db update`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(_ *cobra.Command, args []string) error {
+			return runDBMirror(*opts, args[0])
+		},
+	}
+
+	// prevent from being shown in the grype config
+	type configWrapper struct {
+		*options.DatabaseCommand `yaml:",inline" mapstructure:",squash"`
+	}
+
+	return app.SetupCommand(cmd, &configWrapper{opts})
+}
+
+func runDBMirror(opts options.DatabaseCommand, dir string) error {
+	cfg := opts.ToClientConfig()
+	// a mirror should never silently be left without the latest listing
+	cfg.RequireUpdateCheck = true
+
+	client, err := distribution.NewClient(cfg)
+	if err != nil {
+		return fmt.Errorf("unable to create distribution client: %w", err)
+	}
+
+	stage := progress.NewAtomicStage("mirroring")
+	downloadProgress := progress.NewManual(-1)
+	bus.Publish(partybus.Event{
+		Type: event.UpdateVulnerabilityDatabase,
+		Value: progress.StagedProgressable(&struct {
+			progress.Stager
+			progress.Progressable
+		}{
+			Stager:       stage,
+			Progressable: downloadProgress,
+		}),
+	})
+
+	log.WithFields("dir", dir, "from", cfg.LatestURL).Info("mirroring vulnerability database")
+	latest, err := client.Mirror(dir, downloadProgress)
+	if err != nil {
+		stage.Set("failed")
+		return fmt.Errorf("unable to mirror vulnerability database: %w", err)
+	}
+	stage.Set("mirrored")
+
+	abs, err := filepath.Abs(dir)
+	if err != nil {
+		abs = dir
+	}
+
+	bus.Report(fmt.Sprintf("Mirrored vulnerability database %s (built %s) to %s\nUse it as the update URL with: GRYPE_DB_UPDATE_URL=file://%s\n",
+		latest.SchemaVersion, latest.Built, dir, filepath.ToSlash(abs)))
+
+	return nil
+}
@@ -14,7 +14,9 @@ import (
 	v6 "github.com/anchore/grype/grype/db/v6"
 	"github.com/anchore/grype/grype/db/v6/distribution"
 	"github.com/anchore/grype/grype/db/v6/installation"
+	"github.com/anchore/grype/grype/db/v6/osv"
 	"github.com/anchore/grype/internal/bus"
+	"github.com/anchore/grype/internal/log"
 )
 
 type dbProvidersOptions struct {
@@ -72,6 +74,12 @@ func runDBProviders(opts *dbProvidersOptions) error {
 		return fmt.Errorf("unable to get providers: %w", err)
 	}
 
+	localProviders, err := localAdvisoryProviders(opts.ToLocalAdvisoriesConfig())
+	if err != nil {
+		return err
+	}
+	providerModels = append(providerModels, localProviders...)
+
 	sb := &strings.Builder{}
 
 	switch opts.Output {
@@ -93,6 +101,25 @@ func runDBProviders(opts *dbProvidersOptions) error {
 	return nil
 }
 
+// localAdvisoryProviders returns the providers of the configured local advisories, which are not part of the database
+func localAdvisoryProviders(cfg osv.OverlayConfig) ([]v6.Provider, error) {
+	if len(cfg.Dirs) == 0 {
+		return nil, nil
+	}
+
+	overlay, err := osv.NewOverlay(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("unable to load local advisories: %w", err)
+	}
+	defer log.CloseAndLogError(overlay, strings.Join(cfg.Dirs, ","))
+
+	providers, err := overlay.Reader().AllProviders()
+	if err != nil {
+		return nil, fmt.Errorf("unable to get local advisory providers: %w", err)
+	}
+	return providers, nil
+}
+
 type provider struct {
 	Name         string     `json:"name"`
 	Version      string     `json:"version"`
@@ -0,0 +1,114 @@
+package commands
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/scylladb/go-set/strset"
+	"github.com/spf13/cobra"
+
+	"github.com/anchore/clio"
+	"github.com/anchore/grype/grype/grypeerr"
+	"github.com/anchore/grype/grype/presenter/diff"
+	"github.com/anchore/grype/grype/presenter/models"
+	"github.com/anchore/grype/grype/vulnerability"
+	"github.com/anchore/grype/internal/log"
+)
+
+type diffOptions struct {
+	Output string `yaml:"output" json:"output" mapstructure:"output"`
+	FailOn string `yaml:"fail-on-severity" json:"fail-on-severity" mapstructure:"fail-on-severity"`
+}
+
+var _ interface {
+	clio.FlagAdder
+	clio.PostLoader
+} = (*diffOptions)(nil)
+
+func (d *diffOptions) AddFlags(flags clio.FlagSet) {
+	flags.StringVarP(&d.Output, "output", "o",
+		fmt.Sprintf("format to display the differences (available=[%s])", strings.Join(diff.AvailableFormats, ", ")))
+
+	flags.StringVarP(&d.FailOn, "fail-on", "f",
+		fmt.Sprintf("set the return code to 2 if a newly introduced vulnerability is found with a severity >= the given severity, options=%v", vulnerability.AllSeverities()))
+}
+
+func (d *diffOptions) PostLoad() error {
+	if !strset.New(diff.AvailableFormats...).Has(d.Output) {
+		return fmt.Errorf("invalid output format: %s (expected one of: %s)", d.Output, strings.Join(diff.AvailableFormats, ", "))
+	}
+	if d.FailOn != "" && vulnerability.ParseSeverity(d.FailOn) == vulnerability.UnknownSeverity {
+		return fmt.Errorf("bad --fail-on severity value '%s'", d.FailOn)
+	}
+	return nil
+}
+
+func Diff(app clio.Application) *cobra.Command {
+	opts := &diffOptions{
+		Output: diff.TableFormat,
+	}
+
+	cmd := &cobra.Command{
+		Use:   "diff [OLD-GRYPE-JSON] [NEW-GRYPE-JSON]",
+		Short: "Show the vulnerabilities that were added, removed or changed between two grype JSON reports",
+		Example: `  grype alpine:3.17 -o json > old.json
+  grype alpine:3.18 -o json > new.json
+  grype diff old.json new.json
+  grype diff old.json new.json -o markdown --fail-on high`,
+		Args:    cobra.ExactArgs(2),
+		PreRunE: disableUI(app),
+		RunE: func(_ *cobra.Command, args []string) error {
+			return runDiff(*opts, args[0], args[1])
+		},
+	}
+
+	// prevent from being shown in the grype config
+	type configWrapper struct {
+		Opts *diffOptions `json:"-" yaml:"-" mapstructure:"-"`
+	}
+
+	return app.SetupCommand(cmd, &configWrapper{opts})
+}
+
+func runDiff(opts diffOptions, beforePath, afterPath string) error {
+	before, err := readDocument(beforePath)
+	if err != nil {
+		return err
+	}
+
+	after, err := readDocument(afterPath)
+	if err != nil {
+		return err
+	}
+
+	report := diff.Compare(*before, *after)
+
+	if err := diff.NewPresenter(opts.Output, report).Present(os.Stdout); err != nil {
+		return fmt.Errorf("unable to present diff: %w", err)
+	}
+
+	if opts.FailOn != "" {
+		if added := report.AddedAtOrAbove(vulnerability.ParseSeverity(opts.FailOn)); len(added) > 0 {
+			log.WithFields("count", len(added), "severity", opts.FailOn).Debug("newly introduced vulnerabilities at or above the severity threshold")
+			return grypeerr.ErrAboveSeverityThreshold
+		}
+	}
+
+	return nil
+}
+
+func readDocument(path string) (*models.Document, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("unable to open grype report %q: %w", path, err)
+	}
+	defer log.CloseAndLogError(f, path)
+
+	var doc models.Document
+	if err := json.NewDecoder(f).Decode(&doc); err != nil {
+		return nil, fmt.Errorf("unable to parse grype report %q: %w", path, err)
+	}
+	return &doc, nil
+}
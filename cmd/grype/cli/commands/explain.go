@@ -4,35 +4,87 @@ import (
 	"encoding/json"
 	"fmt"
 	"os"
+	"path/filepath"
+	"slices"
 
 	"github.com/spf13/cobra"
 
 	"github.com/anchore/clio"
+	"github.com/anchore/grype/cmd/grype/cli/options"
+	v6 "github.com/anchore/grype/grype/db/v6"
+	"github.com/anchore/grype/grype/db/v6/installation"
+	"github.com/anchore/grype/grype/pkg"
 	"github.com/anchore/grype/grype/presenter/explain"
 	"github.com/anchore/grype/grype/presenter/models"
+	"github.com/anchore/grype/grype/vulnerability"
 	"github.com/anchore/grype/internal"
 	"github.com/anchore/grype/internal/log"
 )
 
 type explainOptions struct {
-	CVEIDs []string `yaml:"cve-ids" json:"cve-ids" mapstructure:"cve-ids"`
+	CVEIDs      []string `yaml:"cve-ids" json:"cve-ids" mapstructure:"cve-ids"`
+	Package     string   `yaml:"package" json:"package" mapstructure:"package"`
+	Severity    string   `yaml:"severity" json:"severity" mapstructure:"severity"`
+	ForPackages []string `yaml:"for-packages" json:"for-packages" mapstructure:"for-packages"`
+	FixStates   []string `yaml:"fix-states" json:"fix-states" mapstructure:"fix-states"`
+	KEV         bool     `yaml:"kev" json:"kev" mapstructure:"kev"`
+	Matchers    []string `yaml:"matchers" json:"matchers" mapstructure:"matchers"`
 }
 
-var _ clio.FlagAdder = (*explainOptions)(nil)
+var _ interface {
+	clio.FlagAdder
+	clio.PostLoader
+} = (*explainOptions)(nil)
 
 func (d *explainOptions) AddFlags(flags clio.FlagSet) {
 	flags.StringArrayVarP(&d.CVEIDs, "id", "", "CVE IDs to explain")
+	flags.StringVarP(&d.Package, "package", "", "a package URL to trace the matching of the given IDs against the database, explaining why they were (or were not) matched")
+	flags.StringVarP(&d.Severity, "severity", "", fmt.Sprintf("explain findings with a severity >= the given severity, options=%v", vulnerability.AllSeverities()))
+	flags.StringArrayVarP(&d.ForPackages, "for-package", "", "explain findings on packages with the given name or package URL (a package URL without a version selects every version)")
+	flags.StringArrayVarP(&d.FixStates, "fix-state", "", fmt.Sprintf("explain findings with the given fix state, options=%v", vulnerability.AllFixStates()))
+	flags.BoolVarP(&d.KEV, "kev", "", "explain only findings for known exploited vulnerabilities (in the CISA KEV catalog)")
+	flags.StringArrayVarP(&d.Matchers, "matcher", "", "explain findings made by the given matcher (e.g. dpkg-matcher, java-matcher)")
+}
+
+func (d *explainOptions) PostLoad() error {
+	return d.filter().Validate()
+}
+
+func (d *explainOptions) filter() explain.Filter {
+	return explain.Filter{
+		Severity:  d.Severity,
+		Packages:  d.ForPackages,
+		FixStates: d.FixStates,
+		KEV:       d.KEV,
+		Matchers:  d.Matchers,
+	}
 }
 
 func Explain(app clio.Application) *cobra.Command {
 	opts := &explainOptions{}
+	grypeOpts := options.DefaultGrype(app.ID())
 
 	cmd := &cobra.Command{
-		Use:     "explain --id [VULNERABILITY ID]",
-		Short:   "Ask grype to explain a set of findings",
+		Use:   "explain --id [VULNERABILITY ID]",
+		Short: "Ask grype to explain a set of findings",
+		Long: `Ask grype to explain a set of findings from a grype JSON report given on stdin.
+
+Findings are selected by --id, or otherwise every finding is explained, and can be narrowed down further with
+--severity, --for-package, --fix-state, --kev and --matcher. Use -o json for the findings along with the evidence
+of each match (match details, locations and related vulnerabilities) in a machine-readable form.
+
+With --package, the matchers are run for the given package URL against the database instead, tracing each decision
+made for the records of the given vulnerability IDs to explain why they were (or were not) matched. Use -o json for
+a machine-readable trace.`,
+		Example: `  grype alpine:latest -o json | grype explain --id CVE-2023-5678
+  grype alpine:latest -o json | grype explain --severity high --fix-state fixed -o json
+  grype explain --package 'pkg:deb/debian/openssl@3.0.11-1~deb12u2?distro=debian-12' --id CVE-2023-5678`,
 		PreRunE: disableUI(app),
 		RunE: func(_ *cobra.Command, _ []string) error {
 			log.Warn("grype explain is a prototype feature and is subject to change")
+			if opts.Package != "" {
+				return runExplainTrace(grypeOpts, opts)
+			}
 			isStdinPipeOrRedirect, err := internal.IsStdinPipeOrRedirect()
 			if err != nil {
 				log.Warnf("unable to determine if there is piped input: %+v", err)
@@ -46,12 +98,22 @@ func Explain(app clio.Application) *cobra.Command {
 				if err != nil {
 					return fmt.Errorf("unable to parse piped input: %+v", err)
 				}
-				explainer := explain.NewVulnerabilityExplainer(os.Stdout, &parseResult)
-				return explainer.ExplainByID(opts.CVEIDs)
+				explainer := explain.NewVulnerabilityExplainerWithConfig(os.Stdout, &parseResult, explain.Config{
+					Filter: opts.filter(),
+					JSON:   slices.Contains(grypeOpts.Outputs, "json"),
+				})
+				switch {
+				case len(opts.CVEIDs) > 0:
+					return explainer.ExplainByID(opts.CVEIDs)
+				case opts.Severity != "":
+					return explainer.ExplainBySeverity(opts.Severity)
+				default:
+					return explainer.ExplainAll()
+				}
 			}
 			// perform a scan, then explain requested CVEs
 			// TODO: implement
-			return fmt.Errorf("requires grype json on stdin, please run 'grype -o json ... | grype explain ...'")
+			return fmt.Errorf("requires grype json on stdin, please run 'grype -o json ... | grype explain ...', or a package with --package")
 		},
 	}
 
@@ -60,5 +122,97 @@ func Explain(app clio.Application) *cobra.Command {
 		Opts *explainOptions `json:"-" yaml:"-" mapstructure:"-"`
 	}
 
-	return app.SetupCommand(cmd, &configWrapper{opts})
+	return app.SetupCommand(cmd, grypeOpts, &configWrapper{opts})
+}
+
+// runExplainTrace runs the matchers for a single package against the database, explaining how each of the requested
+// vulnerabilities was (or was not) matched
+//
+//nolint:funlen
+func runExplainTrace(opts *options.Grype, explainOpts *explainOptions) error {
+	if len(explainOpts.CVEIDs) == 0 {
+		return fmt.Errorf("at least one vulnerability ID must be given with --id")
+	}
+
+	// explaining a match should never fail because of it
+	opts.DisableFailThresholds()
+
+	if err := applyIgnoreRules(opts); err != nil {
+		return err
+	}
+
+	ignoreFilters, err := getIgnoreFilters(opts)
+	if err != nil {
+		return err
+	}
+
+	if err = applyVexRules(opts); err != nil {
+		return fmt.Errorf("applying vex rules: %w", err)
+	}
+
+	packages, pkgContext, _, err := pkg.Provide(explainOpts.Package, getProviderConfig(opts))
+	if err != nil {
+		return fmt.Errorf("unable to read package %q: %w", explainOpts.Package, err)
+	}
+	if len(packages) != 1 {
+		return fmt.Errorf("expected a single package from %q, found %d", explainOpts.Package, len(packages))
+	}
+
+	applyDistroHint(packages, &pkgContext, opts)
+
+	vp, status, err := loadVulnerabilityDB(opts)
+	if err = validateDBLoad(err, status); err != nil {
+		return err
+	}
+	defer log.CloseAndLogError(vp, status.Path)
+
+	reader, err := openTraceReader(opts.ToCuratorConfig(), status.Path)
+	if err != nil {
+		return err
+	}
+	defer log.CloseAndLogError(reader, status.Path)
+
+	// records are traced the same way the package is matched, which defaults to the distro of the scan context
+	p := packages[0]
+	tracedPkg := p
+	if tracedPkg.Distro == nil {
+		tracedPkg.Distro = pkgContext.Distro
+	}
+
+	vulnMatcher := getVulnerabilityMatcher(opts, vp, ignoreFilters)
+
+	var traces []explain.Trace
+	for _, id := range explainOpts.CVEIDs {
+		records, err := v6.TraceAffected(reader, tracedPkg, id)
+		if err != nil {
+			return err
+		}
+
+		matchTrace, err := vulnMatcher.TraceMatches(p, pkgContext, id)
+		if err != nil {
+			return fmt.Errorf("unable to trace matches for %q: %w", id, err)
+		}
+
+		traces = append(traces, explain.NewTrace(tracedPkg, id, records, *matchTrace))
+	}
+
+	if slices.Contains(opts.Outputs, "json") {
+		return explain.WriteTraceJSON(os.Stdout, traces...)
+	}
+	for _, t := range traces {
+		if err := explain.WriteTrace(os.Stdout, t); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// openTraceReader opens the loaded database to trace records from, with the same options as it is matched with (such
+// as package aliases) so that the records traced are those the matchers find
+func openTraceReader(cfg installation.Config, dbFilePath string) (v6.Reader, error) {
+	reader, err := v6.NewReader(cfg.ReaderConfig(filepath.Dir(dbFilePath)))
+	if err != nil {
+		return nil, fmt.Errorf("unable to open database: %w", err)
+	}
+	return reader, nil
 }
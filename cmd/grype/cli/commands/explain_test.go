@@ -0,0 +1,91 @@
+package commands
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	v6 "github.com/anchore/grype/grype/db/v6"
+	"github.com/anchore/grype/grype/db/v6/installation"
+	"github.com/anchore/grype/grype/pkg"
+	syftPkg "github.com/anchore/syft/syft/pkg"
+)
+
+// acmeNpmAlias searches the (internal) acme-npm ecosystem as npm
+var acmeNpmAlias = v6.PackageSpecifierAlias{Ecosystem: "acme-npm", ReplacementEcosystem: string(syftPkg.NpmPkg)}
+
+func TestOpenTraceReader_PackageAliases(t *testing.T) {
+	cfg := installation.Config{DBRootDir: t.TempDir()}
+	writePackageAliasTestDB(t, cfg.DBDirectoryPath())
+
+	p := pkg.Package{
+		Name:    "lodash",
+		Version: "4.17.15",
+		Type:    "acme-npm",
+	}
+
+	tests := []struct {
+		name    string
+		aliases []v6.PackageSpecifierAlias
+		passed  bool
+	}{
+		{
+			name:   "without aliases",
+			passed: false,
+		},
+		{
+			name:    "with aliases",
+			aliases: []v6.PackageSpecifierAlias{acmeNpmAlias},
+			passed:  true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg.PackageAliases = tt.aliases
+			reader, err := openTraceReader(cfg, cfg.DBFilePath())
+			require.NoError(t, err)
+			defer reader.Close()
+
+			traces, err := v6.TraceAffected(reader, p, "GHSA-test")
+			require.NoError(t, err)
+			require.Len(t, traces, 1)
+
+			// the record is only within the ecosystem searched when the package ecosystem is aliased
+			var ecosystem []v6.TraceDecision
+			for _, d := range traces[0].Decisions {
+				if d.Stage == v6.EcosystemTraceStage {
+					ecosystem = append(ecosystem, d)
+				}
+			}
+			require.Len(t, ecosystem, 1)
+			assert.Equal(t, tt.passed, ecosystem[0].Passed, ecosystem[0].Detail)
+		})
+	}
+}
+
+// writePackageAliasTestDB writes an (indexed) DB to the given directory with a vulnerability for the npm package lodash
+func writePackageAliasTestDB(t *testing.T, dir string) {
+	t.Helper()
+	w, err := v6.NewWriter(v6.Config{DBDirPath: dir})
+	require.NoError(t, err)
+
+	vuln := &v6.VulnerabilityHandle{
+		Name:       "GHSA-test",
+		ProviderID: "github",
+		Provider:   &v6.Provider{ID: "github", Version: "1"},
+		BlobValue:  &v6.VulnerabilityBlob{ID: "GHSA-test"},
+	}
+	require.NoError(t, w.AddVulnerabilities(vuln))
+	require.NoError(t, w.AddAffectedPackages(&v6.AffectedPackageHandle{
+		Vulnerability: vuln,
+		Package:       &v6.Package{Ecosystem: string(syftPkg.NpmPkg), Name: "lodash"},
+		BlobValue: &v6.AffectedPackageBlob{
+			Ranges: []v6.AffectedRange{{Version: v6.AffectedVersion{Type: "semver", Constraint: "< 4.17.21"}}},
+		},
+	}))
+	require.NoError(t, w.Close())
+
+	// closing the writer drops all indexes, which are needed for searching
+	require.NoError(t, v6.Hydrater()(dir))
+}
@@ -0,0 +1,20 @@
+package commands
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/anchore/clio"
+)
+
+func Ignore(app clio.Application) *cobra.Command {
+	ignore := &cobra.Command{
+		Use:   "ignore",
+		Short: "ignore rule operations",
+	}
+
+	ignore.AddCommand(
+		IgnoreAudit(app),
+	)
+
+	return ignore
+}
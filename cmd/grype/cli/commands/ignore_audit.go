@@ -0,0 +1,209 @@
+package commands
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/anchore/clio"
+	"github.com/anchore/grype/grype/match"
+	"github.com/anchore/grype/grype/presenter/models"
+	"github.com/anchore/grype/internal"
+	"github.com/anchore/grype/internal/bus"
+	"github.com/anchore/grype/internal/log"
+)
+
+const (
+	ignoreRuleExpired  = "expired"
+	ignoreRuleExpiring = "expiring"
+	ignoreRuleUnused   = "unused"
+)
+
+type ignoreAuditOptions struct {
+	Output         string `yaml:"output" json:"output" mapstructure:"output"`
+	Report         string `yaml:"report" json:"report" mapstructure:"report"`
+	ExpiringWithin int    `yaml:"expiring-within" json:"expiring-within" mapstructure:"expiring-within"`
+}
+
+var _ clio.FlagAdder = (*ignoreAuditOptions)(nil)
+
+func (o *ignoreAuditOptions) AddFlags(flags clio.FlagSet) {
+	flags.StringVarP(&o.Output, "output", "o", "format to display results (available=[table, json])")
+	flags.StringVarP(&o.Report, "report", "", "grype JSON report from the last scan, used to find rules that did not match anything (also read from stdin)")
+	flags.IntVarP(&o.ExpiringWithin, "expiring-within", "", "number of days within which a rule is considered to be about to expire")
+}
+
+func IgnoreAudit(app clio.Application) *cobra.Command {
+	opts := &ignoreAuditOptions{
+		Output:         tableOutputFormat,
+		ExpiringWithin: 30,
+	}
+
+	cfg := &struct {
+		Hidden *ignoreAuditOptions `json:"-" yaml:"-" mapstructure:"-"`
+		Ignore []match.IgnoreRule  `yaml:"ignore" json:"ignore" mapstructure:"ignore"`
+	}{
+		Hidden: opts,
+	}
+
+	cmd := &cobra.Command{
+		Use:   "audit",
+		Short: "List configured ignore rules that are expired, about to expire, or did not match anything in the last scan",
+		Example: `  grype alpine:latest -o json > report.json
+  grype ignore audit --report report.json`,
+		Args:    cobra.ExactArgs(0),
+		PreRunE: disableUI(app),
+		RunE: func(_ *cobra.Command, _ []string) error {
+			doc, err := readAuditReport(opts.Report)
+			if err != nil {
+				return err
+			}
+			return runIgnoreAudit(opts, cfg.Ignore, doc)
+		},
+	}
+
+	return app.SetupCommand(cmd, cfg)
+}
+
+// ignoreRuleAudit describes the problems found with a configured ignore rule
+type ignoreRuleAudit struct {
+	Issues []string          `json:"issues"`
+	Rule   models.IgnoreRule `json:"rule"`
+}
+
+func runIgnoreAudit(opts *ignoreAuditOptions, rules []match.IgnoreRule, doc *models.Document) error {
+	audits := auditIgnoreRules(rules, doc, time.Now(), time.Duration(opts.ExpiringWithin)*24*time.Hour)
+
+	sb := &strings.Builder{}
+
+	switch opts.Output {
+	case tableOutputFormat, textOutputFormat:
+		if err := displayIgnoreAuditTable(audits, sb); err != nil {
+			return err
+		}
+	case jsonOutputFormat:
+		if err := displayIgnoreAuditJSON(audits, sb); err != nil {
+			return err
+		}
+	default:
+		return fmt.Errorf("unsupported output format: %s", opts.Output)
+	}
+	bus.Report(sb.String())
+
+	return nil
+}
+
+// auditIgnoreRules returns the rules that have expired, will expire within the given window, or (when a report
+// from the last scan is available) were not applied to any ignored match.
+func auditIgnoreRules(rules []match.IgnoreRule, doc *models.Document, now time.Time, within time.Duration) []ignoreRuleAudit {
+	audits := []ignoreRuleAudit{}
+	for _, r := range rules {
+		var issues []string
+
+		switch expiration, err := r.Expiration(); {
+		case err != nil || r.IsExpired(now):
+			issues = append(issues, ignoreRuleExpired)
+		case !expiration.IsZero() && expiration.Before(now.Add(within)):
+			issues = append(issues, ignoreRuleExpiring)
+		}
+
+		if doc != nil && !r.IsExpired(now) && !ignoreRuleApplied(r, doc) {
+			issues = append(issues, ignoreRuleUnused)
+		}
+
+		if len(issues) == 0 {
+			continue
+		}
+
+		audits = append(audits, ignoreRuleAudit{
+			Issues: issues,
+			Rule:   models.NewIgnoreRule(r),
+		})
+	}
+	return audits
+}
+
+func ignoreRuleApplied(r match.IgnoreRule, doc *models.Document) bool {
+	want := models.NewIgnoreRule(r)
+	for _, m := range doc.IgnoredMatches {
+		for _, applied := range m.AppliedIgnoreRules {
+			if want.Namespace == "" {
+				// the namespace is set on VEX rules when they are applied
+				applied.Namespace = ""
+			}
+			if reflect.DeepEqual(want, applied) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func readAuditReport(path string) (*models.Document, error) {
+	if path != "" {
+		return readDocument(path)
+	}
+
+	isStdinPipeOrRedirect, err := internal.IsStdinPipeOrRedirect()
+	if err != nil {
+		log.Warnf("unable to determine if there is piped input: %+v", err)
+		isStdinPipeOrRedirect = false
+	}
+	if !isStdinPipeOrRedirect {
+		log.Debug("no grype report provided, unused ignore rules will not be reported")
+		return nil, nil
+	}
+
+	var doc models.Document
+	if err := json.NewDecoder(os.Stdin).Decode(&doc); err != nil {
+		return nil, fmt.Errorf("unable to parse piped input: %w", err)
+	}
+	return &doc, nil
+}
+
+func displayIgnoreAuditTable(audits []ignoreRuleAudit, output io.Writer) error {
+	if len(audits) == 0 {
+		_, err := io.WriteString(output, "No ignore rules need attention\n")
+		return err
+	}
+
+	rows := [][]string{}
+	for _, a := range audits {
+		var pkgName string
+		if a.Rule.Package != nil {
+			pkgName = a.Rule.Package.Name
+		}
+		rows = append(rows, []string{
+			strings.Join(a.Issues, ", "),
+			a.Rule.Vulnerability,
+			pkgName,
+			a.Rule.Expires,
+			a.Rule.Owner,
+			a.Rule.Ticket,
+			a.Rule.Reason,
+		})
+	}
+
+	table := newTable(output, []string{"Issues", "Vulnerability", "Package", "Expires", "Owner", "Ticket", "Reason"})
+
+	if err := table.Bulk(rows); err != nil {
+		return fmt.Errorf("failed to add table rows: %w", err)
+	}
+	return table.Render()
+}
+
+func displayIgnoreAuditJSON(audits []ignoreRuleAudit, output io.Writer) error {
+	encoder := json.NewEncoder(output)
+	encoder.SetEscapeHTML(false)
+	encoder.SetIndent("", " ")
+	if err := encoder.Encode(audits); err != nil {
+		return fmt.Errorf("cannot display json: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,75 @@
+package commands
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/anchore/grype/grype/match"
+	"github.com/anchore/grype/grype/presenter/models"
+)
+
+func TestAuditIgnoreRules(t *testing.T) {
+	now := time.Date(2024, 6, 15, 12, 0, 0, 0, time.UTC)
+	within := 30 * 24 * time.Hour
+
+	expired := match.IgnoreRule{Vulnerability: "CVE-2024-0001", Expires: "2024-06-01", Owner: "alice"}
+	expiring := match.IgnoreRule{Vulnerability: "CVE-2024-0002", Expires: "2024-07-01"}
+	healthy := match.IgnoreRule{Vulnerability: "CVE-2024-0003", Expires: "2025-01-01"}
+	unused := match.IgnoreRule{Package: match.IgnoreRulePackage{Name: "libfoo"}}
+	vexRule := match.IgnoreRule{VexStatus: "not_affected"}
+
+	rules := []match.IgnoreRule{expired, expiring, healthy, unused, vexRule}
+
+	applied := func(r match.IgnoreRule) models.IgnoredMatch {
+		return models.IgnoredMatch{AppliedIgnoreRules: []models.IgnoreRule{models.NewIgnoreRule(r)}}
+	}
+
+	appliedVEX := vexRule
+	appliedVEX.Namespace = "vex"
+
+	doc := &models.Document{
+		IgnoredMatches: []models.IgnoredMatch{
+			applied(expiring),
+			applied(healthy),
+			applied(appliedVEX),
+		},
+	}
+
+	tests := []struct {
+		name string
+		doc  *models.Document
+		want map[string][]string
+	}{
+		{
+			name: "without a report",
+			want: map[string][]string{
+				"CVE-2024-0001": {ignoreRuleExpired},
+				"CVE-2024-0002": {ignoreRuleExpiring},
+			},
+		},
+		{
+			name: "with a report",
+			doc:  doc,
+			want: map[string][]string{
+				"CVE-2024-0001": {ignoreRuleExpired},
+				"CVE-2024-0002": {ignoreRuleExpiring},
+				"libfoo":        {ignoreRuleUnused},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := map[string][]string{}
+			for _, a := range auditIgnoreRules(rules, tt.doc, now, within) {
+				k := a.Rule.Vulnerability
+				if k == "" && a.Rule.Package != nil {
+					k = a.Rule.Package.Name
+				}
+				got[k] = a.Issues
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,221 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/anchore/clio"
+	"github.com/anchore/grype/cmd/grype/cli/options"
+	v6 "github.com/anchore/grype/grype/db/v6"
+	"github.com/anchore/grype/grype/db/v6/differ"
+	"github.com/anchore/grype/grype/db/v6/distribution"
+	"github.com/anchore/grype/grype/match"
+	"github.com/anchore/grype/grype/pkg"
+	"github.com/anchore/grype/grype/policy"
+	"github.com/anchore/grype/grype/presenter/models"
+	"github.com/anchore/grype/grype/rescan"
+	"github.com/anchore/grype/grype/vulnerability"
+	"github.com/anchore/grype/internal/format"
+	"github.com/anchore/grype/internal/log"
+)
+
+func Rescan(app clio.Application) *cobra.Command {
+	opts := options.DefaultGrype(app.ID())
+
+	cfg := &struct {
+		Rescan options.Rescan `yaml:"rescan" json:"rescan" mapstructure:"rescan"`
+	}{
+		Rescan: options.DefaultRescan(),
+	}
+
+	cmd := &cobra.Command{
+		Use:   "rescan PREVIOUS-REPORT SBOM",
+		Short: "Update a previous grype JSON report by re-evaluating only the packages touched by database changes",
+		Long: `Update a previous grype JSON report by re-evaluating only the packages touched by database changes.
+
+The database the previous report was produced with (given by --previous-db) is compared with the current database,
+and only the packages of the SBOM that are touched by changed affected package or CPE records, as well as packages
+previously matched to a changed vulnerability, KEV, or EPSS record, are matched again. All other matches of the
+previous report are kept as-is. The build time of the previous database must match the database recorded in the
+descriptor of the previous report.
+
+Fail thresholds (--fail-on and friends) are not applied, since only part of the SBOM is re-evaluated.`,
+		Example: `  grype sbom:sbom.json -o json > report.json
+  cp -r ~/.cache/grype/db/6 previous-db
+  grype db update
+  grype rescan report.json sbom:sbom.json --previous-db previous-db -o json > updated.json`,
+		Args: cobra.ExactArgs(2),
+		RunE: func(_ *cobra.Command, args []string) error {
+			return runRescan(app, opts, cfg.Rescan, args[0], args[1])
+		},
+	}
+
+	return app.SetupCommand(cmd, opts, cfg)
+}
+
+//nolint:funlen
+func runRescan(app clio.Application, opts *options.Grype, rescanOpts options.Rescan, reportPath, userInput string) error {
+	if rescanOpts.PreviousDB == "" {
+		return fmt.Errorf("the database the previous report was produced with must be given with --previous-db")
+	}
+
+	// only part of the SBOM is re-evaluated, so fail thresholds cannot be applied accurately
+	opts.DisableFailThresholds()
+
+	writer, err := format.MakeScanResultWriter(opts.Outputs, opts.File, format.PresentationConfig{
+		TemplateFilePath: opts.OutputTemplateFile,
+		ShowSuppressed:   opts.ShowSuppressed,
+		Pretty:           opts.Pretty,
+	})
+	if err != nil {
+		return err
+	}
+
+	previous, err := rescan.ReadDocument(reportPath)
+	if err != nil {
+		return err
+	}
+
+	previousBuilt, err := rescan.DBBuilt(*previous)
+	if err != nil {
+		return fmt.Errorf("unable to rescan %q: %w", reportPath, err)
+	}
+
+	if err = applyIgnoreRules(opts); err != nil {
+		return err
+	}
+
+	ignoreFilters, err := getIgnoreFilters(opts)
+	if err != nil {
+		return err
+	}
+
+	var pol *policy.Policy
+	if opts.Policy != "" {
+		pol, err = policy.Read(opts.Policy)
+		if err != nil {
+			return err
+		}
+	}
+
+	vp, status, err := loadVulnerabilityDB(opts)
+	if err = validateDBLoad(err, status); err != nil {
+		return err
+	}
+	defer log.CloseAndLogError(vp, status.Path)
+
+	changes, err := rescanChanges(opts, rescanOpts.PreviousDB, previousBuilt, status)
+	if err != nil {
+		return err
+	}
+
+	packages, pkgContext, s, err := pkg.Provide(userInput, getProviderConfig(opts))
+	if err != nil {
+		return fmt.Errorf("failed to catalog: %w", err)
+	}
+
+	if err = applyVexRules(opts); err != nil {
+		return fmt.Errorf("applying vex rules: %w", err)
+	}
+
+	applyDistroHint(packages, &pkgContext, opts)
+
+	selected := changes.Select(packages, *previous)
+	log.WithFields("packages", len(packages), "reevaluated", len(selected)).Info("selected packages touched by database changes")
+
+	remainingMatches := &match.Matches{}
+	var ignoredMatches []match.IgnoredMatch
+	if len(selected) > 0 {
+		startTime := time.Now()
+		vulnMatcher := getVulnerabilityMatcher(opts, vp, ignoreFilters)
+		remainingMatches, ignoredMatches, err = vulnMatcher.FindMatches(selected, pkgContext)
+		if err != nil {
+			return err
+		}
+		log.WithFields("time", time.Since(startTime)).Info("found vulnerability matches")
+	}
+
+	strategy := models.SortStrategy(opts.SortBy.Criteria)
+	updated, err := models.NewDocument(app.ID(), selected, pkgContext, *remainingMatches, ignoredMatches, vp, opts, dbInfo(status, vp), strategy)
+	if err != nil {
+		return fmt.Errorf("failed to create document: %w", err)
+	}
+
+	model := rescan.Merge(*previous, updated, selected, strategy)
+
+	var errs error
+	if pol != nil {
+		if err = applyPolicy(*pol, &model); err != nil {
+			errs = appendErrors(errs, err)
+		}
+	}
+
+	if err = writer.Write(models.PresenterConfig{
+		ID:       app.ID(),
+		Document: model,
+		SBOM:     s,
+		Pretty:   opts.Pretty,
+	}); err != nil {
+		errs = appendErrors(errs, err)
+	}
+
+	return errs
+}
+
+// rescanChanges compares the database the previous report was produced with to the current database
+func rescanChanges(opts *options.Grype, previousDB string, previousBuilt time.Time, status *vulnerability.ProviderStatus) (rescan.Changes, error) {
+	if status.Built.Truncate(time.Second).Equal(previousBuilt.Truncate(time.Second)) {
+		log.WithFields("built", previousBuilt).Info("the database has not changed since the previous report")
+		return rescan.NewChanges(differ.Diff{}), nil
+	}
+
+	client, err := distribution.NewClient(opts.ToClientConfig())
+	if err != nil {
+		return rescan.Changes{}, fmt.Errorf("unable to create distribution client: %w", err)
+	}
+
+	tempDir, err := os.MkdirTemp("", "grype-rescan")
+	if err != nil {
+		return rescan.Changes{}, fmt.Errorf("unable to create temp dir: %w", err)
+	}
+	defer func() {
+		if err := os.RemoveAll(tempDir); err != nil {
+			log.WithFields("error", err, "dir", tempDir).Warn("unable to remove temp dir")
+		}
+	}()
+
+	base, err := openDiffDatabase(client, previousDB, filepath.Join(tempDir, "previous"))
+	if err != nil {
+		return rescan.Changes{}, fmt.Errorf("unable to open previous database: %w", err)
+	}
+	defer log.CloseAndLogError(base, previousDB)
+
+	meta, err := base.GetDBMetadata()
+	if err != nil {
+		return rescan.Changes{}, fmt.Errorf("unable to read previous database metadata: %w", err)
+	}
+	if baseBuilt := v6.DescriptionFromMetadata(meta).Built; !baseBuilt.Truncate(time.Second).Equal(previousBuilt.Truncate(time.Second)) {
+		return rescan.Changes{}, fmt.Errorf("the previous database was built at %s, but the previous report was produced with a database built at %s",
+			baseBuilt.UTC().Format(time.RFC3339), previousBuilt.UTC().Format(time.RFC3339))
+	}
+
+	target, err := v6.NewReader(v6.Config{DBDirPath: filepath.Dir(status.Path)})
+	if err != nil {
+		return rescan.Changes{}, fmt.Errorf("unable to open current database: %w", err)
+	}
+	defer log.CloseAndLogError(target, status.Path)
+
+	diff, err := differ.Compare(base, target)
+	if err != nil {
+		return rescan.Changes{}, fmt.Errorf("unable to diff databases: %w", err)
+	}
+
+	log.WithFields("vulnerabilities", len(diff.Vulnerabilities), "affectedPackages", len(diff.AffectedPackages), "affectedCPEs", len(diff.AffectedCPEs)).
+		Info("compared databases")
+
+	return rescan.NewChanges(*diff), nil
+}
@@ -1,7 +1,6 @@
 package commands
 
 import (
-	"errors"
 	"fmt"
 	"strings"
 	"time"
@@ -12,6 +11,9 @@ import (
 	"github.com/anchore/clio"
 	"github.com/anchore/grype/cmd/grype/cli/options"
 	"github.com/anchore/grype/grype"
+	"github.com/anchore/grype/grype/baseline"
+	"github.com/anchore/grype/grype/db/v6/distribution"
+	"github.com/anchore/grype/grype/db/v6/installation"
 	"github.com/anchore/grype/grype/distro"
 	"github.com/anchore/grype/grype/event"
 	"github.com/anchore/grype/grype/event/parsers"
@@ -26,6 +28,7 @@ import (
 	"github.com/anchore/grype/grype/matcher/ruby"
 	"github.com/anchore/grype/grype/matcher/stock"
 	"github.com/anchore/grype/grype/pkg"
+	"github.com/anchore/grype/grype/policy"
 	"github.com/anchore/grype/grype/presenter/models"
 	"github.com/anchore/grype/grype/vex"
 	"github.com/anchore/grype/grype/vulnerability"
@@ -43,8 +46,14 @@ import (
 func Root(app clio.Application) *cobra.Command {
 	opts := options.DefaultGrype(app.ID())
 
+	cfg := &struct {
+		Targets options.Targets `yaml:"targets" json:"targets" mapstructure:"targets"`
+	}{
+		Targets: options.DefaultTargets(),
+	}
+
 	return app.SetupRootCommand(&cobra.Command{
-		Use:   fmt.Sprintf("%s [IMAGE]", app.ID().Name),
+		Use:   fmt.Sprintf("%s [IMAGE...]", app.ID().Name),
 		Short: "A vulnerability scanner for container images, filesystems, and SBOMs",
 		Long: stringutil.Tprintf(`A vulnerability scanner for container images, filesystems, and SBOMs.
 
@@ -70,21 +79,41 @@ You can also explicitly specify the scheme to use:
 You can also pipe in Syft JSON directly:
 	syft yourimage:tag -o json | {{.appName}}
 
+Multiple targets can be scanned in a single invocation, sharing the vulnerability database:
+    {{.appName}} alpine:3.20 debian:12                   scan several targets, reporting the results together
+    {{.appName}} 'sbom:sboms/*.json'                     scan every SBOM matching a glob pattern
+    {{.appName}} --targets-file targets.txt              scan the targets listed in a file, one per line
+    {{.appName}} 'sbom:sboms/*.json' --target-output 'reports/{{"{{"}}.Name{{"}}"}}.json' -o json
+                                                  write one report per target, with a summary of all targets
+
 `, map[string]interface{}{
 			"appName": app.ID().Name,
 		}),
-		Args:          validateRootArgs,
+		Args: func(cmd *cobra.Command, args []string) error {
+			if len(args) == 0 && cfg.Targets.File != "" {
+				return nil
+			}
+			return validateRootArgs(cmd, args)
+		},
 		SilenceUsage:  true,
 		SilenceErrors: true,
 		RunE: func(_ *cobra.Command, args []string) error {
+			targets, err := expandTargets(args, cfg.Targets.File)
+			if err != nil {
+				return err
+			}
+			if len(targets) > 1 || cfg.Targets.Output != "" {
+				return runGrypeTargets(app, opts, cfg.Targets, targets)
+			}
+
 			userInput := ""
-			if len(args) > 0 {
-				userInput = args[0]
+			if len(targets) > 0 {
+				userInput = targets[0]
 			}
 			return runGrype(app, opts, userInput)
 		},
 		ValidArgsFunction: dockerImageValidArgsFunction,
-	}, opts)
+	}, opts, cfg)
 }
 
 var ignoreNonFixedMatches = []match.IgnoreRule{
@@ -125,24 +154,20 @@ func runGrype(app clio.Application, opts *options.Grype, userInput string) (errs
 	var s *sbom.SBOM
 	var pkgContext pkg.Context
 
-	if opts.OnlyFixed {
-		opts.Ignore = append(opts.Ignore, ignoreNonFixedMatches...)
-	}
-
-	if opts.OnlyNotFixed {
-		opts.Ignore = append(opts.Ignore, ignoreFixedMatches...)
+	if err = applyIgnoreRules(opts); err != nil {
+		return err
 	}
 
-	if !opts.MatchUpstreamKernelHeaders {
-		opts.Ignore = append(opts.Ignore, ignoreLinuxKernelHeaders...)
+	ignoreFilters, err := getIgnoreFilters(opts)
+	if err != nil {
+		return err
 	}
 
-	for _, ignoreState := range stringutil.SplitCommaSeparatedString(opts.IgnoreStates) {
-		switch vulnerability.FixState(ignoreState) {
-		case vulnerability.FixStateUnknown, vulnerability.FixStateFixed, vulnerability.FixStateNotFixed, vulnerability.FixStateWontFix:
-			opts.Ignore = append(opts.Ignore, match.IgnoreRule{FixState: ignoreState})
-		default:
-			return fmt.Errorf("unknown fix state %s was supplied for --ignore-states", ignoreState)
+	var pol *policy.Policy
+	if opts.Policy != "" {
+		pol, err = policy.Read(opts.Policy)
+		if err != nil {
+			return err
 		}
 	}
 
@@ -169,7 +194,7 @@ func runGrype(app clio.Application, opts *options.Grype, userInput string) (errs
 			}()
 
 			log.Debug("loading DB")
-			vp, status, err = grype.LoadVulnerabilityDB(opts.ToClientConfig(), opts.ToCuratorConfig(), opts.DB.AutoUpdate)
+			vp, status, err = loadVulnerabilityDB(opts)
 
 			return validateDBLoad(err, status)
 		},
@@ -206,21 +231,11 @@ func runGrype(app clio.Application, opts *options.Grype, userInput string) (errs
 	startTime := time.Now()
 	applyDistroHint(packages, &pkgContext, opts)
 
-	vulnMatcher := grype.VulnerabilityMatcher{
-		VulnerabilityProvider: vp,
-		IgnoreRules:           opts.Ignore,
-		NormalizeByCVE:        opts.ByCVE,
-		FailSeverity:          opts.FailOnSeverity(),
-		Matchers:              getMatchers(opts),
-		VexProcessor: vex.NewProcessor(vex.ProcessorOptions{
-			Documents:   opts.VexDocuments,
-			IgnoreRules: opts.Ignore,
-		}),
-	}
+	vulnMatcher := getVulnerabilityMatcher(opts, vp, ignoreFilters)
 
 	remainingMatches, ignoredMatches, err := vulnMatcher.FindMatches(packages, pkgContext)
 	if err != nil {
-		if !errors.Is(err, grypeerr.ErrAboveSeverityThreshold) {
+		if !grypeerr.IsAboveFailThreshold(err) {
 			return err
 		}
 		errs = appendErrors(errs, err)
@@ -234,6 +249,12 @@ func runGrype(app clio.Application, opts *options.Grype, userInput string) (errs
 		return fmt.Errorf("failed to create document: %w", err)
 	}
 
+	if pol != nil {
+		if err = applyPolicy(*pol, &model); err != nil {
+			errs = appendErrors(errs, err)
+		}
+	}
+
 	if err = writer.Write(models.PresenterConfig{
 		ID:       app.ID(),
 		Document: model,
@@ -248,27 +269,69 @@ func runGrype(app clio.Application, opts *options.Grype, userInput string) (errs
 	return errs
 }
 
+func getVulnerabilityMatcher(opts *options.Grype, vp vulnerability.Provider, ignoreFilters []match.IgnoreFilter) grype.VulnerabilityMatcher {
+	return grype.VulnerabilityMatcher{
+		VulnerabilityProvider: vp,
+		IgnoreRules:           opts.Ignore,
+		IgnoreFilters:         ignoreFilters,
+		NormalizeByCVE:        opts.ByCVE,
+		FailSeverity:          opts.FailOnSeverity(),
+		FailRisk:              opts.FailOnRiskScore(),
+		FailKEV:               opts.FailOnKEV,
+		FailEPSSPercentile:    opts.FailOnEPSS(),
+		Matchers:              getMatchers(opts),
+		Concurrency:           opts.Match.Workers(),
+		VexProcessor: vex.NewProcessor(vex.ProcessorOptions{
+			Documents:   opts.VexDocuments,
+			IgnoreRules: opts.Ignore,
+		}),
+	}
+}
+
 func dbInfo(status *vulnerability.ProviderStatus, vp vulnerability.Provider) any {
-	var providers map[string]vulnerability.DataProvenance
+	type location struct {
+		Status    *vulnerability.ProviderStatus           `json:"status"`
+		Providers map[string]vulnerability.DataProvenance `json:"providers"`
+	}
 
-	if vp != nil {
-		providers = make(map[string]vulnerability.DataProvenance)
-		if dpr, ok := vp.(vulnerability.StoreMetadataProvider); ok {
-			dps, err := dpr.DataProvenance()
-			// ignore errors here
-			if err == nil {
-				providers = dps
-			}
+	var databases []location
+	if m, ok := vp.(*multiDBProvider); ok {
+		for _, l := range m.locations {
+			databases = append(databases, location{
+				Status:    l.status,
+				Providers: dataProvenance(l.provider),
+			})
 		}
 	}
 
+	var providers map[string]vulnerability.DataProvenance
+	if vp != nil {
+		providers = dataProvenance(vp)
+	}
+
 	return struct {
 		Status    *vulnerability.ProviderStatus           `json:"status"`
 		Providers map[string]vulnerability.DataProvenance `json:"providers"`
+		// Databases describes each DB when DBs from additional cache directories are used (the first being the DB
+		// described by the status)
+		Databases []location `json:"databases,omitempty"`
 	}{
 		Status:    status,
 		Providers: providers,
+		Databases: databases,
+	}
+}
+
+func dataProvenance(vp vulnerability.Provider) map[string]vulnerability.DataProvenance {
+	providers := make(map[string]vulnerability.DataProvenance)
+	if dpr, ok := vp.(vulnerability.StoreMetadataProvider); ok {
+		dps, err := dpr.DataProvenance()
+		// ignore errors here
+		if err == nil {
+			providers = dps
+		}
 	}
+	return providers
 }
 
 func applyDistroHint(pkgs []pkg.Package, context *pkg.Context, opts *options.Grype) {
@@ -366,10 +429,75 @@ func getProviderConfig(opts *options.Grype) pkg.ProviderConfig {
 		},
 		SynthesisConfig: pkg.SynthesisConfig{
 			GenerateMissingCPEs: opts.GenerateMissingCPEs,
+			CPEs:                opts.CPEs.ToConfig(),
 		},
 	}
 }
 
+// loadVulnerabilityDB loads the vulnerability DB (installing or updating it as configured), composed with the
+// configured local advisories and the DBs within any additional cache directories
+func loadVulnerabilityDB(opts *options.Grype) (vulnerability.Provider, *vulnerability.ProviderStatus, error) {
+	vp, status, err := grype.LoadVulnerabilityDB(opts.ToClientConfig(), opts.ToCuratorConfig(), opts.DB.AutoUpdate)
+	if err != nil || status == nil || status.Error != nil {
+		return vp, status, err
+	}
+
+	overlaid, err := grype.WithLocalAdvisories(vp, opts.ToLocalAdvisoriesConfig())
+	if err != nil {
+		log.CloseAndLogError(vp, status.Path)
+		return nil, status, fmt.Errorf("unable to load local advisories: %w", err)
+	}
+
+	composed, err := withAdditionalDBs(overlaid, dbLocation{status: status, provider: vp}, opts.ToClientConfig(), opts.ToAdditionalCuratorConfigs())
+	if err != nil {
+		log.CloseAndLogError(overlaid, status.Path)
+		return nil, status, err
+	}
+	return composed, status, nil
+}
+
+// dbLocation is a vulnerability DB loaded from one of the configured cache directories
+type dbLocation struct {
+	status   *vulnerability.ProviderStatus
+	provider vulnerability.Provider
+}
+
+// multiDBProvider is a vulnerability provider composed of the DBs from several cache directories, which keeps the
+// status of each DB (the first being the DB from the cache-dir) to describe them in reports
+type multiDBProvider struct {
+	*vulnerability.CompositeProvider
+	locations []dbLocation
+}
+
+// withAdditionalDBs composes the given provider (of the DB at the given location) with the DBs installed within the
+// additional cache directories, which have a lower priority. The additional DBs are never updated.
+func withAdditionalDBs(vp vulnerability.Provider, primary dbLocation, distCfg distribution.Config, cfgs []installation.Config) (vulnerability.Provider, error) {
+	if len(cfgs) == 0 {
+		return vp, nil
+	}
+
+	providers := []vulnerability.Provider{vp}
+	locations := []dbLocation{primary}
+	for _, cfg := range cfgs {
+		additional, status, err := grype.LoadVulnerabilityDB(distCfg, cfg, false)
+		if err != nil {
+			for _, l := range locations[1:] {
+				log.CloseAndLogError(l.provider, l.status.Path)
+			}
+			return nil, fmt.Errorf("unable to load vulnerability db from %q: %w", cfg.DBRootDir, err)
+		}
+		log.WithFields("path", status.Path, "built", status.Built).Debug("loaded additional vulnerability db")
+
+		providers = append(providers, additional)
+		locations = append(locations, dbLocation{status: status, provider: additional})
+	}
+
+	return &multiDBProvider{
+		CompositeProvider: vulnerability.NewCompositeProvider(providers...),
+		locations:         locations,
+	}, nil
+}
+
 func validateDBLoad(loadErr error, status *vulnerability.ProviderStatus) error {
 	if loadErr != nil {
 		// notify the user about grype db delete to fix checksum errors
@@ -413,9 +541,85 @@ func validateRootArgs(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("an image/directory argument is required")
 	}
 
+	return nil
+}
+
+// validateSingleTargetArgs validates the arguments of commands that scan exactly one target
+func validateSingleTargetArgs(cmd *cobra.Command, args []string) error {
+	if err := validateRootArgs(cmd, args); err != nil {
+		return err
+	}
 	return cobra.MaximumNArgs(1)(cmd, args)
 }
 
+// applyPolicy evaluates the policy against the reported matches, records the result on the document, notifies
+// the user of rules with the "warn" action, and returns an error when any rule with the "fail" action matched
+func applyPolicy(p policy.Policy, model *models.Document) error {
+	evaluation := p.Evaluate(*model, time.Now())
+	model.Policy = &evaluation
+
+	counts := make(map[string]int)
+	var warned []string
+	for _, v := range evaluation.Violations {
+		if v.Action != string(policy.WarnAction) {
+			continue
+		}
+		if counts[v.Rule] == 0 {
+			warned = append(warned, v.Rule)
+		}
+		counts[v.Rule]++
+	}
+	for _, rule := range warned {
+		bus.Notify(fmt.Sprintf("policy rule %q matched %d finding(s)", rule, counts[rule]))
+	}
+
+	if evaluation.Status == policy.FailStatus {
+		return grypeerr.ErrPolicyViolation
+	}
+	return nil
+}
+
+// applyIgnoreRules adds the ignore rules implied by the fix state and kernel header options to the configured ignore rules
+func applyIgnoreRules(opts *options.Grype) error {
+	if opts.OnlyFixed {
+		opts.Ignore = append(opts.Ignore, ignoreNonFixedMatches...)
+	}
+
+	if opts.OnlyNotFixed {
+		opts.Ignore = append(opts.Ignore, ignoreFixedMatches...)
+	}
+
+	if !opts.MatchUpstreamKernelHeaders {
+		opts.Ignore = append(opts.Ignore, ignoreLinuxKernelHeaders...)
+	}
+
+	for _, ignoreState := range stringutil.SplitCommaSeparatedString(opts.IgnoreStates) {
+		switch vulnerability.FixState(ignoreState) {
+		case vulnerability.FixStateUnknown, vulnerability.FixStateFixed, vulnerability.FixStateNotFixed, vulnerability.FixStateWontFix:
+			opts.Ignore = append(opts.Ignore, match.IgnoreRule{FixState: ignoreState})
+		default:
+			return fmt.Errorf("unknown fix state %s was supplied for --ignore-states", ignoreState)
+		}
+	}
+
+	return nil
+}
+
+func getIgnoreFilters(opts *options.Grype) ([]match.IgnoreFilter, error) {
+	var ignoreFilters []match.IgnoreFilter
+	if opts.Baseline != "" {
+		b, err := baseline.Read(opts.Baseline)
+		if err != nil {
+			return nil, err
+		}
+		ignoreFilters = append(ignoreFilters, b)
+	}
+	if opts.MinConfidence > 0 {
+		ignoreFilters = append(ignoreFilters, match.MinConfidence(opts.MinConfidence))
+	}
+	return ignoreFilters, nil
+}
+
 func applyVexRules(opts *options.Grype) error {
 	// If any vex documents are provided, assume the user intends to ignore vulnerabilities that those
 	// vex documents list as "fixed" or "not_affected".
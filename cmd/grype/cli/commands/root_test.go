@@ -1,21 +1,35 @@
 package commands
 
 import (
+	"encoding/json"
+	"os"
+	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/spf13/afero"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
 	"github.com/anchore/clio"
 	"github.com/anchore/grype/cmd/grype/cli/options"
+	v6 "github.com/anchore/grype/grype/db/v6"
+	"github.com/anchore/grype/grype/db/v6/distribution"
+	"github.com/anchore/grype/grype/db/v6/installation"
+	"github.com/anchore/grype/grype/db/v6/osv"
 	"github.com/anchore/grype/grype/match"
 	"github.com/anchore/grype/grype/pkg"
+	"github.com/anchore/grype/grype/search"
+	"github.com/anchore/grype/grype/version"
 	"github.com/anchore/grype/grype/vex"
+	"github.com/anchore/grype/grype/vulnerability"
+	"github.com/anchore/grype/grype/vulnerability/mock"
 	"github.com/anchore/stereoscope/pkg/image"
 	"github.com/anchore/syft/syft"
 	"github.com/anchore/syft/syft/cataloging"
+	syftPkg "github.com/anchore/syft/syft/pkg"
 	"github.com/anchore/syft/syft/pkg/cataloger/binary"
 )
 
@@ -204,3 +218,120 @@ func Test_applyVexRules(t *testing.T) {
 		})
 	}
 }
+
+func Test_withAdditionalDBs(t *testing.T) {
+	// the additional DB is imported into its own cache directory
+	additionalCfg := installation.Config{DBRootDir: t.TempDir(), ValidateChecksum: true}
+	importAdditionalDB(t, additionalCfg, time.Now())
+
+	primary := mock.VulnerabilityProvider(vulnerability.Vulnerability{
+		Reference:   vulnerability.Reference{ID: "GHSA-primary", Namespace: "github:language:javascript"},
+		PackageName: "acme-widget",
+		Constraint:  version.MustGetConstraint("< 2.0.0", version.UnknownFormat),
+	})
+
+	unchanged, err := withAdditionalDBs(primary, dbLocation{provider: primary}, distribution.DefaultConfig(), nil)
+	require.NoError(t, err)
+	assert.Same(t, primary, unchanged)
+
+	status := &vulnerability.ProviderStatus{Path: "primary"}
+	vp, err := withAdditionalDBs(primary, dbLocation{status: status, provider: primary}, distribution.DefaultConfig(), []installation.Config{additionalCfg})
+	require.NoError(t, err)
+	t.Cleanup(func() { require.NoError(t, vp.Close()) })
+
+	vulns, err := vp.FindVulnerabilities(
+		search.ByPackageName("acme-widget"),
+		search.ByEcosystem(syftPkg.JavaScript, syftPkg.NpmPkg),
+		search.ByVersion(*version.NewVersion("1.3.0", version.UnknownFormat)),
+	)
+	require.NoError(t, err)
+
+	var ids []string
+	for _, v := range vulns {
+		ids = append(ids, v.ID)
+	}
+	assert.Equal(t, []string{"GHSA-primary", "ACME-2025-0001"}, ids)
+
+	by, err := json.Marshal(dbInfo(status, vp))
+	require.NoError(t, err)
+
+	var info struct {
+		Status    vulnerability.ProviderStatus `json:"status"`
+		Providers map[string]any               `json:"providers"`
+		Databases []struct {
+			Status struct {
+				Path string `json:"path"`
+			} `json:"status"`
+			Providers map[string]any `json:"providers"`
+		} `json:"databases"`
+	}
+	require.NoError(t, json.Unmarshal(by, &info))
+	assert.Contains(t, info.Providers, "acme")
+	require.Len(t, info.Databases, 2)
+	assert.Equal(t, "primary", info.Databases[0].Status.Path)
+	assert.Empty(t, info.Databases[0].Providers)
+	assert.Equal(t, additionalCfg.DBFilePath(), info.Databases[1].Status.Path)
+	assert.Contains(t, info.Databases[1].Providers, "acme")
+}
+
+func Test_withAdditionalDBs_staleDB(t *testing.T) {
+	cfg := options.DefaultDatabaseCommand(clio.Identification{Name: "grype"})
+	cfg.DB.AdditionalDirs = []string{t.TempDir()}
+	additionalCfgs := cfg.ToAdditionalCuratorConfigs()
+	require.Len(t, additionalCfgs, 1)
+	require.True(t, additionalCfgs[0].ValidateChecksum)
+
+	// additional DBs are never updated, so a DB built well beyond the max allowed age must still be loaded
+	importAdditionalDB(t, additionalCfgs[0], time.Now().Add(-10*cfg.DB.MaxAllowedBuiltAge))
+
+	primary := mock.VulnerabilityProvider()
+	vp, err := withAdditionalDBs(primary, dbLocation{status: &vulnerability.ProviderStatus{Path: "primary"}, provider: primary}, distribution.DefaultConfig(), additionalCfgs)
+	require.NoError(t, err)
+	t.Cleanup(func() { require.NoError(t, vp.Close()) })
+
+	vulns, err := vp.FindVulnerabilities(
+		search.ByPackageName("acme-widget"),
+		search.ByEcosystem(syftPkg.JavaScript, syftPkg.NpmPkg),
+		search.ByVersion(*version.NewVersion("1.3.0", version.UnknownFormat)),
+	)
+	require.NoError(t, err)
+	require.Len(t, vulns, 1)
+	assert.Equal(t, "ACME-2025-0001", vulns[0].ID)
+}
+
+// importAdditionalDB builds a DB (with the given build time) with a single npm advisory and imports it into the cache
+// directory of the given curator configuration
+func importAdditionalDB(t *testing.T, cfg installation.Config, built time.Time) {
+	t.Helper()
+	advisories := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(advisories, "ACME-2025-0001.json"), []byte(`{
+  "id": "ACME-2025-0001",
+  "affected": [
+    {
+      "package": {"ecosystem": "npm", "name": "acme-widget"},
+      "ranges": [{"type": "SEMVER", "events": [{"introduced": "0"}, {"fixed": "1.4.2"}]}]
+    }
+  ]
+}`), 0o600))
+
+	buildDir := t.TempDir()
+	result, err := osv.Build(buildDir, osv.BuildConfig{Dirs: []string{advisories}, Provider: "acme"})
+	require.NoError(t, err)
+
+	// rewrite the build time, along with the import metadata (as the checksum of the DB changes)
+	d, err := v6.NewLowLevelDB(result.Path, false, true, true)
+	require.NoError(t, err)
+	ts := built.UTC().Round(time.Second)
+	require.NoError(t, d.Model(&v6.DBMetadata{}).Where("true").Update("build_timestamp", &ts).Error)
+	sqlDB, err := d.DB()
+	require.NoError(t, err)
+	require.NoError(t, sqlDB.Close())
+	_, err = v6.WriteImportMetadata(afero.NewOsFs(), buildDir, "osv")
+	require.NoError(t, err)
+
+	client, err := distribution.NewClient(distribution.DefaultConfig())
+	require.NoError(t, err)
+	c, err := installation.NewCurator(cfg, client)
+	require.NoError(t, err)
+	require.NoError(t, c.Import(result.Path))
+}
@@ -0,0 +1,314 @@
+package commands
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/anchore/clio"
+	"github.com/anchore/grype/cmd/grype/cli/options"
+	"github.com/anchore/grype/grype"
+	"github.com/anchore/grype/grype/db/v6/distribution"
+	"github.com/anchore/grype/grype/db/v6/installation"
+	"github.com/anchore/grype/grype/match"
+	"github.com/anchore/grype/grype/pkg"
+	"github.com/anchore/grype/grype/policy"
+	"github.com/anchore/grype/grype/presenter/models"
+	"github.com/anchore/grype/internal/format"
+	"github.com/anchore/grype/internal/log"
+	"github.com/anchore/syft/syft/sbom"
+)
+
+// serveShutdownTimeout is how long in-flight scans are given to complete when the server is stopped
+const serveShutdownTimeout = time.Minute
+
+func Serve(app clio.Application) *cobra.Command {
+	opts := options.DefaultGrype(app.ID())
+
+	cfg := &struct {
+		Serve options.Serve `yaml:"serve" json:"serve" mapstructure:"serve"`
+	}{
+		Serve: options.DefaultServe(),
+	}
+
+	cmd := &cobra.Command{
+		Use:   "serve",
+		Short: "Serve an HTTP API to scan SBOMs, keeping the vulnerability database loaded between scans",
+		Long: `Serve an HTTP API to scan SBOMs, keeping the vulnerability database loaded between scans.
+
+Endpoints:
+    POST /v1/scan      scan the SBOM in the request body (any format grype accepts, including a newline separated
+                       list of package URLs); select the report format with ?output=<format> (default: json)
+    GET  /v1/status    describe the vulnerability database being served
+    GET  /healthz      report that the server is running
+
+The vulnerability database is checked for updates every serve.update-check-interval, and newer databases are used
+for new scans without interrupting scans that are in progress.`,
+		Example: `  grype serve --listen localhost:8080
+  curl --data-binary @sbom.json localhost:8080/v1/scan
+  curl --data-binary @purls.txt 'localhost:8080/v1/scan?output=table'`,
+		Args:    cobra.ExactArgs(0),
+		PreRunE: disableUI(app),
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return runServe(cmd.Context(), app.ID(), opts, cfg.Serve)
+		},
+	}
+
+	return app.SetupCommand(cmd, opts, cfg)
+}
+
+func runServe(ctx context.Context, id clio.Identification, opts *options.Grype, serveOpts options.Serve) error {
+	// the results of each scan are returned to the client, who is responsible for any gating decisions
+	opts.DisableFailThresholds()
+
+	if err := applyIgnoreRules(opts); err != nil {
+		return err
+	}
+
+	if err := applyVexRules(opts); err != nil {
+		return fmt.Errorf("applying vex rules: %w", err)
+	}
+
+	ignoreFilters, err := getIgnoreFilters(opts)
+	if err != nil {
+		return err
+	}
+
+	var pol *policy.Policy
+	if opts.Policy != "" {
+		pol, err = policy.Read(opts.Policy)
+		if err != nil {
+			return err
+		}
+	}
+
+	// install (or update) and validate the DB the same way as a scan would before serving it
+	vp, status, err := grype.LoadVulnerabilityDB(opts.ToClientConfig(), opts.ToCuratorConfig(), opts.DB.AutoUpdate)
+	if err = validateDBLoad(err, status); err != nil {
+		return err
+	}
+	log.CloseAndLogError(vp, status.Path)
+
+	client, err := distribution.NewClient(opts.ToClientConfig())
+	if err != nil {
+		return fmt.Errorf("unable to create distribution client: %w", err)
+	}
+
+	curatorCfg := opts.ToCuratorConfig()
+	// the update check interval takes the place of the max update check frequency
+	curatorCfg.UpdateCheckMaxFrequency = 0
+
+	c, err := installation.NewCurator(curatorCfg, client)
+	if err != nil {
+		return fmt.Errorf("unable to create curator: %w", err)
+	}
+
+	db, err := newServedDB(curatorCfg, opts.ToLocalAdvisoriesConfig(), opts.ToClientConfig(), opts.ToAdditionalCuratorConfigs(), c)
+	if err != nil {
+		return err
+	}
+	defer log.CloseAndLogError(db, curatorCfg.DBRootDir)
+
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	watching := &sync.WaitGroup{}
+	defer func() {
+		// stop checking for updates before the DB is closed
+		cancel()
+		watching.Wait()
+	}()
+
+	if opts.DB.AutoUpdate && serveOpts.UpdateCheckInterval > 0 {
+		watching.Add(1)
+		go func() {
+			defer watching.Done()
+			db.watch(ctx, serveOpts.UpdateCheckInterval)
+		}()
+	}
+
+	s := &scanServer{
+		id:             id,
+		opts:           opts,
+		db:             db,
+		ignoreFilters:  ignoreFilters,
+		policy:         pol,
+		maxRequestSize: serveOpts.MaxRequestSize,
+	}
+
+	server := &http.Server{
+		Addr:              serveOpts.Listen,
+		Handler:           s.handler(),
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	errs := make(chan error, 1)
+	go func() {
+		log.WithFields("address", serveOpts.Listen).Info("serving scan API")
+		errs <- server.ListenAndServe()
+	}()
+
+	select {
+	case err = <-errs:
+		return fmt.Errorf("unable to serve scan API: %w", err)
+	case <-ctx.Done():
+	}
+
+	log.Info("shutting down scan API")
+	shutdownCtx, cancelShutdown := context.WithTimeout(context.Background(), serveShutdownTimeout)
+	defer cancelShutdown()
+
+	return server.Shutdown(shutdownCtx)
+}
+
+// scanServer serves scan requests using the configuration shared by all scans
+type scanServer struct {
+	id             clio.Identification
+	opts           *options.Grype
+	db             *servedDB
+	ignoreFilters  []match.IgnoreFilter
+	policy         *policy.Policy
+	maxRequestSize int64
+}
+
+func (s *scanServer) handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /v1/scan", s.handleScan)
+	mux.HandleFunc("GET /v1/status", s.handleStatus)
+	mux.HandleFunc("GET /healthz", func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+	return mux
+}
+
+func (s *scanServer) handleScan(w http.ResponseWriter, r *http.Request) {
+	f := format.JSONFormat
+	if output := r.URL.Query().Get("output"); output != "" {
+		f = format.Parse(output)
+	}
+	switch {
+	case f == format.UnknownFormat:
+		writeServeError(w, http.StatusBadRequest, fmt.Errorf("unsupported output format %q, formats=%v", r.URL.Query().Get("output"), format.AvailableFormats))
+		return
+	case f == format.TemplateFormat && s.opts.OutputTemplateFile == "":
+		writeServeError(w, http.StatusBadRequest, fmt.Errorf("the template output format requires the server to be configured with a template file"))
+		return
+	}
+
+	contents, err := io.ReadAll(http.MaxBytesReader(w, r.Body, s.maxRequestSize))
+	if err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			writeServeError(w, http.StatusRequestEntityTooLarge, fmt.Errorf("the SBOM must not be larger than %d bytes", maxBytesErr.Limit))
+			return
+		}
+		writeServeError(w, http.StatusBadRequest, fmt.Errorf("unable to read request: %w", err))
+		return
+	}
+
+	packages, pkgContext, sb, err := pkg.ProvideFromReader(bytes.NewReader(contents), getProviderConfig(s.opts))
+	if err != nil {
+		writeServeError(w, http.StatusBadRequest, fmt.Errorf("unable to read SBOM: %w", err))
+		return
+	}
+	applyDistroHint(packages, &pkgContext, s.opts)
+
+	report, err := s.scan(f, packages, pkgContext, sb)
+	if err != nil {
+		log.WithFields("error", err).Error("unable to scan SBOM")
+		writeServeError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", contentType(f))
+	if _, err = w.Write(report); err != nil {
+		log.WithFields("error", err).Debug("unable to write scan response")
+	}
+}
+
+func (s *scanServer) scan(f format.Format, packages []pkg.Package, pkgContext pkg.Context, sb *sbom.SBOM) ([]byte, error) {
+	db, release := s.db.acquire()
+	defer release()
+
+	startTime := time.Now()
+
+	vulnMatcher := getVulnerabilityMatcher(s.opts, db.provider, s.ignoreFilters)
+
+	remainingMatches, ignoredMatches, err := vulnMatcher.FindMatches(packages, pkgContext)
+	if err != nil {
+		return nil, err
+	}
+
+	model, err := models.NewDocument(s.id, packages, pkgContext, *remainingMatches, ignoredMatches, db.provider, s.opts, dbInfo(&db.status, db.provider), models.SortStrategy(s.opts.SortBy.Criteria))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create document: %w", err)
+	}
+
+	if s.policy != nil {
+		evaluation := s.policy.Evaluate(model, time.Now())
+		model.Policy = &evaluation
+	}
+
+	pres := format.GetPresenter(f, format.PresentationConfig{
+		TemplateFilePath: s.opts.OutputTemplateFile,
+		ShowSuppressed:   s.opts.ShowSuppressed,
+		Pretty:           s.opts.Pretty,
+	}, models.PresenterConfig{
+		ID:       s.id,
+		Document: model,
+		SBOM:     sb,
+		Pretty:   s.opts.Pretty,
+	})
+
+	buf := &bytes.Buffer{}
+	if err = pres.Present(buf); err != nil {
+		return nil, fmt.Errorf("unable to write report: %w", err)
+	}
+
+	log.WithFields("time", time.Since(startTime), "packages", len(packages), "matches", remainingMatches.Count()).Debug("scanned SBOM")
+
+	return buf.Bytes(), nil
+}
+
+func (s *scanServer) handleStatus(w http.ResponseWriter, _ *http.Request) {
+	db, release := s.db.acquire()
+	defer release()
+
+	writeServeJSON(w, http.StatusOK, dbInfo(&db.status, db.provider))
+}
+
+func contentType(f format.Format) string {
+	switch f {
+	case format.JSONFormat, format.CycloneDXJSON, format.SarifFormat, format.OpenVEXFormat:
+		return "application/json"
+	case format.CycloneDXFormat, format.CycloneDXXML:
+		return "application/xml"
+	default:
+		return "text/plain; charset=utf-8"
+	}
+}
+
+func writeServeError(w http.ResponseWriter, status int, err error) {
+	writeServeJSON(w, status, struct {
+		Error string `json:"error"`
+	}{
+		Error: err.Error(),
+	})
+}
+
+func writeServeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		log.WithFields("error", err).Debug("unable to write response")
+	}
+}
@@ -0,0 +1,223 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/anchore/grype/grype"
+	v6 "github.com/anchore/grype/grype/db/v6"
+	"github.com/anchore/grype/grype/db/v6/distribution"
+	"github.com/anchore/grype/grype/db/v6/installation"
+	"github.com/anchore/grype/grype/db/v6/osv"
+	"github.com/anchore/grype/grype/vulnerability"
+	"github.com/anchore/grype/internal/log"
+)
+
+// dbSnapshot is an open copy of the vulnerability DB, shared by all scans that start while it is the current DB
+type dbSnapshot struct {
+	provider vulnerability.Provider
+	status   vulnerability.ProviderStatus
+	dir      string
+	inflight sync.WaitGroup
+}
+
+// close waits for all scans using the snapshot to complete before closing the DB and removing the copy from disk
+func (s *dbSnapshot) close() {
+	s.inflight.Wait()
+	log.CloseAndLogError(s.provider, s.status.Path)
+	if s.dir != "" {
+		removeAllOrLog(s.dir)
+	}
+}
+
+// servedDB holds the vulnerability DB used while serving scans. When a newer DB is installed the current DB is replaced
+// with the new one, while scans that are already in progress continue to use the DB they started with.
+//
+// The curator replaces the installed DB in place, so each DB is served from a snapshot (a hard link to, or a copy of,
+// the installed DB file) that is not affected by later updates.
+type servedDB struct {
+	curator    v6.Curator
+	cfg        installation.Config
+	advisories osv.OverlayConfig
+	distCfg    distribution.Config
+	additional []installation.Config
+
+	lock    sync.RWMutex
+	current *dbSnapshot
+}
+
+func newServedDB(cfg installation.Config, advisories osv.OverlayConfig, distCfg distribution.Config, additional []installation.Config, curator v6.Curator) (*servedDB, error) {
+	d := &servedDB{
+		curator:    curator,
+		cfg:        cfg,
+		advisories: advisories,
+		distCfg:    distCfg,
+		additional: additional,
+	}
+
+	s, err := d.open()
+	if err != nil {
+		return nil, err
+	}
+	d.current = s
+
+	return d, nil
+}
+
+// acquire returns the current DB, which will not be closed until the returned release function is called
+func (d *servedDB) acquire() (*dbSnapshot, func()) {
+	d.lock.RLock()
+	defer d.lock.RUnlock()
+
+	s := d.current
+	s.inflight.Add(1)
+	return s, s.inflight.Done
+}
+
+// update installs a newer DB if one is available, and swaps it in for the current DB
+func (d *servedDB) update() (bool, error) {
+	updated, err := d.curator.Update()
+	if err != nil {
+		return false, fmt.Errorf("unable to update vulnerability database: %w", err)
+	}
+	if !updated {
+		return false, nil
+	}
+
+	s, err := d.open()
+	if err != nil {
+		return false, err
+	}
+	d.swap(s)
+
+	return true, nil
+}
+
+// watch checks for DB updates at the given interval until the context is cancelled
+func (d *servedDB) watch(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			updated, err := d.update()
+			if err != nil {
+				log.WithFields("error", err).Warn("unable to check for vulnerability database update, continuing with the current database")
+				continue
+			}
+			if updated {
+				s, release := d.acquire()
+				log.WithFields("built", s.status.Built.UTC().Format(time.RFC3339)).Info("now serving updated vulnerability database")
+				release()
+			}
+		}
+	}
+}
+
+func (d *servedDB) swap(s *dbSnapshot) {
+	d.lock.Lock()
+	previous := d.current
+	d.current = s
+	d.lock.Unlock()
+
+	if previous != nil {
+		go previous.close()
+	}
+}
+
+// Close waits for all in-flight scans to complete and closes the current DB
+func (d *servedDB) Close() error {
+	d.lock.Lock()
+	previous := d.current
+	d.current = nil
+	d.lock.Unlock()
+
+	if previous != nil {
+		previous.close()
+	}
+	return nil
+}
+
+func (d *servedDB) open() (*dbSnapshot, error) {
+	status := d.curator.Status()
+	if status.Error != nil {
+		return nil, fmt.Errorf("db could not be loaded: %w", status.Error)
+	}
+
+	dir, err := os.MkdirTemp(d.cfg.DBRootDir, "serve-")
+	if err != nil {
+		return nil, fmt.Errorf("unable to create DB snapshot directory: %w", err)
+	}
+
+	if err = linkOrCopy(d.cfg.DBFilePath(), filepath.Join(dir, v6.VulnerabilityDBFileName)); err != nil {
+		removeAllOrLog(dir)
+		return nil, fmt.Errorf("unable to create DB snapshot: %w", err)
+	}
+
+	rdr, err := v6.NewReader(d.cfg.ReaderConfig(dir))
+	if err != nil {
+		removeAllOrLog(dir)
+		return nil, fmt.Errorf("unable to create db reader: %w", err)
+	}
+
+	// local advisories (and additional DBs) are loaded again for each snapshot, so that changes to them are served
+	// along with DB updates
+	base := v6.NewVulnerabilityProvider(rdr)
+	vp, err := grype.WithLocalAdvisories(base, d.advisories)
+	if err != nil {
+		log.CloseAndLogError(rdr, dir)
+		removeAllOrLog(dir)
+		return nil, fmt.Errorf("unable to load local advisories: %w", err)
+	}
+
+	composed, err := withAdditionalDBs(vp, dbLocation{status: &status, provider: base}, d.distCfg, d.additional)
+	if err != nil {
+		log.CloseAndLogError(vp, dir)
+		removeAllOrLog(dir)
+		return nil, err
+	}
+
+	return &dbSnapshot{
+		provider: composed,
+		status:   status,
+		dir:      dir,
+	}, nil
+}
+
+// linkOrCopy hard links the source file to the destination, falling back to copying the file when linking is not possible
+func linkOrCopy(src, dst string) error {
+	if err := os.Link(src, dst); err == nil {
+		return nil
+	}
+
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer log.CloseAndLogError(in, src)
+
+	out, err := os.Create(dst)
+	if err != nil {
+		return err
+	}
+
+	if _, err = io.Copy(out, in); err != nil {
+		_ = out.Close()
+		return err
+	}
+	return out.Close()
+}
+
+func removeAllOrLog(dir string) {
+	if err := os.RemoveAll(dir); err != nil {
+		log.WithFields("dir", dir, "error", err).Warn("unable to remove directory")
+	}
+}
@@ -0,0 +1,224 @@
+package commands
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/anchore/clio"
+	"github.com/anchore/grype/cmd/grype/cli/options"
+	v6 "github.com/anchore/grype/grype/db/v6"
+	"github.com/anchore/grype/grype/db/v6/installation"
+	"github.com/anchore/grype/grype/presenter/models"
+	"github.com/anchore/grype/grype/search"
+	"github.com/anchore/grype/grype/version"
+	"github.com/anchore/grype/grype/vulnerability"
+	"github.com/anchore/grype/grype/vulnerability/mock"
+)
+
+func newTestScanServer(t *testing.T, vulns ...vulnerability.Vulnerability) *httptest.Server {
+	t.Helper()
+
+	id := clio.Identification{Name: "grype", Version: "test"}
+	s := &scanServer{
+		id:   id,
+		opts: options.DefaultGrype(id),
+		db: &servedDB{
+			current: &dbSnapshot{
+				provider: mock.VulnerabilityProvider(vulns...),
+				status:   vulnerability.ProviderStatus{SchemaVersion: "v6.0.0"},
+			},
+		},
+		maxRequestSize: 1024,
+	}
+
+	server := httptest.NewServer(s.handler())
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestScanServer_scan(t *testing.T) {
+	server := newTestScanServer(t, vulnerability.Vulnerability{
+		PackageName: "lodash",
+		Constraint:  version.MustGetConstraint("< 4.17.21", version.UnknownFormat),
+		Reference:   vulnerability.Reference{ID: "GHSA-test-lodash", Namespace: "github:language:javascript"},
+	})
+
+	tests := []struct {
+		name            string
+		query           string
+		body            string
+		wantStatus      int
+		wantContentType string
+		wantBody        string
+	}{
+		{
+			name:            "purl list",
+			body:            "pkg:npm/lodash@4.17.15\npkg:npm/left-pad@1.3.0\n",
+			wantStatus:      http.StatusOK,
+			wantContentType: "application/json",
+			wantBody:        "GHSA-test-lodash",
+		},
+		{
+			name:            "table output",
+			query:           "?output=table",
+			body:            "pkg:npm/lodash@4.17.15\n",
+			wantStatus:      http.StatusOK,
+			wantContentType: "text/plain; charset=utf-8",
+			wantBody:        "GHSA-test-lodash",
+		},
+		{
+			name:       "unknown output format",
+			query:      "?output=bogus",
+			body:       "pkg:npm/lodash@4.17.15\n",
+			wantStatus: http.StatusBadRequest,
+			wantBody:   "unsupported output format",
+		},
+		{
+			name:       "template output without a template",
+			query:      "?output=template",
+			body:       "pkg:npm/lodash@4.17.15\n",
+			wantStatus: http.StatusBadRequest,
+			wantBody:   "template file",
+		},
+		{
+			name:       "not an sbom",
+			body:       "this is not an sbom",
+			wantStatus: http.StatusBadRequest,
+			wantBody:   "unable to read SBOM",
+		},
+		{
+			name:       "request too large",
+			body:       strings.Repeat("pkg:npm/lodash@4.17.15\n", 100),
+			wantStatus: http.StatusRequestEntityTooLarge,
+			wantBody:   "1024 bytes",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp, err := http.Post(server.URL+"/v1/scan"+tt.query, "text/plain", strings.NewReader(tt.body))
+			require.NoError(t, err)
+			defer resp.Body.Close()
+
+			body, err := io.ReadAll(resp.Body)
+			require.NoError(t, err)
+
+			assert.Equal(t, tt.wantStatus, resp.StatusCode, string(body))
+			if tt.wantContentType != "" {
+				assert.Equal(t, tt.wantContentType, resp.Header.Get("Content-Type"))
+			}
+			assert.Contains(t, string(body), tt.wantBody)
+		})
+	}
+}
+
+func TestScanServer_scanReturnsDocument(t *testing.T) {
+	server := newTestScanServer(t, vulnerability.Vulnerability{
+		PackageName: "lodash",
+		Constraint:  version.MustGetConstraint("< 4.17.21", version.UnknownFormat),
+		Reference:   vulnerability.Reference{ID: "GHSA-test-lodash", Namespace: "github:language:javascript"},
+	})
+
+	resp, err := http.Post(server.URL+"/v1/scan", "text/plain", strings.NewReader("pkg:npm/lodash@4.17.15\npkg:npm/lodash@4.17.21\n"))
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	var doc models.Document
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&doc))
+
+	require.Len(t, doc.Matches, 1)
+	assert.Equal(t, "GHSA-test-lodash", doc.Matches[0].Vulnerability.ID)
+	assert.Equal(t, "4.17.15", doc.Matches[0].Artifact.Version)
+}
+
+func TestScanServer_status(t *testing.T) {
+	server := newTestScanServer(t)
+
+	resp, err := http.Get(server.URL + "/v1/status")
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	var status struct {
+		Status vulnerability.ProviderStatus `json:"status"`
+	}
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&status))
+	assert.Equal(t, "v6.0.0", status.Status.SchemaVersion)
+}
+
+type closeRecordingProvider struct {
+	vulnerability.Provider
+	closed atomic.Bool
+}
+
+func (p *closeRecordingProvider) Close() error {
+	p.closed.Store(true)
+	return nil
+}
+
+func TestServedDB_swapWaitsForInflightScans(t *testing.T) {
+	previous := &closeRecordingProvider{Provider: mock.VulnerabilityProvider()}
+	next := &closeRecordingProvider{Provider: mock.VulnerabilityProvider()}
+
+	d := &servedDB{
+		current: &dbSnapshot{provider: previous},
+	}
+
+	inflight, release := d.acquire()
+	require.Same(t, previous, inflight.provider)
+
+	d.swap(&dbSnapshot{provider: next})
+
+	// new scans use the new DB while the scan in progress continues to use the previous DB
+	current, releaseCurrent := d.acquire()
+	assert.Same(t, next, current.provider)
+	releaseCurrent()
+
+	time.Sleep(50 * time.Millisecond)
+	assert.False(t, previous.closed.Load(), "the previous DB was closed while a scan was using it")
+
+	release()
+	require.Eventually(t, previous.closed.Load, time.Second, 10*time.Millisecond)
+
+	require.NoError(t, d.Close())
+	assert.True(t, next.closed.Load())
+}
+
+type statusCurator struct {
+	v6.Curator
+	status vulnerability.ProviderStatus
+}
+
+func (c statusCurator) Status() vulnerability.ProviderStatus {
+	return c.status
+}
+
+func TestServedDB_open_PackageAliases(t *testing.T) {
+	cfg := installation.Config{
+		DBRootDir:      t.TempDir(),
+		PackageAliases: []v6.PackageSpecifierAlias{acmeNpmAlias},
+	}
+	writePackageAliasTestDB(t, cfg.DBDirectoryPath())
+
+	d := &servedDB{
+		cfg:     cfg,
+		curator: statusCurator{status: vulnerability.ProviderStatus{SchemaVersion: "v6.0.0", Path: cfg.DBFilePath()}},
+	}
+	s, err := d.open()
+	require.NoError(t, err)
+	defer s.close()
+
+	vulns, err := s.provider.FindVulnerabilities(search.ByEcosystem("", "acme-npm"), search.ByPackageName("lodash"))
+	require.NoError(t, err)
+	require.Len(t, vulns, 1)
+	assert.Equal(t, "GHSA-test", vulns[0].ID)
+}
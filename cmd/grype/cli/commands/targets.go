@@ -0,0 +1,507 @@
+package commands
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/bmatcuk/doublestar/v2"
+
+	"github.com/anchore/clio"
+	"github.com/anchore/grype/cmd/grype/cli/options"
+	"github.com/anchore/grype/grype"
+	"github.com/anchore/grype/grype/grypeerr"
+	"github.com/anchore/grype/grype/pkg"
+	"github.com/anchore/grype/grype/policy"
+	"github.com/anchore/grype/grype/presenter/models"
+	"github.com/anchore/grype/grype/vulnerability"
+	"github.com/anchore/grype/internal/bus"
+	"github.com/anchore/grype/internal/format"
+	"github.com/anchore/grype/internal/log"
+)
+
+// fileSchemes are the target schemes that refer to paths on disk, which may be given as glob patterns
+var fileSchemes = []string{"sbom", "purl", "dir", "file", "docker-archive", "oci-archive", "oci-dir", "singularity"}
+
+// targetNameDisallowed matches the characters that are replaced when deriving a filename-safe name from a target
+var targetNameDisallowed = regexp.MustCompile(`[^A-Za-z0-9._-]+`)
+
+// targetOutput is the data available to the --target-output path template
+type targetOutput struct {
+	Index  int
+	Target string
+	Name   string
+	Format string
+}
+
+// targetResult is the outcome of scanning a single target of a multi-target scan
+type targetResult struct {
+	target  string
+	summary models.TargetSummary
+	// document is only retained when writing a combined report
+	document *models.Document
+}
+
+// expandTargets returns the targets given as arguments followed by the targets listed in the targets file, with any
+// glob patterns expanded to the matching paths
+func expandTargets(args []string, targetsFile string) ([]string, error) {
+	inputs := append([]string{}, args...)
+
+	if targetsFile != "" {
+		listed, err := readTargetsFile(targetsFile)
+		if err != nil {
+			return nil, err
+		}
+		inputs = append(inputs, listed...)
+	}
+
+	var targets []string
+	for _, input := range inputs {
+		expanded, err := expandTarget(input)
+		if err != nil {
+			return nil, err
+		}
+		targets = append(targets, expanded...)
+	}
+	return targets, nil
+}
+
+func readTargetsFile(path string) ([]string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("unable to open targets file: %w", err)
+	}
+	defer log.CloseAndLogError(f, path)
+
+	var targets []string
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		targets = append(targets, line)
+	}
+	if err = scanner.Err(); err != nil {
+		return nil, fmt.Errorf("unable to read targets file: %w", err)
+	}
+	return targets, nil
+}
+
+// expandTarget expands a target given as a glob pattern (optionally prefixed with a file-based scheme, such as
+// "sbom:sboms/*.json") to the matching paths, keeping the scheme on each. Any other target is returned as-is.
+func expandTarget(target string) ([]string, error) {
+	scheme, pattern := splitFileScheme(target)
+	if !strings.ContainsAny(pattern, "*?[") {
+		return []string{target}, nil
+	}
+
+	matches, err := doublestar.Glob(pattern)
+	if err != nil {
+		return nil, fmt.Errorf("bad target pattern %q: %w", target, err)
+	}
+	if len(matches) == 0 {
+		return nil, fmt.Errorf("no targets match %q", target)
+	}
+
+	for i, m := range matches {
+		matches[i] = scheme + m
+	}
+	return matches, nil
+}
+
+// splitFileScheme splits a target into the file-based scheme prefix (including the separator) and the path
+func splitFileScheme(target string) (string, string) {
+	for _, s := range fileSchemes {
+		if strings.HasPrefix(target, s+":") {
+			return s + ":", strings.TrimPrefix(target, s+":")
+		}
+	}
+	return "", target
+}
+
+// targetName derives a filename-safe name from a target: the file name (without extension) for a path on disk,
+// otherwise the target (such as an image reference) with path separators and other special characters replaced
+func targetName(target string) string {
+	_, path := splitFileScheme(target)
+	name := path
+	if _, err := os.Stat(path); err == nil {
+		name = strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+	}
+	name = strings.Trim(targetNameDisallowed.ReplaceAllString(name, "_"), "_.")
+	if name == "" {
+		return "target"
+	}
+	return name
+}
+
+// targetOutputPaths renders the --target-output template for each target and output format, ensuring no two reports
+// would be written to the same path
+func targetOutputPaths(tmpl string, targets []string, formats []format.Format) ([]map[format.Format]string, error) {
+	t, err := template.New("target-output").Option("missingkey=error").Parse(tmpl)
+	if err != nil {
+		return nil, fmt.Errorf("bad target-output template: %w", err)
+	}
+
+	seen := make(map[string]string)
+	paths := make([]map[format.Format]string, len(targets))
+	for i, target := range targets {
+		paths[i] = make(map[format.Format]string)
+		for _, f := range formats {
+			buf := &bytes.Buffer{}
+			if err = t.Execute(buf, targetOutput{
+				Index:  i + 1,
+				Target: target,
+				Name:   targetName(target),
+				Format: f.String(),
+			}); err != nil {
+				return nil, fmt.Errorf("unable to render target-output template for %q: %w", target, err)
+			}
+
+			path := buf.String()
+			if other, ok := seen[path]; ok {
+				return nil, fmt.Errorf("the %s report for %q and the report for %q would both be written to %q, the target-output template must give each report a unique path (e.g. with {{.Index}} or {{.Format}})", f, target, other, path)
+			}
+			seen[path] = target
+			paths[i][f] = path
+		}
+	}
+	return paths, nil
+}
+
+// targetsOutput is a report format and the file to write it to (empty for the default output stream)
+type targetsOutput struct {
+	format format.Format
+	path   string
+}
+
+func parseTargetsOutputs(opts *options.Grype, perTarget bool) ([]targetsOutput, error) {
+	outputs := opts.Outputs
+	if len(outputs) == 0 {
+		outputs = []string{format.TableFormat.String()}
+	}
+
+	var out []targetsOutput
+	for _, o := range outputs {
+		parts := strings.SplitN(strings.TrimSpace(o), "=", 2)
+
+		f := format.Parse(parts[0])
+		if f == format.UnknownFormat {
+			return nil, fmt.Errorf(`unsupported output format "%s", supported formats are: %+v`, parts[0], format.AvailableFormats)
+		}
+
+		path := opts.File
+		if len(parts) > 1 {
+			path = parts[1]
+		}
+
+		switch {
+		case perTarget && path != "":
+			return nil, fmt.Errorf("an output file cannot be given when writing one report per target with --target-output")
+		case !perTarget && f != format.JSONFormat && f != format.TableFormat:
+			return nil, fmt.Errorf("the %s format does not support combining the reports for multiple targets, use --target-output to write one report per target", f)
+		}
+
+		out = append(out, targetsOutput{format: f, path: path})
+	}
+	return out, nil
+}
+
+// runGrypeTargets scans each of the targets with the same vulnerability DB and matchers, writing either one report per
+// target (when a --target-output template is given) or a combined report, along with a summary of all targets
+//
+//nolint:funlen
+func runGrypeTargets(app clio.Application, opts *options.Grype, targetsOpts options.Targets, targets []string) error {
+	perTarget := targetsOpts.Output != ""
+
+	outputs, err := parseTargetsOutputs(opts, perTarget)
+	if err != nil {
+		return err
+	}
+
+	var outputPaths []map[format.Format]string
+	if perTarget {
+		var formats []format.Format
+		for _, o := range outputs {
+			formats = append(formats, o.format)
+		}
+		outputPaths, err = targetOutputPaths(targetsOpts.Output, targets, formats)
+		if err != nil {
+			return err
+		}
+	}
+
+	if err = applyIgnoreRules(opts); err != nil {
+		return err
+	}
+
+	ignoreFilters, err := getIgnoreFilters(opts)
+	if err != nil {
+		return err
+	}
+
+	var pol *policy.Policy
+	if opts.Policy != "" {
+		pol, err = policy.Read(opts.Policy)
+		if err != nil {
+			return err
+		}
+	}
+
+	var vp vulnerability.Provider
+	var status *vulnerability.ProviderStatus
+
+	err = parallel(
+		func() error {
+			checkForAppUpdate(app.ID(), opts)
+			return nil
+		},
+		func() (err error) {
+			startTime := time.Now()
+			log.Debug("loading DB")
+			vp, status, err = loadVulnerabilityDB(opts)
+			log.WithFields("time", time.Since(startTime)).Info("loaded DB")
+			return validateDBLoad(err, status)
+		},
+	)
+	if err != nil {
+		return err
+	}
+
+	defer log.CloseAndLogError(vp, status.Path)
+
+	if err = applyVexRules(opts); err != nil {
+		return fmt.Errorf("applying vex rules: %w", err)
+	}
+
+	// the provider and matchers are shared by all targets
+	scan := targetsScan{
+		id:          app.ID(),
+		opts:        opts,
+		provider:    vp,
+		status:      status,
+		matcher:     getVulnerabilityMatcher(opts, vp, ignoreFilters),
+		policy:      pol,
+		outputs:     outputs,
+		outputPaths: outputPaths,
+	}
+
+	return scan.run(targets)
+}
+
+// targetsScan scans a set of targets with a shared vulnerability provider and matchers
+type targetsScan struct {
+	id       clio.Identification
+	opts     *options.Grype
+	provider vulnerability.Provider
+	status   *vulnerability.ProviderStatus
+	matcher  grype.VulnerabilityMatcher
+	policy   *policy.Policy
+	outputs  []targetsOutput
+	// outputPaths holds the report path of each output format for each target, only when writing one report per target
+	outputPaths []map[format.Format]string
+}
+
+// run scans each target, writing the report of each target (or the combined report of all targets) to the outputs
+//
+//nolint:funlen
+func (s targetsScan) run(targets []string) error {
+	perTarget := s.outputPaths != nil
+	presentationCfg := format.PresentationConfig{
+		TemplateFilePath: s.opts.OutputTemplateFile,
+		ShowSuppressed:   s.opts.ShowSuppressed,
+		Pretty:           s.opts.Pretty,
+	}
+
+	var scanErrs, failErrs error
+	var results []targetResult
+	for i, target := range targets {
+		log.WithFields("target", target, "index", i+1, "total", len(targets)).Info("scanning target")
+
+		result := targetResult{target: target}
+
+		packages, pkgContext, sb, err := pkg.Provide(target, getProviderConfig(s.opts))
+		if err != nil {
+			err = fmt.Errorf("failed to catalog %q: %w", target, err)
+			log.Error(err)
+			scanErrs = appendErrors(scanErrs, err)
+			result.summary = models.TargetSummary{Target: target, Error: err.Error()}
+			results = append(results, result)
+			continue
+		}
+		applyDistroHint(packages, &pkgContext, s.opts)
+
+		remainingMatches, ignoredMatches, err := s.matcher.FindMatches(packages, pkgContext)
+		if err != nil {
+			if !grypeerr.IsAboveFailThreshold(err) {
+				return err
+			}
+			failErrs = appendErrors(failErrs, fmt.Errorf("%s: %w", target, err))
+		}
+
+		model, err := models.NewDocument(s.id, packages, pkgContext, *remainingMatches, ignoredMatches, s.provider, s.opts, dbInfo(s.status, s.provider), models.SortStrategy(s.opts.SortBy.Criteria))
+		if err != nil {
+			return fmt.Errorf("failed to create document for %q: %w", target, err)
+		}
+
+		if s.policy != nil {
+			if err = applyPolicy(*s.policy, &model); err != nil {
+				failErrs = appendErrors(failErrs, fmt.Errorf("%s: %w", target, err))
+			}
+		}
+
+		result.summary = models.NewTargetSummary(target, len(packages), model)
+
+		if perTarget {
+			for _, o := range s.outputs {
+				if err = writeTargetReport(o.format, s.outputPaths[i][o.format], presentationCfg, models.PresenterConfig{
+					ID:       s.id,
+					Document: model,
+					SBOM:     sb,
+					Pretty:   s.opts.Pretty,
+				}); err != nil {
+					scanErrs = appendErrors(scanErrs, err)
+				}
+			}
+		} else {
+			result.document = &model
+		}
+
+		results = append(results, result)
+	}
+
+	if perTarget {
+		buf := &bytes.Buffer{}
+		if err := presentTargetsSummary(buf, results); err != nil {
+			return err
+		}
+		bus.Report(buf.String())
+	} else {
+		for _, o := range s.outputs {
+			if err := writeCombinedReport(o, s.id, presentationCfg, results); err != nil {
+				scanErrs = appendErrors(scanErrs, err)
+			}
+		}
+	}
+
+	// failing to scan a target takes precedence over findings that trip a fail threshold
+	if scanErrs != nil {
+		return scanErrs
+	}
+	return failErrs
+}
+
+func writeTargetReport(f format.Format, path string, cfg format.PresentationConfig, pb models.PresenterConfig) error {
+	writer, err := format.MakeScanResultWriterForFormat(f.String(), path, cfg)
+	if err != nil {
+		return err
+	}
+	if closer, ok := writer.(io.Closer); ok {
+		defer log.CloseAndLogError(closer, path)
+	}
+	return writer.Write(pb)
+}
+
+// writeCombinedReport writes the results of all targets as a single report to the output file, or to the report stream
+// when no file is given
+func writeCombinedReport(o targetsOutput, id clio.Identification, cfg format.PresentationConfig, results []targetResult) error {
+	buf := &bytes.Buffer{}
+
+	switch o.format {
+	case format.JSONFormat:
+		doc := models.TargetsDocument{
+			Targets: make(map[string]models.Document),
+		}
+		for _, r := range results {
+			doc.Summary = append(doc.Summary, r.summary)
+			if r.document != nil {
+				doc.Targets[r.target] = *r.document
+			}
+		}
+
+		enc := json.NewEncoder(buf)
+		enc.SetEscapeHTML(false)
+		if cfg.Pretty {
+			enc.SetIndent("", " ")
+		}
+		if err := enc.Encode(doc); err != nil {
+			return fmt.Errorf("unable to encode result: %w", err)
+		}
+	case format.TableFormat:
+		for _, r := range results {
+			if r.document == nil {
+				continue
+			}
+			fmt.Fprintf(buf, "%s\n", r.target)
+			pres := format.GetPresenter(o.format, cfg, models.PresenterConfig{
+				ID:       id,
+				Document: *r.document,
+			})
+			if err := pres.Present(buf); err != nil {
+				return fmt.Errorf("unable to encode result: %w", err)
+			}
+			buf.WriteString("\n")
+		}
+		if err := presentTargetsSummary(buf, results); err != nil {
+			return err
+		}
+	default:
+		return fmt.Errorf("the %s format does not support combining the reports for multiple targets", o.format)
+	}
+
+	if o.path == "" {
+		bus.Report(buf.String())
+		return nil
+	}
+
+	if err := os.MkdirAll(filepath.Dir(o.path), 0755); err != nil {
+		return fmt.Errorf("unable to create report directory: %w", err)
+	}
+	if err := os.WriteFile(o.path, buf.Bytes(), 0644); err != nil {
+		return fmt.Errorf("unable to create report file: %w", err)
+	}
+	return nil
+}
+
+// presentTargetsSummary writes a table with the number of packages and findings (by severity) of each target
+func presentTargetsSummary(output io.Writer, results []targetResult) error {
+	rows := make([][]string, 0, len(results))
+	for _, r := range results {
+		s := r.summary
+		if s.Error != "" {
+			rows = append(rows, []string{s.Target, "", "", "", "", "", "", "", "", "", s.Error})
+			continue
+		}
+
+		sev := s.Severities
+		other := sev[vulnerability.NegligibleSeverity.String()] + sev[vulnerability.UnknownSeverity.String()]
+		rows = append(rows, []string{
+			s.Target,
+			strconv.Itoa(s.Packages),
+			strconv.Itoa(sev[vulnerability.CriticalSeverity.String()]),
+			strconv.Itoa(sev[vulnerability.HighSeverity.String()]),
+			strconv.Itoa(sev[vulnerability.MediumSeverity.String()]),
+			strconv.Itoa(sev[vulnerability.LowSeverity.String()]),
+			strconv.Itoa(other),
+			strconv.Itoa(s.Matches),
+			strconv.Itoa(s.Ignored),
+			s.Policy,
+			"",
+		})
+	}
+
+	table := newTable(output, []string{"Target", "Packages", "Critical", "High", "Medium", "Low", "Other", "Total", "Ignored", "Policy", "Error"})
+	if err := table.Bulk(rows); err != nil {
+		return fmt.Errorf("failed to add table rows: %w", err)
+	}
+	return table.Render()
+}
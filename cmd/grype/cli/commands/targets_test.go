@@ -0,0 +1,291 @@
+package commands
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/anchore/clio"
+	loggerRedact "github.com/anchore/go-logger/adapter/redact"
+	"github.com/anchore/grype/cmd/grype/cli/options"
+	"github.com/anchore/grype/grype/presenter/models"
+	"github.com/anchore/grype/grype/version"
+	"github.com/anchore/grype/grype/vulnerability"
+	"github.com/anchore/grype/grype/vulnerability/mock"
+	"github.com/anchore/grype/internal/format"
+	"github.com/anchore/grype/internal/redact"
+)
+
+func TestExpandTargets(t *testing.T) {
+	dir := t.TempDir()
+	for _, name := range []string{"api.json", "web.json", "notes.txt"} {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte("{}"), 0600))
+	}
+
+	targetsFile := filepath.Join(dir, "targets.txt")
+	require.NoError(t, os.WriteFile(targetsFile, []byte("# images\nalpine:3.20\n\n  debian:12  \n"), 0600))
+
+	tests := []struct {
+		name        string
+		args        []string
+		targetsFile string
+		want        []string
+		wantErr     require.ErrorAssertionFunc
+	}{
+		{
+			name: "targets are kept as-is",
+			args: []string{"alpine:3.20", "pkg:npm/lodash@4.17.15", "dir:" + dir},
+			want: []string{"alpine:3.20", "pkg:npm/lodash@4.17.15", "dir:" + dir},
+		},
+		{
+			name: "glob without a scheme",
+			args: []string{filepath.Join(dir, "*.json")},
+			want: []string{filepath.Join(dir, "api.json"), filepath.Join(dir, "web.json")},
+		},
+		{
+			name: "glob keeps the scheme",
+			args: []string{"sbom:" + filepath.Join(dir, "*.json")},
+			want: []string{"sbom:" + filepath.Join(dir, "api.json"), "sbom:" + filepath.Join(dir, "web.json")},
+		},
+		{
+			name:        "targets file follows the arguments",
+			args:        []string{"sbom:" + filepath.Join(dir, "web.json")},
+			targetsFile: targetsFile,
+			want:        []string{"sbom:" + filepath.Join(dir, "web.json"), "alpine:3.20", "debian:12"},
+		},
+		{
+			name:    "glob without matches",
+			args:    []string{"sbom:" + filepath.Join(dir, "*.xml")},
+			wantErr: require.Error,
+		},
+		{
+			name:        "missing targets file",
+			targetsFile: filepath.Join(dir, "missing.txt"),
+			wantErr:     require.Error,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.wantErr == nil {
+				tt.wantErr = require.NoError
+			}
+			got, err := expandTargets(tt.args, tt.targetsFile)
+			tt.wantErr(t, err)
+			if err != nil {
+				return
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestTargetName(t *testing.T) {
+	dir := t.TempDir()
+	sbomPath := filepath.Join(dir, "api.syft.json")
+	require.NoError(t, os.WriteFile(sbomPath, []byte("{}"), 0600))
+
+	tests := []struct {
+		target string
+		want   string
+	}{
+		{target: "sbom:" + sbomPath, want: "api.syft"},
+		{target: sbomPath, want: "api.syft"},
+		{target: "docker.io/anchore/test:1.0", want: "docker.io_anchore_test_1.0"},
+		{target: "registry:alpine@sha256:1234", want: "registry_alpine_sha256_1234"},
+		{target: "///", want: "target"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.target, func(t *testing.T) {
+			assert.Equal(t, tt.want, targetName(tt.target))
+		})
+	}
+}
+
+func TestTargetOutputPaths(t *testing.T) {
+	targets := []string{"alpine:3.20", "debian:12"}
+
+	t.Run("paths for each target and format", func(t *testing.T) {
+		got, err := targetOutputPaths("reports/{{.Index}}-{{.Name}}.{{.Format}}", targets, []format.Format{format.JSONFormat, format.TableFormat})
+		require.NoError(t, err)
+		assert.Equal(t, []map[format.Format]string{
+			{format.JSONFormat: "reports/1-alpine_3.20.json", format.TableFormat: "reports/1-alpine_3.20.table"},
+			{format.JSONFormat: "reports/2-debian_12.json", format.TableFormat: "reports/2-debian_12.table"},
+		}, got)
+	})
+
+	t.Run("paths must be unique", func(t *testing.T) {
+		_, err := targetOutputPaths("reports/{{.Name}}.out", targets, []format.Format{format.JSONFormat, format.TableFormat})
+		require.ErrorContains(t, err, "unique path")
+	})
+
+	t.Run("unknown fields are rejected", func(t *testing.T) {
+		_, err := targetOutputPaths("reports/{{.Bogus}}.json", targets, []format.Format{format.JSONFormat})
+		require.Error(t, err)
+	})
+}
+
+func TestParseTargetsOutputs(t *testing.T) {
+	tests := []struct {
+		name      string
+		outputs   []string
+		file      string
+		perTarget bool
+		want      []targetsOutput
+		wantErr   string
+	}{
+		{
+			name: "defaults to table",
+			want: []targetsOutput{{format: format.TableFormat}},
+		},
+		{
+			name:    "combined report files",
+			outputs: []string{"json=all.json", "table"},
+			file:    "summary.txt",
+			want:    []targetsOutput{{format: format.JSONFormat, path: "all.json"}, {format: format.TableFormat, path: "summary.txt"}},
+		},
+		{
+			name:    "formats without a combined report",
+			outputs: []string{"cyclonedx"},
+			wantErr: "--target-output",
+		},
+		{
+			name:      "any format per target",
+			outputs:   []string{"cyclonedx", "sarif"},
+			perTarget: true,
+			want:      []targetsOutput{{format: format.CycloneDXFormat}, {format: format.SarifFormat}},
+		},
+		{
+			name:      "output files per target",
+			outputs:   []string{"json=all.json"},
+			perTarget: true,
+			wantErr:   "output file cannot be given",
+		},
+		{
+			name:    "unknown format",
+			outputs: []string{"bogus"},
+			wantErr: "unsupported output format",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts := &options.Grype{Outputs: tt.outputs, File: tt.file}
+			got, err := parseTargetsOutputs(opts, tt.perTarget)
+			if tt.wantErr != "" {
+				require.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestPresentTargetsSummary(t *testing.T) {
+	results := []targetResult{
+		{
+			summary: models.TargetSummary{
+				Target:     "alpine:3.20",
+				Packages:   15,
+				Matches:    4,
+				Ignored:    1,
+				Severities: map[string]int{"critical": 1, "high": 2, "negligible": 1},
+			},
+		},
+		{
+			summary: models.TargetSummary{
+				Target:     "sbom:api.json",
+				Packages:   120,
+				Severities: map[string]int{},
+			},
+		},
+	}
+
+	expected := `TARGET         PACKAGES  CRITICAL  HIGH  MEDIUM  LOW  OTHER  TOTAL  IGNORED  
+alpine:3.20    15        1         2     0       0    1      4      1        
+sbom:api.json  120       0         0     0       0    0      0      0        
+`
+
+	var output bytes.Buffer
+	require.NoError(t, presentTargetsSummary(&output, results))
+	assert.Equal(t, expected, output.String())
+}
+
+func newTestTargetsScan(t *testing.T, outputs []targetsOutput, outputPaths []map[format.Format]string) targetsScan {
+	t.Helper()
+
+	id := clio.Identification{Name: "grype", Version: "test"}
+	opts := options.DefaultGrype(id)
+	vp := mock.VulnerabilityProvider(vulnerability.Vulnerability{
+		PackageName: "lodash",
+		Constraint:  version.MustGetConstraint("< 4.17.21", version.UnknownFormat),
+		Reference:   vulnerability.Reference{ID: "GHSA-test-lodash", Namespace: "github:language:javascript"},
+	})
+
+	return targetsScan{
+		id:          id,
+		opts:        opts,
+		provider:    vp,
+		status:      &vulnerability.ProviderStatus{SchemaVersion: "v6.0.0"},
+		matcher:     getVulnerabilityMatcher(opts, vp, nil),
+		outputs:     outputs,
+		outputPaths: outputPaths,
+	}
+}
+
+func TestTargetsScan_combinedReport(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "report.json")
+	targets := []string{"pkg:npm/lodash@4.17.15", "pkg:npm/lodash@4.17.21", "purl:does-not-exist.txt"}
+
+	err := newTestTargetsScan(t, []targetsOutput{{format: format.JSONFormat, path: path}}, nil).run(targets)
+	require.ErrorContains(t, err, "failed to catalog")
+
+	contents, err := os.ReadFile(path)
+	require.NoError(t, err)
+
+	var doc models.TargetsDocument
+	require.NoError(t, json.Unmarshal(contents, &doc))
+
+	require.Len(t, doc.Targets, 2)
+	require.Len(t, doc.Targets["pkg:npm/lodash@4.17.15"].Matches, 1)
+	assert.Equal(t, "GHSA-test-lodash", doc.Targets["pkg:npm/lodash@4.17.15"].Matches[0].Vulnerability.ID)
+	assert.Empty(t, doc.Targets["pkg:npm/lodash@4.17.21"].Matches)
+
+	require.Len(t, doc.Summary, 3)
+	assert.Equal(t, []string{"pkg:npm/lodash@4.17.15", "pkg:npm/lodash@4.17.21", "purl:does-not-exist.txt"},
+		[]string{doc.Summary[0].Target, doc.Summary[1].Target, doc.Summary[2].Target})
+	assert.Equal(t, 1, doc.Summary[0].Matches)
+	assert.Equal(t, 0, doc.Summary[1].Matches)
+	assert.NotEmpty(t, doc.Summary[2].Error)
+}
+
+func TestTargetsScan_reportPerTarget(t *testing.T) {
+	dir := t.TempDir()
+	targets := []string{"pkg:npm/lodash@4.17.15", "pkg:npm/lodash@4.17.21"}
+
+	outputs := []targetsOutput{{format: format.JSONFormat}, {format: format.TableFormat}}
+	paths, err := targetOutputPaths(filepath.Join(dir, "{{.Index}}.{{.Format}}"), targets, []format.Format{format.JSONFormat, format.TableFormat})
+	require.NoError(t, err)
+
+	// the summary of all targets is reported to the UI
+	if redact.Get() == nil {
+		redact.Set(loggerRedact.NewStore())
+	}
+
+	require.NoError(t, newTestTargetsScan(t, outputs, paths).run(targets))
+
+	contents, err := os.ReadFile(filepath.Join(dir, "1.json"))
+	require.NoError(t, err)
+	var doc models.Document
+	require.NoError(t, json.Unmarshal(contents, &doc))
+	require.Len(t, doc.Matches, 1)
+	assert.Equal(t, "GHSA-test-lodash", doc.Matches[0].Vulnerability.ID)
+
+	contents, err = os.ReadFile(filepath.Join(dir, "2.table"))
+	require.NoError(t, err)
+	assert.Equal(t, "No vulnerabilities found\n", string(contents))
+}
@@ -0,0 +1,71 @@
+package options
+
+import (
+	"github.com/anchore/clio"
+	"github.com/anchore/grype/grype/pkg"
+)
+
+// cpeConfig contains the user-provided CPEs for packages and the CPEs that should never be used for matching.
+type cpeConfig struct {
+	Overrides []cpeOverride `yaml:"overrides" json:"overrides" mapstructure:"overrides"` // explicit CPEs for selected packages
+	Deny      []cpeDenial   `yaml:"deny" json:"deny" mapstructure:"deny"`                // CPE vendor/products removed from all packages
+}
+
+type cpeOverride struct {
+	Type    string   `yaml:"type" json:"type" mapstructure:"type"`          // the package type (e.g. java-archive)
+	Name    string   `yaml:"name" json:"name" mapstructure:"name"`          // a regular expression the package name must match
+	PURL    string   `yaml:"purl" json:"purl" mapstructure:"purl"`          // a glob the package URL must match
+	CPEs    []string `yaml:"cpes" json:"cpes" mapstructure:"cpes"`          // the CPEs to assign to the selected packages
+	Replace bool     `yaml:"replace" json:"replace" mapstructure:"replace"` // replace the CPEs of the package instead of adding to them
+}
+
+type cpeDenial struct {
+	Vendor  string `yaml:"vendor" json:"vendor" mapstructure:"vendor"`
+	Product string `yaml:"product" json:"product" mapstructure:"product"`
+}
+
+var _ interface {
+	clio.PostLoader
+	clio.FieldDescriber
+} = (*cpeConfig)(nil)
+
+func (cfg *cpeConfig) PostLoad() error {
+	return cfg.ToConfig().Validate()
+}
+
+func (cfg *cpeConfig) DescribeFields(descriptions clio.FieldDescriptionSet) {
+	descriptions.Add(&cfg.Overrides, `explicit CPEs for packages that are selected by type, name (a regular expression) and/or package URL (a
+glob, where '**' also matches '/'); all given selectors must match. CPEs without a version take the version of
+the package, and are added to the CPEs of the package unless 'replace' is set, for example:
+  - type: java-archive
+    name: "^acme-logging$"
+    cpes: ["cpe:2.3:a:apache:log4j:*:*:*:*:*:*:*:*"]
+    replace: true
+  - purl: "pkg:generic/vendored/zlib@*"
+    cpes: ["cpe:2.3:a:zlib:zlib:*:*:*:*:*:*:*:*"]`)
+	descriptions.Add(&cfg.Deny, `CPEs that are removed from every package so are never matched, by vendor and/or product ('*' or
+unset matches any value), for example:
+  - vendor: apache
+    product: tomcat`)
+}
+
+// ToConfig returns the CPE configuration applied when providing packages
+func (cfg cpeConfig) ToConfig() pkg.CPEConfig {
+	var out pkg.CPEConfig
+	for _, o := range cfg.Overrides {
+		out.Overrides = append(out.Overrides, pkg.CPEOverride{
+			Type:        o.Type,
+			NamePattern: o.Name,
+			PURLPattern: o.PURL,
+			CPEs:        o.CPEs,
+			Replace:     o.Replace,
+		})
+	}
+	for _, d := range cfg.Deny {
+		out.Deny = append(out.Deny, pkg.CPEDenial{
+			Vendor:  d.Vendor,
+			Product: d.Product,
+		})
+	}
+	return out
+}
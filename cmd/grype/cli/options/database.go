@@ -1,17 +1,21 @@
 package options
 
 import (
+	"fmt"
 	"time"
 
 	"github.com/anchore/clio"
 	"github.com/anchore/go-homedir"
+	v6 "github.com/anchore/grype/grype/db/v6"
 	"github.com/anchore/grype/grype/db/v6/distribution"
 	"github.com/anchore/grype/grype/db/v6/installation"
+	"github.com/anchore/grype/grype/db/v6/osv"
 )
 
 type Database struct {
 	ID                      clio.Identification `yaml:"-" json:"-" mapstructure:"-"`
 	Dir                     string              `yaml:"cache-dir" json:"cache-dir" mapstructure:"cache-dir"`
+	AdditionalDirs          []string            `yaml:"additional-cache-dirs" json:"additional-cache-dirs" mapstructure:"additional-cache-dirs"`
 	UpdateURL               string              `yaml:"update-url" json:"update-url" mapstructure:"update-url"`
 	CACert                  string              `yaml:"ca-cert" json:"ca-cert" mapstructure:"ca-cert"`
 	AutoUpdate              bool                `yaml:"auto-update" json:"auto-update" mapstructure:"auto-update"`
@@ -22,6 +26,14 @@ type Database struct {
 	UpdateAvailableTimeout  time.Duration       `yaml:"update-available-timeout" json:"update-available-timeout" mapstructure:"update-available-timeout"`
 	UpdateDownloadTimeout   time.Duration       `yaml:"update-download-timeout" json:"update-download-timeout" mapstructure:"update-download-timeout"`
 	MaxUpdateCheckFrequency time.Duration       `yaml:"max-update-check-frequency" json:"max-update-check-frequency" mapstructure:"max-update-check-frequency"`
+	SignatureKey            string              `yaml:"signature-key" json:"signature-key" mapstructure:"signature-key"`
+	RequireSignature        bool                `yaml:"require-signature" json:"require-signature" mapstructure:"require-signature"`
+	LocalAdvisories         []string            `yaml:"local-advisories" json:"local-advisories" mapstructure:"local-advisories"`
+	LocalAdvisoriesProvider string              `yaml:"local-advisories-provider" json:"local-advisories-provider" mapstructure:"local-advisories-provider"`
+	PackageOverrides        string              `yaml:"package-overrides" json:"package-overrides" mapstructure:"package-overrides"`
+
+	// packageAliases are the aliases read from the package-overrides file
+	packageAliases []v6.PackageSpecifierAlias
 }
 
 var _ interface {
@@ -46,11 +58,14 @@ func DefaultDatabase(id clio.Identification) Database {
 		UpdateDownloadTimeout:   distConfig.UpdateTimeout,
 		MaxUpdateCheckFrequency: installConfig.UpdateCheckMaxFrequency,
 		CACert:                  distConfig.CACert,
+		LocalAdvisoriesProvider: osv.DefaultProvider,
 	}
 }
 
 func (cfg *Database) DescribeFields(descriptions clio.FieldDescriptionSet) {
 	descriptions.Add(&cfg.Dir, `location to write the vulnerability database cache`)
+	descriptions.Add(&cfg.AdditionalDirs, `additional vulnerability database cache directories (e.g. populated with "grype db import" using another cache-dir)
+to find vulnerabilities from, in order of priority after the cache-dir (these are never updated, nor held to the max-allowed-built-age)`)
 	descriptions.Add(&cfg.UpdateURL, `URL of the vulnerability database`)
 	descriptions.Add(&cfg.CACert, `certificate to trust download the database and listing file`)
 	descriptions.Add(&cfg.AutoUpdate, `check for database updates on execution`)
@@ -65,10 +80,71 @@ This file is ~156KiB as of 2024-04-17 so the download should be quick; adjust as
 	descriptions.Add(&cfg.UpdateDownloadTimeout, `Timeout for downloading actual vulnerability DB
 The DB is ~156MB as of 2024-04-17 so slower connections may exceed the default timeout; adjust as needed`)
 	descriptions.Add(&cfg.MaxUpdateCheckFrequency, `Maximum frequency to check for vulnerability database updates`)
+	descriptions.Add(&cfg.SignatureKey, `public key (PEM encoded ECDSA, Ed25519, or RSA) to verify the detached signatures (".sig" files)
+of the listing file and database archives with`)
+	descriptions.Add(&cfg.RequireSignature, `fail the database update or import if the listing file or database archive is not signed by the signature-key`)
+	descriptions.Add(&cfg.LocalAdvisories, `directories of OSV JSON or simple YAML advisories to find vulnerabilities from in addition to the database`)
+	descriptions.Add(&cfg.LocalAdvisoriesProvider, `the provider name that vulnerabilities from the local-advisories are attributed to (the prefix of their namespace)`)
+	descriptions.Add(&cfg.PackageOverrides, `YAML file of package aliases applied when searching the database, in addition to the aliases within the database
+(e.g. to match renamed or forked packages by the upstream name, or a custom package type as a known ecosystem), for example:
+  - ecosystem: python
+    name: acme-requests
+    replacement-name: requests
+  - ecosystem: npm
+    name: "@acme/*"
+    replacement-name: "*"
+  - ecosystem: acme-wheel
+    replacement-ecosystem: python`)
 }
 
 func (cfg *Database) PostLoad() error {
 	var err error
 	cfg.Dir, err = homedir.Expand(cfg.Dir)
-	return err
+	if err != nil {
+		return err
+	}
+
+	for i, dir := range cfg.AdditionalDirs {
+		cfg.AdditionalDirs[i], err = homedir.Expand(dir)
+		if err != nil {
+			return err
+		}
+	}
+
+	cfg.SignatureKey, err = homedir.Expand(cfg.SignatureKey)
+	if err != nil {
+		return err
+	}
+
+	for i, dir := range cfg.LocalAdvisories {
+		cfg.LocalAdvisories[i], err = homedir.Expand(dir)
+		if err != nil {
+			return err
+		}
+	}
+
+	cfg.PackageOverrides, err = homedir.Expand(cfg.PackageOverrides)
+	if err != nil {
+		return err
+	}
+
+	cfg.packageAliases = nil
+	if cfg.PackageOverrides != "" {
+		cfg.packageAliases, err = v6.ReadPackageSpecifierAliases(cfg.PackageOverrides)
+		if err != nil {
+			return err
+		}
+	}
+
+	if cfg.RequireSignature && cfg.SignatureKey == "" {
+		return fmt.Errorf("a signature-key must be provided when require-signature is enabled")
+	}
+	return nil
+}
+
+func (cfg Database) signatureConfig() distribution.SignatureConfig {
+	return distribution.SignatureConfig{
+		PublicKey: cfg.SignatureKey,
+		Required:  cfg.RequireSignature,
+	}
 }
@@ -4,6 +4,7 @@ import (
 	"github.com/anchore/clio"
 	"github.com/anchore/grype/grype/db/v6/distribution"
 	"github.com/anchore/grype/grype/db/v6/installation"
+	"github.com/anchore/grype/grype/db/v6/osv"
 )
 
 type DatabaseCommand struct {
@@ -31,9 +32,24 @@ func (cfg DatabaseCommand) ToCuratorConfig() installation.Config {
 		MaxAllowedBuiltAge:      cfg.DB.MaxAllowedBuiltAge,
 		UpdateCheckMaxFrequency: cfg.DB.MaxUpdateCheckFrequency,
 		Debug:                   cfg.Developer.DB.Debug,
+		Signature:               cfg.DB.signatureConfig(),
+		PackageAliases:          cfg.DB.packageAliases,
 	}
 }
 
+// ToAdditionalCuratorConfigs returns the curator configuration for each of the additional database cache directories.
+// Additional DBs are never updated by grype, so they are not held to the max allowed age (but are still checksummed).
+func (cfg DatabaseCommand) ToAdditionalCuratorConfigs() []installation.Config {
+	var out []installation.Config
+	for _, dir := range cfg.DB.AdditionalDirs {
+		c := cfg.ToCuratorConfig()
+		c.DBRootDir = dir
+		c.ValidateAge = false
+		out = append(out, c)
+	}
+	return out
+}
+
 func (cfg DatabaseCommand) ToClientConfig() distribution.Config {
 	return distribution.Config{
 		ID:                 cfg.DB.ID,
@@ -42,5 +58,13 @@ func (cfg DatabaseCommand) ToClientConfig() distribution.Config {
 		RequireUpdateCheck: cfg.DB.RequireUpdateCheck,
 		CheckTimeout:       cfg.DB.UpdateAvailableTimeout,
 		UpdateTimeout:      cfg.DB.UpdateDownloadTimeout,
+		Signature:          cfg.DB.signatureConfig(),
+	}
+}
+
+func (cfg DatabaseCommand) ToLocalAdvisoriesConfig() osv.OverlayConfig {
+	return osv.OverlayConfig{
+		Dirs:     cfg.DB.LocalAdvisories,
+		Provider: cfg.DB.LocalAdvisoriesProvider,
 	}
 }
@@ -16,6 +16,7 @@ type Grype struct {
 	Pretty                     bool               `yaml:"pretty" json:"pretty" mapstructure:"pretty"`
 	Distro                     string             `yaml:"distro" json:"distro" mapstructure:"distro"`                                           // --distro, specify a distro to explicitly use
 	GenerateMissingCPEs        bool               `yaml:"add-cpes-if-none" json:"add-cpes-if-none" mapstructure:"add-cpes-if-none"`             // --add-cpes-if-none, automatically generate CPEs if they are not present in import (e.g. from a 3rd party SPDX document)
+	CPEs                       cpeConfig          `yaml:"cpes" json:"cpes" mapstructure:"cpes"`                                                 // user-provided CPEs for packages and CPEs that are never matched
 	OutputTemplateFile         string             `yaml:"output-template-file" json:"output-template-file" mapstructure:"output-template-file"` // -t, the template file to use for formatting the final report
 	CheckForAppUpdate          bool               `yaml:"check-for-app-update" json:"check-for-app-update" mapstructure:"check-for-app-update"` // whether to check for an application update on start up or not
 	OnlyFixed                  bool               `yaml:"only-fixed" json:"only-fixed" mapstructure:"only-fixed"`                               // only fail if detected vulns have a fix
@@ -28,6 +29,9 @@ type Grype struct {
 	ExternalSources            externalSources    `yaml:"external-sources" json:"externalSources" mapstructure:"external-sources"`
 	Match                      matchConfig        `yaml:"match" json:"match" mapstructure:"match"`
 	FailOn                     string             `yaml:"fail-on-severity" json:"fail-on-severity" mapstructure:"fail-on-severity"`
+	FailOnRisk                 float64            `yaml:"fail-on-risk" json:"fail-on-risk" mapstructure:"fail-on-risk"`
+	FailOnKEV                  bool               `yaml:"fail-on-kev" json:"fail-on-kev" mapstructure:"fail-on-kev"`
+	FailOnEPSSPercentile       float64            `yaml:"fail-on-epss-percentile" json:"fail-on-epss-percentile" mapstructure:"fail-on-epss-percentile"`
 	Registry                   registry           `yaml:"registry" json:"registry" mapstructure:"registry"`
 	ShowSuppressed             bool               `yaml:"show-suppressed" json:"show-suppressed" mapstructure:"show-suppressed"`
 	ByCVE                      bool               `yaml:"by-cve" json:"by-cve" mapstructure:"by-cve"` // --by-cve, indicates if the original match vulnerability IDs should be preserved or the CVE should be used instead
@@ -36,6 +40,9 @@ type Grype struct {
 	DefaultImagePullSource     string             `yaml:"default-image-pull-source" json:"default-image-pull-source" mapstructure:"default-image-pull-source"`
 	VexDocuments               []string           `yaml:"vex-documents" json:"vex-documents" mapstructure:"vex-documents"`
 	VexAdd                     []string           `yaml:"vex-add" json:"vex-add" mapstructure:"vex-add"`                                                                   // GRYPE_VEX_ADD
+	Baseline                   string             `yaml:"baseline" json:"baseline" mapstructure:"baseline"`                                                                // --baseline, a grype JSON report of known findings to suppress
+	Policy                     string             `yaml:"policy" json:"policy" mapstructure:"policy"`                                                                      // --policy, a policy file with rules to warn or fail on findings
+	MinConfidence              float64            `yaml:"min-confidence" json:"min-confidence" mapstructure:"min-confidence"`                                              // --min-confidence, matches less certain than this are ignored
 	MatchUpstreamKernelHeaders bool               `yaml:"match-upstream-kernel-headers" json:"match-upstream-kernel-headers" mapstructure:"match-upstream-kernel-headers"` // Show matches on kernel-headers packages where the match is on kernel upstream instead of marking them as ignored, default=false
 	DatabaseCommand            `yaml:",inline" json:",inline" mapstructure:",squash"`
 }
@@ -110,6 +117,21 @@ func (o *Grype) AddFlags(flags clio.FlagSet) {
 		fmt.Sprintf("set the return code to 1 if a vulnerability is found with a severity >= the given severity, options=%v", vulnerability.AllSeverities()),
 	)
 
+	flags.Float64VarP(&o.FailOnRisk,
+		"fail-on-risk", "",
+		"set the return code to 2 if a vulnerability is found with a risk score >= the given score (0-100)",
+	)
+
+	flags.BoolVarP(&o.FailOnKEV,
+		"fail-on-kev", "",
+		"set the return code to 2 if a known exploited vulnerability (CISA KEV) is found",
+	)
+
+	flags.Float64VarP(&o.FailOnEPSSPercentile,
+		"fail-on-epss-percentile", "",
+		"set the return code to 2 if a vulnerability is found with an EPSS percentile >= the given percentile (0-1)",
+	)
+
 	flags.BoolVarP(&o.OnlyFixed,
 		"only-fixed", "",
 		"ignore matches for vulnerabilities that are not fixed",
@@ -149,6 +171,21 @@ func (o *Grype) AddFlags(flags clio.FlagSet) {
 		"vex", "",
 		"a list of VEX documents to consider when producing scanning results",
 	)
+
+	flags.StringVarP(&o.Baseline,
+		"baseline", "",
+		"a grype JSON report of known findings; matches already in the baseline are ignored so only new findings are reported",
+	)
+
+	flags.StringVarP(&o.Policy,
+		"policy", "",
+		"a policy file with rules that warn or fail on findings (exit code 2 when a fail rule matches)",
+	)
+
+	flags.Float64VarP(&o.MinConfidence,
+		"min-confidence", "",
+		"ignore matches with a confidence (0-1) below the given value, such as loosely matching CPEs",
+	)
 }
 
 func (o *Grype) PostLoad() error {
@@ -158,6 +195,20 @@ func (o *Grype) PostLoad() error {
 			return fmt.Errorf("bad --fail-on severity value '%s'", o.FailOn)
 		}
 	}
+	if o.FailOnRisk < 0 || o.FailOnRisk > 100 {
+		return fmt.Errorf("bad --fail-on-risk value '%v', must be between 0 and 100", o.FailOnRisk)
+	}
+	if o.FailOnEPSSPercentile < 0 || o.FailOnEPSSPercentile > 1 {
+		return fmt.Errorf("bad --fail-on-epss-percentile value '%v', must be between 0 and 1", o.FailOnEPSSPercentile)
+	}
+	if o.MinConfidence < 0 || o.MinConfidence > 1 {
+		return fmt.Errorf("bad --min-confidence value '%v', must be between 0 and 1", o.MinConfidence)
+	}
+	for i := range o.Ignore {
+		if err := o.Ignore[i].ParseExpiration(); err != nil {
+			return fmt.Errorf("bad ignore rule: %w", err)
+		}
+	}
 	return nil
 }
 
@@ -181,6 +232,12 @@ when using template as the output type, you must also provide a value for 'outpu
 	descriptions.Add(&o.Pretty, `pretty-print output`)
 	descriptions.Add(&o.FailOn, `upon scanning, if a severity is found at or above the given severity then the return code will be 1
 default is unset which will skip this validation (options: negligible, low, medium, high, critical)`)
+	descriptions.Add(&o.FailOnRisk, `upon scanning, if a vulnerability is found with a risk score (0-100, combining EPSS, CVSS and KEV) at or
+above the given score then the return code will be 2, default is unset which will skip this validation`)
+	descriptions.Add(&o.FailOnKEV, `upon scanning, if a vulnerability in the CISA Known Exploited Vulnerabilities catalog is found then the
+return code will be 2`)
+	descriptions.Add(&o.FailOnEPSSPercentile, `upon scanning, if a vulnerability is found with an EPSS percentile (0-1) at or above the given
+percentile then the return code will be 2, default is unset which will skip this validation`)
 	descriptions.Add(&o.Ignore, `A list of vulnerability ignore rules, one or more property may be specified and all matching vulnerabilities will be ignored.
 This is the full set of supported rule fields:
   - vulnerability: CVE-2008-4318
@@ -190,12 +247,35 @@ This is the full set of supported rule fields:
       version: 1.5.1
       type: npm
       location: "/usr/local/lib/node_modules/**"
+    reason: "not reachable from the application entrypoint"
+    expires: "2024-12-31"  # the rule stops applying after this date (or an RFC3339 timestamp), which must be quoted
+    owner: security-team@example.com
+    ticket: SEC-1234
 
 VEX fields apply when Grype reads vex data:
   - vex-status: not_affected
     vex-justification: vulnerable_code_not_present
 `)
 	descriptions.Add(&o.VexAdd, `VEX statuses to consider as ignored rules`)
+	descriptions.Add(&o.Baseline, `a grype JSON report of known findings (see 'grype baseline'); matches for findings already in the
+baseline are ignored with the reason "baseline", so --fail-on only considers newly introduced findings`)
+	descriptions.Add(&o.MinConfidence, `ignore matches with a confidence (0-1) below the given value, with a reason starting with "low-confidence";
+exact ecosystem and distro matches are the most certain, while CPE matches are scored lower by how loosely the CPE
+matched and whether it was generated from package metadata, default is unset which will keep all matches`)
+	descriptions.Add(&o.Policy, `a YAML policy file with rules that warn or fail on findings, for example:
+rules:
+  - name: no-exploited-vulnerabilities
+    action: fail
+    kev: true
+  - name: stale-fixes
+    action: fail
+    severity: high
+    fix-age-days: 30
+    exclude-match-types: [cpe-match]
+  - name: likely-exploitable
+    action: warn
+    epss-percentile: 0.9
+the results are included in the JSON output and exit code 2 is returned when a "fail" rule matches`)
 	descriptions.Add(&o.MatchUpstreamKernelHeaders, `match kernel-header packages with upstream kernel as kernel vulnerabilities`)
 }
 
@@ -203,3 +283,28 @@ func (o Grype) FailOnSeverity() *vulnerability.Severity {
 	severity := vulnerability.ParseSeverity(o.FailOn)
 	return &severity
 }
+
+// FailOnRiskScore returns the risk score threshold, or nil when no threshold is configured
+func (o Grype) FailOnRiskScore() *float64 {
+	if o.FailOnRisk == 0 {
+		return nil
+	}
+	return &o.FailOnRisk
+}
+
+// FailOnEPSS returns the EPSS percentile threshold, or nil when no threshold is configured
+func (o Grype) FailOnEPSS() *float64 {
+	if o.FailOnEPSSPercentile == 0 {
+		return nil
+	}
+	return &o.FailOnEPSSPercentile
+}
+
+// DisableFailThresholds clears the severity, risk, KEV and EPSS thresholds, for commands whose results must never fail
+// the invocation
+func (o *Grype) DisableFailThresholds() {
+	o.FailOn = ""
+	o.FailOnRisk = 0
+	o.FailOnKEV = false
+	o.FailOnEPSSPercentile = 0
+}
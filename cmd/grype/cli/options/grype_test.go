@@ -0,0 +1,30 @@
+package options
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/anchore/clio"
+	"github.com/anchore/grype/grype/match"
+)
+
+func TestGrype_PostLoad_IgnoreRuleExpiration(t *testing.T) {
+	o := DefaultGrype(clio.Identification{})
+	o.Ignore = []match.IgnoreRule{
+		{Vulnerability: "CVE-2024-0001", Expires: "2024-12-31"},
+		{Vulnerability: "CVE-2024-0002"},
+	}
+	require.NoError(t, o.PostLoad())
+
+	// expirations are parsed once when the configuration is loaded
+	require.NotNil(t, o.Ignore[0].ExpiresAt)
+	assert.Equal(t, time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC), *o.Ignore[0].ExpiresAt)
+	require.NotNil(t, o.Ignore[1].ExpiresAt)
+	assert.True(t, o.Ignore[1].ExpiresAt.IsZero())
+
+	o.Ignore = []match.IgnoreRule{{Vulnerability: "CVE-2024-0001", Expires: "next tuesday"}}
+	require.ErrorContains(t, o.PostLoad(), "invalid expires value")
+}
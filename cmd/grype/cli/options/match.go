@@ -1,18 +1,23 @@
 package options
 
-import "github.com/anchore/clio"
+import (
+	"runtime"
+
+	"github.com/anchore/clio"
+)
 
 // matchConfig contains all matching-related configuration options available to the user via the application config.
 type matchConfig struct {
-	Java       matcherConfig `yaml:"java" json:"java" mapstructure:"java"`                   // settings for the java matcher
-	JVM        matcherConfig `yaml:"jvm" json:"jvm" mapstructure:"jvm"`                      // settings for the jvm matcher
-	Dotnet     matcherConfig `yaml:"dotnet" json:"dotnet" mapstructure:"dotnet"`             // settings for the dotnet matcher
-	Golang     golangConfig  `yaml:"golang" json:"golang" mapstructure:"golang"`             // settings for the golang matcher
-	Javascript matcherConfig `yaml:"javascript" json:"javascript" mapstructure:"javascript"` // settings for the javascript matcher
-	Python     matcherConfig `yaml:"python" json:"python" mapstructure:"python"`             // settings for the python matcher
-	Ruby       matcherConfig `yaml:"ruby" json:"ruby" mapstructure:"ruby"`                   // settings for the ruby matcher
-	Rust       matcherConfig `yaml:"rust" json:"rust" mapstructure:"rust"`                   // settings for the rust matcher
-	Stock      matcherConfig `yaml:"stock" json:"stock" mapstructure:"stock"`                // settings for the default/stock matcher
+	Java        matcherConfig `yaml:"java" json:"java" mapstructure:"java"`                      // settings for the java matcher
+	JVM         matcherConfig `yaml:"jvm" json:"jvm" mapstructure:"jvm"`                         // settings for the jvm matcher
+	Dotnet      matcherConfig `yaml:"dotnet" json:"dotnet" mapstructure:"dotnet"`                // settings for the dotnet matcher
+	Golang      golangConfig  `yaml:"golang" json:"golang" mapstructure:"golang"`                // settings for the golang matcher
+	Javascript  matcherConfig `yaml:"javascript" json:"javascript" mapstructure:"javascript"`    // settings for the javascript matcher
+	Python      matcherConfig `yaml:"python" json:"python" mapstructure:"python"`                // settings for the python matcher
+	Ruby        matcherConfig `yaml:"ruby" json:"ruby" mapstructure:"ruby"`                      // settings for the ruby matcher
+	Rust        matcherConfig `yaml:"rust" json:"rust" mapstructure:"rust"`                      // settings for the rust matcher
+	Stock       matcherConfig `yaml:"stock" json:"stock" mapstructure:"stock"`                   // settings for the default/stock matcher
+	Concurrency int           `yaml:"concurrency" json:"concurrency" mapstructure:"concurrency"` // the number of packages to search for matches in parallel
 }
 
 var _ interface {
@@ -67,4 +72,13 @@ func (cfg *matchConfig) DescribeFields(descriptions clio.FieldDescriptionSet) {
 	descriptions.Add(&cfg.Ruby.UseCPEs, usingCpeDescription)
 	descriptions.Add(&cfg.Rust.UseCPEs, usingCpeDescription)
 	descriptions.Add(&cfg.Stock.UseCPEs, usingCpeDescription)
+	descriptions.Add(&cfg.Concurrency, `the number of packages to search for vulnerability matches in parallel (0 uses the number of available CPUs)`)
+}
+
+// Workers returns the number of packages to search for matches in parallel
+func (cfg matchConfig) Workers() int {
+	if cfg.Concurrency > 0 {
+		return cfg.Concurrency
+	}
+	return runtime.NumCPU()
 }
@@ -0,0 +1,30 @@
+package options
+
+import (
+	"github.com/anchore/clio"
+)
+
+type Rescan struct {
+	PreviousDB string `yaml:"previous-db" json:"previous-db" mapstructure:"previous-db"` // --previous-db, the database the previous report was produced with
+}
+
+var _ interface {
+	clio.FlagAdder
+	clio.FieldDescriber
+} = (*Rescan)(nil)
+
+func DefaultRescan() Rescan {
+	return Rescan{}
+}
+
+func (o *Rescan) AddFlags(flags clio.FlagSet) {
+	flags.StringVarP(&o.PreviousDB,
+		"previous-db", "",
+		"the vulnerability database the previous report was produced with (a DB directory, vulnerability.db file, archive, or archive URL)",
+	)
+}
+
+func (o *Rescan) DescribeFields(descriptions clio.FieldDescriptionSet) {
+	descriptions.Add(&o.PreviousDB, `the vulnerability database the previous report was produced with (a DB directory, vulnerability.db file,
+archive, or archive URL). Its build time must match the database recorded in the report's descriptor`)
+}
@@ -0,0 +1,55 @@
+package options
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/anchore/clio"
+)
+
+type Serve struct {
+	Listen              string        `yaml:"listen" json:"listen" mapstructure:"listen"`                                              // --listen, the address to serve the HTTP API on
+	UpdateCheckInterval time.Duration `yaml:"update-check-interval" json:"update-check-interval" mapstructure:"update-check-interval"` // how often to check for vulnerability database updates while serving
+	MaxRequestSize      int64         `yaml:"max-request-size" json:"max-request-size" mapstructure:"max-request-size"`                // the largest SBOM (in bytes) accepted in a single request
+}
+
+var _ interface {
+	clio.FlagAdder
+	clio.PostLoader
+	clio.FieldDescriber
+} = (*Serve)(nil)
+
+func DefaultServe() Serve {
+	return Serve{
+		Listen:              "localhost:8080",
+		UpdateCheckInterval: time.Hour,
+		MaxRequestSize:      100 * 1024 * 1024,
+	}
+}
+
+func (o *Serve) AddFlags(flags clio.FlagSet) {
+	flags.StringVarP(&o.Listen,
+		"listen", "",
+		"address to serve the HTTP API on",
+	)
+}
+
+func (o *Serve) DescribeFields(descriptions clio.FieldDescriptionSet) {
+	descriptions.Add(&o.Listen, `address (host:port) to serve the HTTP API on`)
+	descriptions.Add(&o.UpdateCheckInterval, `how often to check for vulnerability database updates while serving (0 disables update checks)
+new databases are swapped in without interrupting scans that are in progress`)
+	descriptions.Add(&o.MaxRequestSize, `the largest SBOM (in bytes) accepted in a single scan request`)
+}
+
+func (o *Serve) PostLoad() error {
+	if o.Listen == "" {
+		return fmt.Errorf("a listen address is required")
+	}
+	if o.UpdateCheckInterval < 0 {
+		return fmt.Errorf("update-check-interval must not be negative, got %s", o.UpdateCheckInterval)
+	}
+	if o.MaxRequestSize <= 0 {
+		return fmt.Errorf("max-request-size must be greater than 0, got %d", o.MaxRequestSize)
+	}
+	return nil
+}
@@ -0,0 +1,57 @@
+package options
+
+import (
+	"fmt"
+	"text/template"
+
+	"github.com/anchore/clio"
+)
+
+type Targets struct {
+	File   string `yaml:"file" json:"file" mapstructure:"file"`       // --targets-file, a file listing the targets to scan, one per line
+	Output string `yaml:"output" json:"output" mapstructure:"output"` // --target-output, a path template to write one report per target to
+}
+
+var _ interface {
+	clio.FlagAdder
+	clio.PostLoader
+	clio.FieldDescriber
+} = (*Targets)(nil)
+
+func DefaultTargets() Targets {
+	return Targets{}
+}
+
+func (o *Targets) AddFlags(flags clio.FlagSet) {
+	flags.StringVarP(&o.File,
+		"targets-file", "",
+		"a file listing the targets to scan, one per line (glob patterns are expanded)",
+	)
+
+	flags.StringVarP(&o.Output,
+		"target-output", "",
+		"a path template to write one report per target to (e.g. 'reports/{{.Name}}.{{.Format}}')",
+	)
+}
+
+func (o *Targets) DescribeFields(descriptions clio.FieldDescriptionSet) {
+	descriptions.Add(&o.File, `a file listing the targets to scan in addition to any given as arguments, one per line
+blank lines and lines starting with '#' are ignored, and glob patterns (e.g. 'sbom:sboms/*.json') are expanded`)
+	descriptions.Add(&o.Output, `when scanning multiple targets, write one report per target to the path rendered from this Go template
+instead of a single combined report. The template is given:
+  .Index   the position of the target (starting at 1)
+  .Target  the target as given
+  .Name    a filename-safe name derived from the target
+  .Format  the output format of the report
+for example: reports/{{.Name}}.{{.Format}}`)
+}
+
+func (o *Targets) PostLoad() error {
+	if o.Output == "" {
+		return nil
+	}
+	if _, err := template.New("target-output").Option("missingkey=error").Parse(o.Output); err != nil {
+		return fmt.Errorf("bad target-output template: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,93 @@
+package baseline
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/anchore/grype/grype/match"
+	"github.com/anchore/grype/grype/presenter/models"
+	"github.com/anchore/grype/internal/log"
+)
+
+// Reason is the reason given on the synthetic ignore rule attached to matches suppressed by a baseline
+const Reason = "baseline"
+
+var _ match.IgnoreFilter = (*Baseline)(nil)
+
+// Baseline is a set of known findings from a previous grype scan. Matches for findings that are
+// already in the baseline are ignored, so that only newly introduced findings are reported.
+type Baseline struct {
+	findings map[string]struct{}
+}
+
+// Read loads a baseline from a grype JSON report
+func Read(path string) (*Baseline, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("unable to open baseline %q: %w", path, err)
+	}
+	defer log.CloseAndLogError(f, path)
+
+	var doc models.Document
+	if err := json.NewDecoder(f).Decode(&doc); err != nil {
+		return nil, fmt.Errorf("unable to parse baseline %q: %w", path, err)
+	}
+
+	b := FromDocument(doc)
+	log.WithFields("path", path, "findings", len(b.findings)).Debug("loaded baseline")
+	return b, nil
+}
+
+// FromDocument creates a baseline from the findings in a grype report. This includes the reported matches
+// and the matches that were already suppressed by a baseline, so that a report produced with a baseline can
+// itself be used as the baseline for the next scan.
+func FromDocument(doc models.Document) *Baseline {
+	b := &Baseline{findings: make(map[string]struct{})}
+
+	for _, m := range doc.Matches {
+		b.findings[m.FindingKey()] = struct{}{}
+	}
+
+	for _, m := range doc.IgnoredMatches {
+		if !IsBaselineRule(m.AppliedIgnoreRules...) {
+			continue
+		}
+		b.findings[m.Match.FindingKey()] = struct{}{}
+	}
+
+	return b
+}
+
+// IgnoreMatch returns a synthetic ignore rule when the match is a finding that is already in the baseline
+func (b *Baseline) IgnoreMatch(m match.Match) []match.IgnoreRule {
+	if b == nil {
+		return nil
+	}
+
+	var locations []string
+	for _, l := range m.Package.Locations.ToSlice() {
+		locations = append(locations, l.RealPath)
+	}
+
+	if _, ok := b.findings[models.FindingKey(m.Vulnerability.ID, m.Package.Name, m.Package.Version, string(m.Package.Type), locations)]; !ok {
+		return nil
+	}
+
+	return []match.IgnoreRule{
+		{
+			Vulnerability: m.Vulnerability.ID,
+			Reason:        Reason,
+		},
+	}
+}
+
+// IsBaselineRule indicates if any of the given ignore rules were applied because the match was in a baseline
+func IsBaselineRule(rules ...models.IgnoreRule) bool {
+	for _, r := range rules {
+		if r.Reason == Reason {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,98 @@
+package baseline
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/anchore/grype/grype/match"
+	"github.com/anchore/grype/grype/pkg"
+	"github.com/anchore/grype/grype/vulnerability"
+	"github.com/anchore/syft/syft/file"
+	syftPkg "github.com/anchore/syft/syft/pkg"
+)
+
+func newMatch(id, name, version, location string) match.Match {
+	return match.Match{
+		Vulnerability: vulnerability.Vulnerability{
+			Reference: vulnerability.Reference{ID: id},
+		},
+		Package: pkg.Package{
+			Name:      name,
+			Version:   version,
+			Type:      syftPkg.ApkPkg,
+			Locations: file.NewLocationSet(file.NewLocation(location)),
+		},
+	}
+}
+
+func TestRead(t *testing.T) {
+	b, err := Read("test-fixtures/baseline.json")
+	require.NoError(t, err)
+
+	tests := []struct {
+		name    string
+		match   match.Match
+		ignored bool
+	}{
+		{
+			name:    "finding in baseline matches",
+			match:   newMatch("CVE-2023-2650", "libcrypto3", "3.0.8-r3", "/lib/apk/db/installed"),
+			ignored: true,
+		},
+		{
+			name:    "finding suppressed by a previous baseline",
+			match:   newMatch("CVE-2023-1255", "libcrypto3", "3.0.8-r3", "/lib/apk/db/installed"),
+			ignored: true,
+		},
+		{
+			name:  "finding ignored by another rule is not part of the baseline",
+			match: newMatch("CVE-2023-0464", "libcrypto3", "3.0.8-r3", "/lib/apk/db/installed"),
+		},
+		{
+			name:  "new vulnerability",
+			match: newMatch("CVE-2023-9999", "libcrypto3", "3.0.8-r3", "/lib/apk/db/installed"),
+		},
+		{
+			name:  "different package version",
+			match: newMatch("CVE-2023-2650", "libcrypto3", "3.0.9-r0", "/lib/apk/db/installed"),
+		},
+		{
+			name:  "different location",
+			match: newMatch("CVE-2023-2650", "libcrypto3", "3.0.8-r3", "/other/apk/db/installed"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rules := b.IgnoreMatch(tt.match)
+			if !tt.ignored {
+				assert.Empty(t, rules)
+				return
+			}
+			require.Len(t, rules, 1)
+			assert.Equal(t, Reason, rules[0].Reason)
+			assert.Equal(t, tt.match.Vulnerability.ID, rules[0].Vulnerability)
+		})
+	}
+}
+
+func TestRead_missingFile(t *testing.T) {
+	_, err := Read("test-fixtures/does-not-exist.json")
+	require.Error(t, err)
+}
+
+func TestBaseline_ApplyIgnoreRules(t *testing.T) {
+	b, err := Read("test-fixtures/baseline.json")
+	require.NoError(t, err)
+
+	known := newMatch("CVE-2023-2650", "libcrypto3", "3.0.8-r3", "/lib/apk/db/installed")
+	added := newMatch("CVE-2023-9999", "libcrypto3", "3.0.8-r3", "/lib/apk/db/installed")
+
+	remaining, ignored := match.ApplyIgnoreFilters([]match.Match{known, added}, b)
+
+	require.Len(t, remaining, 1)
+	assert.Equal(t, "CVE-2023-9999", remaining[0].Vulnerability.ID)
+	require.Len(t, ignored, 1)
+	assert.Equal(t, "CVE-2023-2650", ignored[0].Vulnerability.ID)
+}
@@ -91,17 +91,25 @@ type AffectedPackageStoreReader interface {
 	GetAffectedPackages(pkg *PackageSpecifier, config *GetAffectedPackageOptions) ([]AffectedPackageHandle, error)
 }
 
+// AffectedPackageBatchStoreReader fetches affected packages for many package names at once, which is considerably
+// faster than calling GetAffectedPackages for each package when searching for a large number of packages.
+type AffectedPackageBatchStoreReader interface {
+	GetAffectedPackagesByName(ecosystem string, names []string, config *GetAffectedPackageOptions) ([]AffectedPackageHandle, error)
+}
+
 type affectedPackageStore struct {
 	db        *gorm.DB
 	blobStore *blobStore
 	osStore   *operatingSystemStore
+	aliases   []PackageSpecifierAlias
 }
 
-func newAffectedPackageStore(db *gorm.DB, bs *blobStore, oss *operatingSystemStore) *affectedPackageStore {
+func newAffectedPackageStore(db *gorm.DB, bs *blobStore, oss *operatingSystemStore, aliases ...PackageSpecifierAlias) *affectedPackageStore {
 	return &affectedPackageStore{
 		db:        db,
 		blobStore: bs,
 		osStore:   oss,
+		aliases:   aliases,
 	}
 }
 
@@ -231,6 +239,107 @@ func (s *affectedPackageStore) GetAffectedPackages(pkg *PackageSpecifier, config
 
 	query = s.handlePreload(query, *config)
 
+	models, err := s.findAffectedPackages(query, *config)
+	count = len(models)
+	return models, err
+}
+
+// GetAffectedPackagesByName returns the affected packages for any of the given package names within the ecosystem
+// (which may be empty to search across all ecosystems). The package is always preloaded so that callers can attribute
+// each result to the name that was searched for. Names are searched in chunks to limit the number of query variables,
+// so the Limit option is not supported. User-provided aliases are only applied to the ecosystem, so packages aliased by
+// name are to be searched for with GetAffectedPackages.
+func (s *affectedPackageStore) GetAffectedPackagesByName(ecosystem string, names []string, config *GetAffectedPackageOptions) ([]AffectedPackageHandle, error) {
+	if len(names) == 0 {
+		return nil, nil
+	}
+
+	cfg := GetAffectedPackageOptions{}
+	if config != nil {
+		cfg = *config
+	}
+	cfg.PreloadPackage = true
+	cfg.Limit = 0
+
+	start := time.Now()
+	count := 0
+	defer func() {
+		log.
+			WithFields(
+				"ecosystem", ecosystem,
+				"names", len(names),
+				"distro", cfg.OSs,
+				"vulns", cfg.Vulnerabilities,
+				"duration", time.Since(start),
+				"records", count,
+			).
+			Trace("fetched affected package records by name")
+	}()
+
+	spec := &PackageSpecifier{Ecosystem: ecosystem}
+	if err := s.applyPackageAlias(spec); err != nil {
+		log.Errorf("failed to apply package alias: %v", err)
+	}
+
+	var models []AffectedPackageHandle
+	for _, chunk := range chunk(uniqueNames(names), maxNamesPerQuery) {
+		query := s.db.Joins("JOIN packages ON affected_package_handles.package_id = packages.id").
+			Where("packages.name collate nocase IN ?", chunk)
+		if spec.Ecosystem != "" {
+			query = query.Where("packages.ecosystem = ? collate nocase", spec.Ecosystem)
+		}
+
+		var err error
+		query, err = s.handleVulnerabilityOptions(query, cfg.Vulnerabilities)
+		if err != nil {
+			return nil, err
+		}
+
+		query, err = s.handleOSOptions(query, cfg.OSs)
+		if err != nil {
+			return nil, err
+		}
+
+		query = s.handlePreload(query, cfg)
+
+		results, err := s.findAffectedPackages(query, cfg)
+		if err != nil {
+			return nil, err
+		}
+		models = append(models, results...)
+	}
+
+	count = len(models)
+	return models, nil
+}
+
+// uniqueNames returns the distinct names (ignoring case, as names are compared case-insensitively) in their original order
+func uniqueNames(names []string) []string {
+	seen := make(map[string]struct{}, len(names))
+	var out []string
+	for _, n := range names {
+		key := strings.ToLower(n)
+		if _, ok := seen[key]; ok {
+			continue
+		}
+		seen[key] = struct{}{}
+		out = append(out, n)
+	}
+	return out
+}
+
+func chunk[T any](values []T, size int) [][]T {
+	var out [][]T
+	for size < len(values) {
+		values, out = values[size:], append(out, values[:size:size])
+	}
+	if len(values) > 0 {
+		out = append(out, values)
+	}
+	return out
+}
+
+func (s *affectedPackageStore) findAffectedPackages(query *gorm.DB, config GetAffectedPackageOptions) ([]AffectedPackageHandle, error) {
 	var models []AffectedPackageHandle
 
 	var results []*AffectedPackageHandle
@@ -261,8 +370,6 @@ func (s *affectedPackageStore) GetAffectedPackages(pkg *PackageSpecifier, config
 			models = append(models, *r)
 		}
 
-		count += len(results)
-
 		if config.Limit > 0 && len(models) >= config.Limit {
 			return ErrLimitReached
 		}
@@ -302,7 +409,14 @@ func (s *affectedPackageStore) handlePackage(query *gorm.DB, p *PackageSpecifier
 	return query
 }
 
+// applyPackageAlias replaces the package name and ecosystem by the first matching user-provided alias (if any), then
+// replaces the ecosystem by any override found within the DB.
 func (s *affectedPackageStore) applyPackageAlias(d *PackageSpecifier) error {
+	if alias := s.findUserPackageAlias(d.Ecosystem, d.Name); alias != nil {
+		log.WithFields("ecosystem", d.Ecosystem, "name", d.Name, "alias", *alias).Trace("applying user package alias")
+		alias.apply(d)
+	}
+
 	if d.Ecosystem == "" {
 		return nil
 	}
@@ -339,6 +453,22 @@ func (s *affectedPackageStore) applyPackageAlias(d *PackageSpecifier) error {
 	return nil
 }
 
+func (s *affectedPackageStore) findUserPackageAlias(ecosystem, name string) *PackageSpecifierAlias {
+	for i := range s.aliases {
+		if s.aliases[i].matches(ecosystem, name) {
+			return &s.aliases[i]
+		}
+	}
+	return nil
+}
+
+// hasPackageNameAlias indicates if a user-provided alias applies to the given package by name (or replaces the name), in
+// which case the package must not be searched for with GetAffectedPackagesByName (as the name searched for would differ).
+func (s *affectedPackageStore) hasPackageNameAlias(ecosystem, name string) bool {
+	alias := s.findUserPackageAlias(ecosystem, name)
+	return alias != nil && (alias.Name != "" || alias.ReplacementName != "")
+}
+
 func (s *affectedPackageStore) handleVulnerabilityOptions(query *gorm.DB, configs []VulnerabilitySpecifier) (*gorm.DB, error) {
 	if len(configs) == 0 {
 		return query, nil
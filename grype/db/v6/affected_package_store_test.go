@@ -945,6 +945,130 @@ func TestAffectedPackageStore_GetAffectedPackages(t *testing.T) {
 	}
 }
 
+func TestAffectedPackageStore_GetAffectedPackagesByName(t *testing.T) {
+	db := setupTestStore(t).db
+	bs := newBlobStore(db)
+	oss := newOperatingSystemStore(db, bs)
+	s := newAffectedPackageStore(db, bs, oss)
+
+	pkg2d1 := testDistro1AffectedPackage2Handle()
+	pkg2 := testNonDistroAffectedPackage2Handle()
+	pkg2d2 := testDistro2AffectedPackage2Handle()
+	pkg3 := testNonDistroAffectedPackage2Handle()
+	pkg3.Package.Name = "pkg3"
+	pkg3.Vulnerability.Name = "CVE-2023-8910"
+	err := s.AddAffectedPackages(pkg2d1, pkg2, pkg2d2, pkg3)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name      string
+		ecosystem string
+		names     []string
+		options   *GetAffectedPackageOptions
+		expected  []AffectedPackageHandle
+	}{
+		{
+			name:  "no names",
+			names: nil,
+		},
+		{
+			name:  "many names across distros",
+			names: []string{"PKG2", "pkg3", "pkg2", "does-not-exist"},
+			options: &GetAffectedPackageOptions{
+				OSs: []*OSSpecifier{AnyOSSpecified},
+			},
+			expected: []AffectedPackageHandle{*pkg2d1, *pkg2, *pkg2d2, *pkg3},
+		},
+		{
+			name:      "ecosystem without distro",
+			ecosystem: "type2",
+			names:     []string{"pkg2", "pkg3"},
+			options: &GetAffectedPackageOptions{
+				OSs: []*OSSpecifier{NoOSSpecified},
+			},
+			expected: []AffectedPackageHandle{*pkg2, *pkg3},
+		},
+		{
+			name:  "specific distro",
+			names: []string{"pkg2", "pkg3"},
+			options: &GetAffectedPackageOptions{
+				OSs: []*OSSpecifier{{
+					Name:         "ubuntu",
+					MajorVersion: "20",
+					MinorVersion: "04",
+				}},
+			},
+			expected: []AffectedPackageHandle{*pkg2d1},
+		},
+		{
+			name:  "limit is not supported",
+			names: []string{"pkg2"},
+			options: &GetAffectedPackageOptions{
+				OSs:   []*OSSpecifier{AnyOSSpecified},
+				Limit: 1,
+			},
+			expected: []AffectedPackageHandle{*pkg2d1, *pkg2, *pkg2d2},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts := tt.options
+			if opts == nil {
+				opts = &GetAffectedPackageOptions{}
+			}
+			opts.PreloadOS = true
+			opts.PreloadBlob = true
+			opts.PreloadVulnerability = true
+
+			result, err := s.GetAffectedPackagesByName(tt.ecosystem, tt.names, opts)
+			require.NoError(t, err)
+			if d := cmp.Diff(tt.expected, result, cmpopts.EquateEmpty()); d != "" {
+				t.Errorf("unexpected result: %s", d)
+			}
+		})
+	}
+}
+
+func Test_chunk(t *testing.T) {
+	tests := []struct {
+		name   string
+		values []int
+		size   int
+		want   [][]int
+	}{
+		{
+			name:   "empty",
+			values: nil,
+			size:   2,
+			want:   nil,
+		},
+		{
+			name:   "smaller than chunk",
+			values: []int{1},
+			size:   2,
+			want:   [][]int{{1}},
+		},
+		{
+			name:   "exact multiple",
+			values: []int{1, 2, 3, 4},
+			size:   2,
+			want:   [][]int{{1, 2}, {3, 4}},
+		},
+		{
+			name:   "remainder",
+			values: []int{1, 2, 3, 4, 5},
+			size:   2,
+			want:   [][]int{{1, 2}, {3, 4}, {5}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, chunk(tt.values, tt.size))
+		})
+	}
+}
+
 func TestAffectedPackageStore_ApplyPackageAlias(t *testing.T) {
 	db := setupTestStore(t).db
 	bs := newBlobStore(db)
@@ -985,6 +1109,74 @@ func TestAffectedPackageStore_ApplyPackageAlias(t *testing.T) {
 	}
 }
 
+func TestAffectedPackageStore_ApplyPackageAlias_UserAliases(t *testing.T) {
+	db := setupTestStore(t).db
+	bs := newBlobStore(db)
+	oss := newOperatingSystemStore(db, bs)
+	s := newAffectedPackageStore(db, bs, oss,
+		PackageSpecifierAlias{Ecosystem: "python", Name: "acme-requests", ReplacementName: "requests"},
+		PackageSpecifierAlias{Ecosystem: "npm", Name: "@acme/*", ReplacementName: "*"},
+		PackageSpecifierAlias{Ecosystem: "npm", Name: "@acme-*", ReplacementName: "@upstream-*"},
+		PackageSpecifierAlias{Ecosystem: "acme-wheel", ReplacementEcosystem: "pypi"},
+		PackageSpecifierAlias{Name: "acme-openssl", ReplacementName: "openssl"},
+	)
+
+	tests := []struct {
+		name     string
+		input    *PackageSpecifier
+		expected *PackageSpecifier
+	}{
+		{
+			name:     "renamed package",
+			input:    &PackageSpecifier{Ecosystem: "python", Name: "Acme-Requests"},
+			expected: &PackageSpecifier{Ecosystem: "python", Name: "requests"},
+		},
+		{
+			name:     "forked scope",
+			input:    &PackageSpecifier{Ecosystem: "npm", Name: "@acme/lodash"},
+			expected: &PackageSpecifier{Ecosystem: "npm", Name: "lodash"},
+		},
+		{
+			name:     "first matching alias wins",
+			input:    &PackageSpecifier{Ecosystem: "npm", Name: "@acme-ui/button"},
+			expected: &PackageSpecifier{Ecosystem: "npm", Name: "@upstream-ui/button"},
+		},
+		{
+			name:     "custom type, then the DB override",
+			input:    &PackageSpecifier{Ecosystem: "acme-wheel", Name: "requests"},
+			expected: &PackageSpecifier{Ecosystem: "python", Name: "requests"},
+		},
+		{
+			name:     "any ecosystem",
+			input:    &PackageSpecifier{Ecosystem: "deb", Name: "acme-openssl"},
+			expected: &PackageSpecifier{Ecosystem: "deb", Name: "openssl"},
+		},
+		{
+			name:     "no ecosystem",
+			input:    &PackageSpecifier{Name: "acme-openssl"},
+			expected: &PackageSpecifier{Name: "openssl"},
+		},
+		{
+			name:     "other ecosystem",
+			input:    &PackageSpecifier{Ecosystem: "python", Name: "@acme/lodash"},
+			expected: &PackageSpecifier{Ecosystem: "python", Name: "@acme/lodash"},
+		},
+		{
+			name:     "DB override only",
+			input:    &PackageSpecifier{Ecosystem: "cargo", Name: "acme-requests"},
+			expected: &PackageSpecifier{Ecosystem: "rust-crate", Name: "acme-requests"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := s.applyPackageAlias(tt.input)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, tt.input)
+		})
+	}
+}
+
 func testDistro1AffectedPackage2Handle() *AffectedPackageHandle {
 	now := time.Date(2023, 1, 1, 3, 4, 5, 0, time.UTC)
 	later := now.Add(time.Hour * 200)
@@ -40,6 +40,11 @@ const (
 	// parameters in the query -- if above 999 then this will result in an error for sqlite. For this reason we
 	// try to keep this value well below 999.
 	batchSize = 300
+
+	// maxNamesPerQuery is the number of package names searched for in a single "WHERE name in (...)" query when
+	// fetching records for many packages at once, which is kept well below the sqlite parameter limit for the same
+	// reasons as batchSize.
+	maxNamesPerQuery = 300
 )
 
 var ErrDBCapabilityNotSupported = fmt.Errorf("capability not supported by DB")
@@ -54,8 +59,10 @@ type Reader interface {
 	ProviderStoreReader
 	VulnerabilityStoreReader
 	VulnerabilityDecoratorStoreReader
+	VulnerabilityDecoratorListStoreReader
 	OperatingSystemStoreReader
 	AffectedPackageStoreReader
+	AffectedPackageBatchStoreReader
 	AffectedCPEStoreReader
 	io.Closer
 	attachBlobValue(...blobable) error
@@ -82,6 +89,9 @@ type Curator interface {
 type Config struct {
 	DBDirPath string
 	Debug     bool
+
+	// PackageAliases are applied to the packages searched for, before any package overrides within the DB
+	PackageAliases []PackageSpecifierAlias
 }
 
 func (c Config) DBFilePath() string {
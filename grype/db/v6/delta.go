@@ -0,0 +1,308 @@
+package v6
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+
+	"gorm.io/gorm"
+
+	"github.com/anchore/grype/internal/log"
+)
+
+// DeltaFileName is the name of the file within a delta archive that holds the row changes that transform one database
+// build into the next (including updating the DB metadata to describe the resulting build). Each line is a JSON
+// encoded DeltaOperation.
+const DeltaFileName = "delta.jsonl"
+
+// DeltaOp is the kind of change a DeltaOperation makes to a single row
+type DeltaOp string
+
+const (
+	DeltaInsert DeltaOp = "insert"
+	DeltaUpdate DeltaOp = "update"
+	DeltaDelete DeltaOp = "delete"
+)
+
+// DeltaOperation is a change to a single row of a table in the v6 schema. Rows are selected (for updates and deletes)
+// by the values of all primary key columns of the table (or all rows for tables without a primary key, such as the DB
+// metadata). Only scalar values (strings, numbers, booleans and null) are supported.
+type DeltaOperation struct {
+	Table string         `json:"table"`
+	Op    DeltaOp        `json:"op"`
+	Key   map[string]any `json:"key,omitempty"`
+	Row   map[string]any `json:"row,omitempty"`
+}
+
+// deltaTable describes a table that deltas may change, as derived from the v6 models
+type deltaTable struct {
+	name       string
+	columns    []string
+	primaryKey []string
+}
+
+// ApplyDelta applies the row changes of a delta file to the database within the given directory in a single
+// transaction, returning the description of the resulting database. None of the changes are applied if any of them
+// are invalid (e.g. refer to a table or column that is not part of the v6 schema) or fail, or the resulting database
+// does not pass an integrity check. Deltas never contain SQL, so cannot do anything other than change rows.
+func ApplyDelta(dbDirPath, deltaFilePath string) (*Description, error) {
+	contents, err := os.ReadFile(deltaFilePath)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read delta: %w", err)
+	}
+
+	dbFilePath := filepath.Join(dbDirPath, VulnerabilityDBFileName)
+	if _, err := os.Stat(dbFilePath); err != nil {
+		return nil, fmt.Errorf("unable to access database to apply delta to: %w", err)
+	}
+
+	db, err := NewLowLevelDB(dbFilePath, false, true, false)
+	if err != nil {
+		return nil, fmt.Errorf("unable to open database to apply delta to: %w", err)
+	}
+	defer func() {
+		if d, err := db.DB(); err == nil {
+			log.CloseAndLogError(d, dbFilePath)
+		}
+	}()
+
+	tables, err := deltaTables(db)
+	if err != nil {
+		return nil, err
+	}
+
+	operations, err := readDeltaOperations(contents, tables)
+	if err != nil {
+		return nil, fmt.Errorf("invalid delta: %w", err)
+	}
+
+	err = db.Transaction(func(tx *gorm.DB) error {
+		// the changes are only consistent as a whole, so foreign keys are checked once all have been applied
+		if err := tx.Exec("PRAGMA defer_foreign_keys = ON").Error; err != nil {
+			return fmt.Errorf("unable to defer foreign key checks: %w", err)
+		}
+		for i, o := range operations {
+			if err := o.apply(tx, tables[o.Table]); err != nil {
+				return fmt.Errorf("unable to apply delta operation %d (%s %s): %w", i+1, o.Op, o.Table, err)
+			}
+		}
+		return checkIntegrity(tx)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	meta, err := newDBMetadataStore(db).GetDBMetadata()
+	if err != nil {
+		return nil, fmt.Errorf("unable to read DB metadata after applying delta: %w", err)
+	}
+
+	return DescriptionFromMetadata(meta), nil
+}
+
+// deltaTables returns the tables of the v6 schema by name, which are the only tables deltas may change
+func deltaTables(db *gorm.DB) (map[string]deltaTable, error) {
+	out := make(map[string]deltaTable)
+	for _, m := range Models() {
+		stmt := &gorm.Statement{DB: db}
+		if err := stmt.Parse(m); err != nil {
+			return nil, fmt.Errorf("unable to describe table for %T: %w", m, err)
+		}
+		out[stmt.Schema.Table] = deltaTable{
+			name:       stmt.Schema.Table,
+			columns:    stmt.Schema.DBNames,
+			primaryKey: stmt.Schema.PrimaryFieldDBNames,
+		}
+	}
+	return out, nil
+}
+
+// readDeltaOperations decodes and validates all operations of a delta before any are applied
+func readDeltaOperations(contents []byte, tables map[string]deltaTable) ([]DeltaOperation, error) {
+	var out []DeltaOperation
+	scanner := bufio.NewScanner(bytes.NewReader(contents))
+	scanner.Buffer(nil, 64*1024*1024) // blob values can be large
+	line := 0
+	for scanner.Scan() {
+		line++
+		raw := bytes.TrimSpace(scanner.Bytes())
+		if len(raw) == 0 {
+			continue
+		}
+
+		dec := json.NewDecoder(bytes.NewReader(raw))
+		dec.UseNumber()
+		dec.DisallowUnknownFields()
+		var o DeltaOperation
+		if err := dec.Decode(&o); err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+		if err := o.validate(tables); err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+		out = append(out, o)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (o DeltaOperation) validate(tables map[string]deltaTable) error {
+	t, ok := tables[o.Table]
+	if !ok {
+		return fmt.Errorf("unknown table %q", o.Table)
+	}
+
+	switch o.Op {
+	case DeltaInsert:
+		if len(o.Key) > 0 {
+			return fmt.Errorf("an insert into %q must not have a key", o.Table)
+		}
+		if len(o.Row) == 0 {
+			return fmt.Errorf("an insert into %q requires a row", o.Table)
+		}
+	case DeltaUpdate:
+		if len(o.Row) == 0 {
+			return fmt.Errorf("an update of %q requires a row", o.Table)
+		}
+	case DeltaDelete:
+		if len(o.Row) > 0 {
+			return fmt.Errorf("a delete from %q must not have a row", o.Table)
+		}
+	default:
+		return fmt.Errorf("unknown operation %q", o.Op)
+	}
+
+	if o.Op != DeltaInsert {
+		// rows are always selected by their complete primary key, so a single operation changes at most one row
+		if !slices.Equal(sortedKeys(o.Key), sortedStrings(t.primaryKey)) {
+			return fmt.Errorf("the key of a %s of %q must be exactly the primary key %v", o.Op, o.Table, t.primaryKey)
+		}
+	}
+
+	for _, values := range []map[string]any{o.Key, o.Row} {
+		for column, value := range values {
+			if !slices.Contains(t.columns, column) {
+				return fmt.Errorf("unknown column %q of table %q", column, o.Table)
+			}
+			switch value.(type) {
+			case nil, string, json.Number, bool:
+			default:
+				return fmt.Errorf("unsupported value for column %q of table %q: %T", column, o.Table, value)
+			}
+		}
+	}
+	return nil
+}
+
+// apply changes the row of the given (validated) table. Identifiers are only ever taken from the schema, and all
+// values are bound as parameters.
+func (o DeltaOperation) apply(tx *gorm.DB, t deltaTable) error {
+	var query strings.Builder
+	var args []any
+
+	where := func() {
+		if len(o.Key) == 0 {
+			return
+		}
+		var conditions []string
+		for _, column := range sortedKeys(o.Key) {
+			conditions = append(conditions, quoteIdentifier(column)+" = ?")
+			args = append(args, bindValue(o.Key[column]))
+		}
+		query.WriteString(" WHERE " + strings.Join(conditions, " AND "))
+	}
+
+	switch o.Op {
+	case DeltaInsert:
+		var columns, placeholders []string
+		for _, column := range sortedKeys(o.Row) {
+			columns = append(columns, quoteIdentifier(column))
+			placeholders = append(placeholders, "?")
+			args = append(args, bindValue(o.Row[column]))
+		}
+		fmt.Fprintf(&query, "INSERT INTO %s (%s) VALUES (%s)", quoteIdentifier(t.name), strings.Join(columns, ", "), strings.Join(placeholders, ", "))
+	case DeltaUpdate:
+		var assignments []string
+		for _, column := range sortedKeys(o.Row) {
+			assignments = append(assignments, quoteIdentifier(column)+" = ?")
+			args = append(args, bindValue(o.Row[column]))
+		}
+		fmt.Fprintf(&query, "UPDATE %s SET %s", quoteIdentifier(t.name), strings.Join(assignments, ", "))
+		where()
+	case DeltaDelete:
+		fmt.Fprintf(&query, "DELETE FROM %s", quoteIdentifier(t.name))
+		where()
+	}
+
+	result := tx.Exec(query.String(), args...)
+	if result.Error != nil {
+		return result.Error
+	}
+	if o.Op != DeltaInsert && result.RowsAffected == 0 {
+		return fmt.Errorf("no row found for key %v", o.Key)
+	}
+	return nil
+}
+
+func quoteIdentifier(name string) string {
+	return `"` + strings.ReplaceAll(name, `"`, `""`) + `"`
+}
+
+func sortedKeys(m map[string]any) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func sortedStrings(values []string) []string {
+	out := slices.Clone(values)
+	sort.Strings(out)
+	return out
+}
+
+// bindValue returns the value to bind for a (validated) column value, keeping integers as integers
+func bindValue(value any) any {
+	n, ok := value.(json.Number)
+	if !ok {
+		return value
+	}
+	if i, err := n.Int64(); err == nil {
+		return i
+	}
+	if f, err := n.Float64(); err == nil {
+		return f
+	}
+	return n.String()
+}
+
+// checkIntegrity ensures the database is consistent, including foreign key relationships
+func checkIntegrity(tx *gorm.DB) error {
+	var results []string
+	if err := tx.Raw("PRAGMA integrity_check").Scan(&results).Error; err != nil {
+		return fmt.Errorf("unable to check database integrity: %w", err)
+	}
+	if len(results) != 1 || results[0] != "ok" {
+		return fmt.Errorf("database integrity check failed: %v", results)
+	}
+
+	var violations []map[string]any
+	if err := tx.Raw("PRAGMA foreign_key_check").Scan(&violations).Error; err != nil {
+		return fmt.Errorf("unable to check database foreign keys: %w", err)
+	}
+	if len(violations) > 0 {
+		return fmt.Errorf("database has %d foreign key violations", len(violations))
+	}
+
+	return nil
+}
@@ -0,0 +1,163 @@
+package v6
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestApplyDelta(t *testing.T) {
+	newDB := func(t *testing.T) string {
+		dir := t.TempDir()
+		w, err := NewWriter(Config{DBDirPath: dir})
+		require.NoError(t, err)
+		require.NoError(t, w.AddProvider(Provider{ID: "alpine", Version: "1"}))
+		require.NoError(t, w.Close())
+		return dir
+	}
+
+	writeDelta := func(t *testing.T, operations string) string {
+		path := filepath.Join(t.TempDir(), DeltaFileName)
+		require.NoError(t, os.WriteFile(path, []byte(operations), 0600))
+		return path
+	}
+
+	readProviders := func(t *testing.T, dir string) map[string]string {
+		r, err := NewReader(Config{DBDirPath: dir})
+		require.NoError(t, err)
+		defer r.Close()
+
+		var providers []Provider
+		require.NoError(t, r.(*store).db.Order("id").Find(&providers).Error)
+		out := make(map[string]string)
+		for _, p := range providers {
+			out[p.ID] = p.Version
+		}
+		return out
+	}
+
+	t.Run("applies the delta", func(t *testing.T) {
+		dir := newDB(t)
+		delta := writeDelta(t, `
+{"table": "db_metadata", "op": "update", "row": {"build_timestamp": "2030-01-02 03:04:05+00:00"}}
+{"table": "providers", "op": "insert", "row": {"id": "debian", "version": "2"}}
+{"table": "providers", "op": "insert", "row": {"id": "nvd", "version": "1"}}
+{"table": "providers", "op": "update", "key": {"id": "alpine"}, "row": {"version": "3"}}
+{"table": "providers", "op": "delete", "key": {"id": "nvd"}}
+`)
+
+		got, err := ApplyDelta(dir, delta)
+		require.NoError(t, err)
+		assert.Equal(t, time.Date(2030, 1, 2, 3, 4, 5, 0, time.UTC), got.Built.UTC())
+		assert.Equal(t, ModelVersion, got.SchemaVersion.Model)
+
+		assert.Equal(t, map[string]string{"alpine": "3", "debian": "2"}, readProviders(t, dir))
+	})
+
+	t.Run("failed operations are rolled back", func(t *testing.T) {
+		dir := newDB(t)
+		before, err := ReadDescription(filepath.Join(dir, VulnerabilityDBFileName))
+		require.NoError(t, err)
+
+		delta := writeDelta(t, `
+{"table": "db_metadata", "op": "update", "row": {"build_timestamp": "2030-01-02 03:04:05+00:00"}}
+{"table": "providers", "op": "insert", "row": {"id": "debian", "version": "2"}}
+{"table": "providers", "op": "update", "key": {"id": "no-such-provider"}, "row": {"version": "3"}}
+`)
+
+		_, err = ApplyDelta(dir, delta)
+		require.ErrorContains(t, err, "unable to apply delta operation 3")
+
+		after, err := ReadDescription(filepath.Join(dir, VulnerabilityDBFileName))
+		require.NoError(t, err)
+		assert.Equal(t, before, after)
+		assert.Equal(t, map[string]string{"alpine": "1"}, readProviders(t, dir))
+	})
+
+	t.Run("foreign key violations are rejected", func(t *testing.T) {
+		dir := newDB(t)
+		delta := writeDelta(t, `
+{"table": "vulnerability_handles", "op": "insert", "row": {"id": 1, "name": "CVE-2030-0001", "status": "active", "provider_id": "no-such-provider", "blob_id": 1}}
+`)
+
+		_, err := ApplyDelta(dir, delta)
+		require.ErrorContains(t, err, "foreign key")
+		assert.Equal(t, map[string]string{"alpine": "1"}, readProviders(t, dir))
+	})
+
+	t.Run("rejects anything but row changes to the v6 schema", func(t *testing.T) {
+		tests := []struct {
+			name  string
+			delta string
+			want  string
+		}{
+			{
+				name:  "SQL statements",
+				delta: "ATTACH DATABASE 'attached.db' AS attached;\n",
+				want:  "line 1",
+			},
+			{
+				name:  "ATTACH as a table",
+				delta: `{"table": "providers\" (id) VALUES ('x'); ATTACH DATABASE 'attached.db' AS attached; --", "op": "insert", "row": {"id": "x"}}`,
+				want:  "unknown table",
+			},
+			{
+				name:  "VACUUM as a column",
+				delta: `{"table": "providers", "op": "update", "key": {"id": "alpine"}, "row": {"version\" = '1'; VACUUM INTO 'copy.db'; --": "1"}}`,
+				want:  "unknown column",
+			},
+			{
+				name:  "PRAGMA as a table",
+				delta: `{"table": "pragma_table_info", "op": "delete", "key": {"name": "id"}}`,
+				want:  "unknown table",
+			},
+			{
+				name:  "unknown operation",
+				delta: `{"table": "providers", "op": "pragma", "row": {"id": "x"}}`,
+				want:  "unknown operation",
+			},
+			{
+				name:  "unknown field",
+				delta: `{"table": "providers", "op": "insert", "row": {"id": "x"}, "sql": "VACUUM INTO 'copy.db'"}`,
+				want:  "unknown field",
+			},
+			{
+				name:  "partial key",
+				delta: `{"table": "providers", "op": "delete", "key": {"version": "1"}}`,
+				want:  "must be exactly the primary key",
+			},
+			{
+				name:  "non-scalar value",
+				delta: `{"table": "providers", "op": "insert", "row": {"id": {"nested": true}}}`,
+				want:  "unsupported value",
+			},
+		}
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				dir := newDB(t)
+				wd, err := os.Getwd()
+				require.NoError(t, err)
+				t.Chdir(dir)
+
+				_, err = ApplyDelta(dir, writeDelta(t, tt.delta))
+				require.ErrorContains(t, err, "invalid delta")
+				require.ErrorContains(t, err, tt.want)
+
+				assert.NoFileExists(t, filepath.Join(dir, "attached.db"))
+				assert.NoFileExists(t, filepath.Join(dir, "copy.db"))
+				assert.NoFileExists(t, filepath.Join(wd, "attached.db"))
+				assert.NoFileExists(t, filepath.Join(wd, "copy.db"))
+				assert.Equal(t, map[string]string{"alpine": "1"}, readProviders(t, dir))
+			})
+		}
+	})
+
+	t.Run("missing database", func(t *testing.T) {
+		_, err := ApplyDelta(t.TempDir(), writeDelta(t, ""))
+		require.ErrorContains(t, err, "unable to access database")
+	})
+}
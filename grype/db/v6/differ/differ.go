@@ -0,0 +1,735 @@
+package differ
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/wagoodman/go-partybus"
+	"github.com/wagoodman/go-progress"
+
+	v6 "github.com/anchore/grype/grype/db/v6"
+	"github.com/anchore/grype/grype/event"
+	"github.com/anchore/grype/grype/event/monitor"
+	"github.com/anchore/grype/internal/bus"
+)
+
+// ChangeType describes how a record differs between the base and target databases
+type ChangeType string
+
+const (
+	Added    ChangeType = "added"
+	Removed  ChangeType = "removed"
+	Modified ChangeType = "modified"
+)
+
+// Diff is the set of differences between two v6 databases
+type Diff struct {
+	Base             *v6.Description       `json:"base,omitempty"`
+	Target           *v6.Description       `json:"target,omitempty"`
+	Providers        []ProviderSummary     `json:"providers"`
+	Vulnerabilities  []VulnerabilityDiff   `json:"vulnerabilities"`
+	AffectedPackages []AffectedPackageDiff `json:"affectedPackages"`
+	AffectedCPEs     []AffectedCPEDiff     `json:"affectedCPEs"`
+	KnownExploited   []KnownExploitedDiff  `json:"knownExploited"`
+	EPSS             []EPSSDiff            `json:"epss"`
+}
+
+// ProviderSummary counts the changed vulnerability, affected package, and affected CPE records of a single provider
+type ProviderSummary struct {
+	Provider         string `json:"provider"`
+	Vulnerabilities  Counts `json:"vulnerabilities"`
+	AffectedPackages Counts `json:"affectedPackages"`
+	AffectedCPEs     Counts `json:"affectedCPEs"`
+}
+
+type Counts struct {
+	Added    int `json:"added"`
+	Removed  int `json:"removed"`
+	Modified int `json:"modified"`
+}
+
+// VulnerabilityDiff describes a changed vulnerability record (keyed by provider and vulnerability ID)
+type VulnerabilityDiff struct {
+	ID       string     `json:"id"`
+	Provider string     `json:"provider"`
+	Change   ChangeType `json:"change"`
+	// Fields are the names of the modified fields (e.g. "status", "severities")
+	Fields []string `json:"fields,omitempty"`
+}
+
+// AffectedPackageDiff describes changed affected package records (keyed by provider, vulnerability ID, package, and
+// operating system)
+type AffectedPackageDiff struct {
+	Vulnerability string     `json:"vulnerability"`
+	Provider      string     `json:"provider"`
+	Ecosystem     string     `json:"ecosystem,omitempty"`
+	Package       string     `json:"package"`
+	OS            string     `json:"os,omitempty"`
+	Change        ChangeType `json:"change"`
+	// Fields are the names of the modified fields (one or more of "ranges", "fix", "qualifiers", "cves")
+	Fields []string `json:"fields,omitempty"`
+}
+
+// AffectedCPEDiff describes changed affected CPE records (keyed by provider, vulnerability ID, and CPE)
+type AffectedCPEDiff struct {
+	Vulnerability string     `json:"vulnerability"`
+	Provider      string     `json:"provider"`
+	CPE           string     `json:"cpe"`
+	Vendor        string     `json:"vendor,omitempty"`
+	Product       string     `json:"product"`
+	Change        ChangeType `json:"change"`
+	// Fields are the names of the modified fields (one or more of "ranges", "fix", "qualifiers", "cves")
+	Fields []string `json:"fields,omitempty"`
+}
+
+// KnownExploitedDiff describes a changed CISA KEV entry
+type KnownExploitedDiff struct {
+	CVE    string     `json:"cve"`
+	Change ChangeType `json:"change"`
+}
+
+// EPSSDiff describes a changed EPSS score
+type EPSSDiff struct {
+	CVE      string     `json:"cve"`
+	Change   ChangeType `json:"change"`
+	Previous *float64   `json:"previous,omitempty"`
+	Current  *float64   `json:"current,omitempty"`
+}
+
+// IsEmpty indicates that no differences were found
+func (d Diff) IsEmpty() bool {
+	return len(d.Vulnerabilities) == 0 && len(d.AffectedPackages) == 0 && len(d.AffectedCPEs) == 0 &&
+		len(d.KnownExploited) == 0 && len(d.EPSS) == 0
+}
+
+// Compare reports the vulnerability, affected package, affected CPE, and decorator (KEV and EPSS) records that differ
+// between the base and target databases. Progress is published as a DatabaseDiffingStarted event.
+func Compare(base, target v6.Reader) (*Diff, error) {
+	stageProgress, differencesDiscovered, stager := trackDiff(5)
+	defer stageProgress.SetCompleted()
+	defer differencesDiscovered.SetCompleted()
+
+	diff := Diff{}
+
+	var err error
+	if diff.Base, err = describe(base); err != nil {
+		return nil, fmt.Errorf("unable to describe base database: %w", err)
+	}
+	if diff.Target, err = describe(target); err != nil {
+		return nil, fmt.Errorf("unable to describe target database: %w", err)
+	}
+
+	stager.Current = "comparing vulnerabilities"
+	if diff.Vulnerabilities, err = compareVulnerabilities(base, target); err != nil {
+		return nil, err
+	}
+	differencesDiscovered.Add(int64(len(diff.Vulnerabilities)))
+	stageProgress.Increment()
+
+	stager.Current = "comparing affected packages"
+	if diff.AffectedPackages, err = compareAffectedPackages(base, target); err != nil {
+		return nil, err
+	}
+	differencesDiscovered.Add(int64(len(diff.AffectedPackages)))
+	stageProgress.Increment()
+
+	stager.Current = "comparing affected CPEs"
+	if diff.AffectedCPEs, err = compareAffectedCPEs(base, target); err != nil {
+		return nil, err
+	}
+	differencesDiscovered.Add(int64(len(diff.AffectedCPEs)))
+	stageProgress.Increment()
+
+	stager.Current = "comparing known exploited vulnerabilities"
+	if diff.KnownExploited, err = compareKnownExploited(base, target); err != nil {
+		return nil, err
+	}
+	differencesDiscovered.Add(int64(len(diff.KnownExploited)))
+	stageProgress.Increment()
+
+	stager.Current = "comparing EPSS scores"
+	if diff.EPSS, err = compareEPSS(base, target); err != nil {
+		return nil, err
+	}
+	differencesDiscovered.Add(int64(len(diff.EPSS)))
+	stageProgress.Increment()
+
+	diff.Providers = summarizeProviders(diff)
+
+	stager.Current = "complete"
+	return &diff, nil
+}
+
+// create manual progress bars for tracking the database diff's progress
+func trackDiff(total int64) (*progress.Manual, *progress.Manual, *progress.Stage) {
+	stageProgress := &progress.Manual{}
+	stageProgress.SetTotal(total)
+	differencesDiscovered := &progress.Manual{}
+	stager := &progress.Stage{}
+
+	bus.Publish(partybus.Event{
+		Type: event.DatabaseDiffingStarted,
+		Value: monitor.DBDiff{
+			Stager:                stager,
+			StageProgress:         progress.Progressable(stageProgress),
+			DifferencesDiscovered: progress.Monitorable(differencesDiscovered),
+		},
+	})
+	return stageProgress, differencesDiscovered, stager
+}
+
+func describe(reader v6.Reader) (*v6.Description, error) {
+	meta, err := reader.GetDBMetadata()
+	if err != nil {
+		return nil, err
+	}
+	return v6.DescriptionFromMetadata(meta), nil
+}
+
+type vulnerabilityKey struct {
+	provider string
+	id       string
+}
+
+func compareVulnerabilities(base, target v6.Reader) ([]VulnerabilityDiff, error) {
+	baseVulns, err := vulnerabilitiesByKey(base)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read base vulnerabilities: %w", err)
+	}
+	targetVulns, err := vulnerabilitiesByKey(target)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read target vulnerabilities: %w", err)
+	}
+
+	var diffs []VulnerabilityDiff
+	for key, b := range baseVulns {
+		t, ok := targetVulns[key]
+		if !ok {
+			diffs = append(diffs, VulnerabilityDiff{ID: b.Name, Provider: key.provider, Change: Removed})
+			continue
+		}
+		if fields := vulnerabilityChanges(b, t); len(fields) > 0 {
+			diffs = append(diffs, VulnerabilityDiff{ID: t.Name, Provider: key.provider, Change: Modified, Fields: fields})
+		}
+	}
+	for key, t := range targetVulns {
+		if _, ok := baseVulns[key]; !ok {
+			diffs = append(diffs, VulnerabilityDiff{ID: t.Name, Provider: key.provider, Change: Added})
+		}
+	}
+
+	sort.Slice(diffs, func(i, j int) bool {
+		if diffs[i].Provider != diffs[j].Provider {
+			return diffs[i].Provider < diffs[j].Provider
+		}
+		return diffs[i].ID < diffs[j].ID
+	})
+	return diffs, nil
+}
+
+func vulnerabilitiesByKey(reader v6.Reader) (map[vulnerabilityKey]v6.VulnerabilityHandle, error) {
+	vulns, err := reader.GetVulnerabilities(nil, &v6.GetVulnerabilityOptions{Preload: true})
+	if err != nil {
+		return nil, err
+	}
+
+	byKey := make(map[vulnerabilityKey]v6.VulnerabilityHandle, len(vulns))
+	for _, v := range vulns {
+		byKey[vulnerabilityKey{provider: v.ProviderID, id: strings.ToLower(v.Name)}] = v
+	}
+	return byKey, nil
+}
+
+// vulnerabilityChanges returns the names of the fields that differ between two versions of a vulnerability record
+func vulnerabilityChanges(base, target v6.VulnerabilityHandle) []string {
+	var fields []string
+	if base.Status != target.Status {
+		fields = append(fields, "status")
+	}
+	if !timesEqual(base.PublishedDate, target.PublishedDate) {
+		fields = append(fields, "published")
+	}
+	if !timesEqual(base.ModifiedDate, target.ModifiedDate) {
+		fields = append(fields, "modified")
+	}
+	if !timesEqual(base.WithdrawnDate, target.WithdrawnDate) {
+		fields = append(fields, "withdrawn")
+	}
+
+	b, t := base.BlobValue, target.BlobValue
+	if b == nil {
+		b = &v6.VulnerabilityBlob{}
+	}
+	if t == nil {
+		t = &v6.VulnerabilityBlob{}
+	}
+	if b.Description != t.Description {
+		fields = append(fields, "description")
+	}
+	if !jsonEqual(b.Assigners, t.Assigners) {
+		fields = append(fields, "assigners")
+	}
+	if !jsonEqual(b.References, t.References) {
+		fields = append(fields, "references")
+	}
+	if !jsonEqual(b.Aliases, t.Aliases) {
+		fields = append(fields, "aliases")
+	}
+	if !jsonEqual(b.Severities, t.Severities) {
+		fields = append(fields, "severities")
+	}
+	return fields
+}
+
+type affectedKey struct {
+	provider      string
+	vulnerability string
+	subject       string
+	os            string
+}
+
+// affectedRecord is the union of all affected package (or CPE) records that share the same key
+type affectedRecord struct {
+	provider      string
+	vulnerability string
+	ranges        []string
+	fixes         []string
+	qualifiers    []string
+	cves          []string
+}
+
+type affectedPackageRecord struct {
+	affectedRecord
+	ecosystem string
+	name      string
+	os        string
+}
+
+type affectedCPERecord struct {
+	affectedRecord
+	cpe v6.Cpe
+}
+
+func compareAffectedPackages(base, target v6.Reader) ([]AffectedPackageDiff, error) {
+	basePkgs, err := affectedPackagesByKey(base)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read base affected packages: %w", err)
+	}
+	targetPkgs, err := affectedPackagesByKey(target)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read target affected packages: %w", err)
+	}
+
+	diffs := compareAffected(basePkgs, targetPkgs, func(r *affectedPackageRecord, change ChangeType, fields []string) AffectedPackageDiff {
+		return AffectedPackageDiff{
+			Vulnerability: r.vulnerability,
+			Provider:      r.provider,
+			Ecosystem:     r.ecosystem,
+			Package:       r.name,
+			OS:            r.os,
+			Change:        change,
+			Fields:        fields,
+		}
+	})
+
+	sort.Slice(diffs, func(i, j int) bool {
+		a, b := diffs[i], diffs[j]
+		if a.Provider != b.Provider {
+			return a.Provider < b.Provider
+		}
+		if a.Vulnerability != b.Vulnerability {
+			return a.Vulnerability < b.Vulnerability
+		}
+		if a.Ecosystem != b.Ecosystem {
+			return a.Ecosystem < b.Ecosystem
+		}
+		if a.Package != b.Package {
+			return a.Package < b.Package
+		}
+		return a.OS < b.OS
+	})
+	return diffs, nil
+}
+
+func affectedPackagesByKey(reader v6.Reader) (map[affectedKey]*affectedPackageRecord, error) {
+	handles, err := reader.GetAffectedPackages(nil, &v6.GetAffectedPackageOptions{
+		PreloadOS:            true,
+		PreloadPackage:       true,
+		PreloadVulnerability: true,
+		PreloadBlob:          true,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	byKey := make(map[affectedKey]*affectedPackageRecord)
+	for _, h := range handles {
+		provider, vulnerability := vulnerabilityOf(h.Vulnerability)
+
+		var ecosystem, name string
+		if h.Package != nil {
+			ecosystem, name = h.Package.Ecosystem, h.Package.Name
+		}
+
+		var os string
+		if h.OperatingSystem != nil {
+			os = h.OperatingSystem.String()
+		}
+
+		key := affectedKey{
+			provider:      provider,
+			vulnerability: strings.ToLower(vulnerability),
+			subject:       strings.ToLower(ecosystem + "/" + name),
+			os:            strings.ToLower(os),
+		}
+
+		r, ok := byKey[key]
+		if !ok {
+			r = &affectedPackageRecord{
+				affectedRecord: affectedRecord{provider: provider, vulnerability: vulnerability},
+				ecosystem:      ecosystem,
+				name:           name,
+				os:             os,
+			}
+			byKey[key] = r
+		}
+		r.add(h.BlobValue)
+	}
+
+	for _, r := range byKey {
+		r.normalize()
+	}
+	return byKey, nil
+}
+
+func compareAffectedCPEs(base, target v6.Reader) ([]AffectedCPEDiff, error) {
+	baseCPEs, err := affectedCPEsByKey(base)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read base affected CPEs: %w", err)
+	}
+	targetCPEs, err := affectedCPEsByKey(target)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read target affected CPEs: %w", err)
+	}
+
+	diffs := compareAffected(baseCPEs, targetCPEs, func(r *affectedCPERecord, change ChangeType, fields []string) AffectedCPEDiff {
+		return AffectedCPEDiff{
+			Vulnerability: r.vulnerability,
+			Provider:      r.provider,
+			CPE:           r.cpe.String(),
+			Vendor:        r.cpe.Vendor,
+			Product:       r.cpe.Product,
+			Change:        change,
+			Fields:        fields,
+		}
+	})
+
+	sort.Slice(diffs, func(i, j int) bool {
+		a, b := diffs[i], diffs[j]
+		if a.Provider != b.Provider {
+			return a.Provider < b.Provider
+		}
+		if a.Vulnerability != b.Vulnerability {
+			return a.Vulnerability < b.Vulnerability
+		}
+		return a.CPE < b.CPE
+	})
+	return diffs, nil
+}
+
+func affectedCPEsByKey(reader v6.Reader) (map[affectedKey]*affectedCPERecord, error) {
+	handles, err := reader.GetAffectedCPEs(nil, &v6.GetAffectedCPEOptions{
+		PreloadCPE:           true,
+		PreloadVulnerability: true,
+		PreloadBlob:          true,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	byKey := make(map[affectedKey]*affectedCPERecord)
+	for _, h := range handles {
+		provider, vulnerability := vulnerabilityOf(h.Vulnerability)
+
+		var c v6.Cpe
+		if h.CPE != nil {
+			c = *h.CPE
+			c.ID = 0
+		}
+
+		key := affectedKey{
+			provider:      provider,
+			vulnerability: strings.ToLower(vulnerability),
+			subject:       strings.ToLower(c.String()),
+		}
+
+		r, ok := byKey[key]
+		if !ok {
+			r = &affectedCPERecord{
+				affectedRecord: affectedRecord{provider: provider, vulnerability: vulnerability},
+				cpe:            c,
+			}
+			byKey[key] = r
+		}
+		r.add(h.BlobValue)
+	}
+
+	for _, r := range byKey {
+		r.normalize()
+	}
+	return byKey, nil
+}
+
+type comparableRecord interface {
+	changes(other *affectedRecord) []string
+	record() *affectedRecord
+}
+
+func (r *affectedRecord) record() *affectedRecord {
+	return r
+}
+
+// compareAffected reports the added, removed, and modified affected records between the base and target records
+func compareAffected[R comparableRecord, D any](base, target map[affectedKey]R, newDiff func(r R, change ChangeType, fields []string) D) []D {
+	var diffs []D
+	for key, b := range base {
+		t, ok := target[key]
+		if !ok {
+			diffs = append(diffs, newDiff(b, Removed, nil))
+			continue
+		}
+		if fields := b.changes(t.record()); len(fields) > 0 {
+			diffs = append(diffs, newDiff(t, Modified, fields))
+		}
+	}
+	for key, t := range target {
+		if _, ok := base[key]; !ok {
+			diffs = append(diffs, newDiff(t, Added, nil))
+		}
+	}
+	return diffs
+}
+
+func vulnerabilityOf(v *v6.VulnerabilityHandle) (provider, name string) {
+	if v == nil {
+		return "", ""
+	}
+	return v.ProviderID, v.Name
+}
+
+func (r *affectedRecord) add(blob *v6.AffectedPackageBlob) {
+	if blob == nil {
+		return
+	}
+	r.cves = append(r.cves, blob.CVEs...)
+	if blob.Qualifiers != nil {
+		r.qualifiers = append(r.qualifiers, jsonString(blob.Qualifiers))
+	}
+	for _, rng := range blob.Ranges {
+		r.ranges = append(r.ranges, fmt.Sprintf("%s:%s", rng.Version.Type, rng.Version.Constraint))
+		if rng.Fix != nil {
+			r.fixes = append(r.fixes, jsonString(rng.Fix))
+		}
+	}
+}
+
+// normalize sorts and de-duplicates all values so that records can be compared regardless of the order of the
+// underlying rows
+func (r *affectedRecord) normalize() {
+	r.ranges = sortedUnique(r.ranges)
+	r.fixes = sortedUnique(r.fixes)
+	r.qualifiers = sortedUnique(r.qualifiers)
+	r.cves = sortedUnique(r.cves)
+}
+
+// changes returns the names of the fields that differ between two versions of an affected record
+func (r *affectedRecord) changes(other *affectedRecord) []string {
+	var fields []string
+	if !reflect.DeepEqual(r.ranges, other.ranges) {
+		fields = append(fields, "ranges")
+	}
+	if !reflect.DeepEqual(r.fixes, other.fixes) {
+		fields = append(fields, "fix")
+	}
+	if !reflect.DeepEqual(r.qualifiers, other.qualifiers) {
+		fields = append(fields, "qualifiers")
+	}
+	if !reflect.DeepEqual(r.cves, other.cves) {
+		fields = append(fields, "cves")
+	}
+	return fields
+}
+
+func compareKnownExploited(base, target v6.Reader) ([]KnownExploitedDiff, error) {
+	baseKEVs, err := knownExploitedByCVE(base)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read base known exploited vulnerabilities: %w", err)
+	}
+	targetKEVs, err := knownExploitedByCVE(target)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read target known exploited vulnerabilities: %w", err)
+	}
+
+	var diffs []KnownExploitedDiff
+	for cve, b := range baseKEVs {
+		t, ok := targetKEVs[cve]
+		switch {
+		case !ok:
+			diffs = append(diffs, KnownExploitedDiff{CVE: cve, Change: Removed})
+		case !reflect.DeepEqual(b, t):
+			diffs = append(diffs, KnownExploitedDiff{CVE: cve, Change: Modified})
+		}
+	}
+	for cve := range targetKEVs {
+		if _, ok := baseKEVs[cve]; !ok {
+			diffs = append(diffs, KnownExploitedDiff{CVE: cve, Change: Added})
+		}
+	}
+
+	sort.Slice(diffs, func(i, j int) bool {
+		return diffs[i].CVE < diffs[j].CVE
+	})
+	return diffs, nil
+}
+
+// knownExploitedByCVE returns the (normalized) KEV entries for each CVE
+func knownExploitedByCVE(reader v6.Reader) (map[string][]string, error) {
+	kevs, err := reader.AllKnownExploitedVulnerabilities()
+	if err != nil {
+		return nil, err
+	}
+
+	byCVE := make(map[string][]string)
+	for _, k := range kevs {
+		cve := strings.ToUpper(k.Cve)
+		byCVE[cve] = append(byCVE[cve], jsonString(k.BlobValue))
+	}
+	for cve, entries := range byCVE {
+		byCVE[cve] = sortedUnique(entries)
+	}
+	return byCVE, nil
+}
+
+func compareEPSS(base, target v6.Reader) ([]EPSSDiff, error) {
+	baseScores, err := epssByCVE(base)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read base EPSS scores: %w", err)
+	}
+	targetScores, err := epssByCVE(target)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read target EPSS scores: %w", err)
+	}
+
+	var diffs []EPSSDiff
+	for cve, b := range baseScores {
+		t, ok := targetScores[cve]
+		switch {
+		case !ok:
+			diffs = append(diffs, EPSSDiff{CVE: cve, Change: Removed, Previous: &b})
+		case b != t:
+			diffs = append(diffs, EPSSDiff{CVE: cve, Change: Modified, Previous: &b, Current: &t})
+		}
+	}
+	for cve, t := range targetScores {
+		if _, ok := baseScores[cve]; !ok {
+			diffs = append(diffs, EPSSDiff{CVE: cve, Change: Added, Current: &t})
+		}
+	}
+
+	sort.Slice(diffs, func(i, j int) bool {
+		return diffs[i].CVE < diffs[j].CVE
+	})
+	return diffs, nil
+}
+
+func epssByCVE(reader v6.Reader) (map[string]float64, error) {
+	scores, err := reader.AllEpss()
+	if err != nil {
+		return nil, err
+	}
+
+	byCVE := make(map[string]float64, len(scores))
+	for _, s := range scores {
+		byCVE[strings.ToUpper(s.Cve)] = s.Epss
+	}
+	return byCVE, nil
+}
+
+func summarizeProviders(diff Diff) []ProviderSummary {
+	byProvider := make(map[string]*ProviderSummary)
+	summary := func(provider string) *ProviderSummary {
+		s, ok := byProvider[provider]
+		if !ok {
+			s = &ProviderSummary{Provider: provider}
+			byProvider[provider] = s
+		}
+		return s
+	}
+
+	for _, v := range diff.Vulnerabilities {
+		summary(v.Provider).Vulnerabilities.count(v.Change)
+	}
+	for _, p := range diff.AffectedPackages {
+		summary(p.Provider).AffectedPackages.count(p.Change)
+	}
+	for _, c := range diff.AffectedCPEs {
+		summary(c.Provider).AffectedCPEs.count(c.Change)
+	}
+
+	var summaries []ProviderSummary
+	for _, s := range byProvider {
+		summaries = append(summaries, *s)
+	}
+	sort.Slice(summaries, func(i, j int) bool {
+		return summaries[i].Provider < summaries[j].Provider
+	})
+	return summaries
+}
+
+func (c *Counts) count(change ChangeType) {
+	switch change {
+	case Added:
+		c.Added++
+	case Removed:
+		c.Removed++
+	case Modified:
+		c.Modified++
+	}
+}
+
+func timesEqual(a, b *time.Time) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return a.Equal(*b)
+}
+
+func jsonEqual(a, b any) bool {
+	return jsonString(a) == jsonString(b)
+}
+
+func jsonString(v any) string {
+	by, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprintf("%+v", v)
+	}
+	return string(by)
+}
+
+func sortedUnique(values []string) []string {
+	if len(values) == 0 {
+		return nil
+	}
+	sort.Strings(values)
+	unique := values[:1]
+	for _, v := range values[1:] {
+		if v != unique[len(unique)-1] {
+			unique = append(unique, v)
+		}
+	}
+	return unique
+}
@@ -0,0 +1,213 @@
+package differ
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	v6 "github.com/anchore/grype/grype/db/v6"
+)
+
+type testDB struct {
+	vulns    []*v6.VulnerabilityHandle
+	packages []*v6.AffectedPackageHandle
+	cpes     []*v6.AffectedCPEHandle
+	kevs     []*v6.KnownExploitedVulnerabilityHandle
+	epss     []*v6.EpssHandle
+}
+
+func newTestReader(t *testing.T, contents testDB) v6.Reader {
+	t.Helper()
+
+	dir := t.TempDir()
+	w, err := v6.NewWriter(v6.Config{DBDirPath: dir})
+	require.NoError(t, err)
+	require.NoError(t, w.AddVulnerabilities(contents.vulns...))
+	require.NoError(t, w.AddAffectedPackages(contents.packages...))
+	require.NoError(t, w.AddAffectedCPEs(contents.cpes...))
+	require.NoError(t, w.AddKnownExploitedVulnerabilities(contents.kevs...))
+	require.NoError(t, w.AddEpss(contents.epss...))
+	require.NoError(t, w.Close())
+
+	r, err := v6.NewReader(v6.Config{DBDirPath: dir})
+	require.NoError(t, err)
+	t.Cleanup(func() { require.NoError(t, r.Close()) })
+	return r
+}
+
+func vuln(provider, id, description string, severities ...v6.Severity) *v6.VulnerabilityHandle {
+	return &v6.VulnerabilityHandle{
+		Name:     id,
+		Status:   v6.VulnerabilityActive,
+		Provider: &v6.Provider{ID: provider, Version: "1"},
+		BlobValue: &v6.VulnerabilityBlob{
+			ID:          id,
+			Description: description,
+			Severities:  severities,
+		},
+	}
+}
+
+func affected(v *v6.VulnerabilityHandle, pkg string, os *v6.OperatingSystem, constraint, fix string) *v6.AffectedPackageHandle {
+	rng := v6.AffectedRange{Version: v6.AffectedVersion{Type: "semver", Constraint: constraint}}
+	if fix != "" {
+		rng.Fix = &v6.Fix{Version: fix, State: v6.FixedStatus}
+	}
+	return &v6.AffectedPackageHandle{
+		Vulnerability:   v,
+		OperatingSystem: os,
+		Package:         &v6.Package{Ecosystem: "npm", Name: pkg},
+		BlobValue:       &v6.AffectedPackageBlob{CVEs: []string{v.Name}, Ranges: []v6.AffectedRange{rng}},
+	}
+}
+
+func affectedCPE(v *v6.VulnerabilityHandle, product, constraint string) *v6.AffectedCPEHandle {
+	return &v6.AffectedCPEHandle{
+		Vulnerability: v,
+		CPE:           &v6.Cpe{Part: "a", Vendor: "vendor", Product: product},
+		BlobValue: &v6.AffectedPackageBlob{
+			CVEs:   []string{v.Name},
+			Ranges: []v6.AffectedRange{{Version: v6.AffectedVersion{Type: "semver", Constraint: constraint}}},
+		},
+	}
+}
+
+func TestCompare(t *testing.T) {
+	date := time.Date(2025, 1, 2, 0, 0, 0, 0, time.UTC)
+	alpine := func() *v6.OperatingSystem {
+		return &v6.OperatingSystem{Name: "alpine", MajorVersion: "3", MinorVersion: "20"}
+	}
+	high := v6.Severity{Scheme: v6.SeveritySchemeHML, Value: "high"}
+	low := v6.Severity{Scheme: v6.SeveritySchemeHML, Value: "low"}
+
+	baseUnchanged := vuln("github", "GHSA-unchanged", "same")
+	baseModified := vuln("github", "GHSA-modified", "before", high)
+	baseRemoved := vuln("github", "GHSA-removed", "gone")
+	baseOS := vuln("alpine", "CVE-2024-0001", "os vuln")
+	baseNVD := vuln("nvd", "CVE-2024-0002", "cpe vuln")
+
+	base := newTestReader(t, testDB{
+		vulns: []*v6.VulnerabilityHandle{baseUnchanged, baseModified, baseRemoved, baseOS, baseNVD},
+		packages: []*v6.AffectedPackageHandle{
+			affected(baseUnchanged, "left-pad", nil, "<1.0.0", "1.0.0"),
+			affected(baseModified, "lodash", nil, "<4.17.0", "4.17.0"),
+			affected(baseRemoved, "moment", nil, "<2.0.0", ""),
+			affected(baseOS, "musl", alpine(), "<1.2.5", ""),
+		},
+		cpes: []*v6.AffectedCPEHandle{
+			affectedCPE(baseNVD, "widget", "<1.0"),
+			affectedCPE(baseNVD, "gadget", "<2.0"),
+		},
+		kevs: []*v6.KnownExploitedVulnerabilityHandle{
+			{Cve: "CVE-2024-0001", BlobValue: &v6.KnownExploitedVulnerabilityBlob{Cve: "CVE-2024-0001", Product: "musl"}},
+			{Cve: "CVE-2024-0002", BlobValue: &v6.KnownExploitedVulnerabilityBlob{Cve: "CVE-2024-0002"}},
+		},
+		epss: []*v6.EpssHandle{
+			{Cve: "CVE-2024-0001", Epss: 0.1, Percentile: 0.5, Date: date},
+			{Cve: "CVE-2024-0002", Epss: 0.2, Percentile: 0.6, Date: date},
+		},
+	})
+
+	targetUnchanged := vuln("github", "GHSA-unchanged", "same")
+	targetModified := vuln("github", "GHSA-modified", "after", low)
+	targetAdded := vuln("github", "GHSA-added", "new")
+	targetOS := vuln("alpine", "CVE-2024-0001", "os vuln")
+	targetNVD := vuln("nvd", "CVE-2024-0002", "cpe vuln")
+
+	target := newTestReader(t, testDB{
+		vulns: []*v6.VulnerabilityHandle{targetUnchanged, targetModified, targetAdded, targetOS, targetNVD},
+		packages: []*v6.AffectedPackageHandle{
+			affected(targetUnchanged, "left-pad", nil, "<1.0.0", "1.0.0"),
+			affected(targetModified, "lodash", nil, "<4.17.0", "4.17.1"),
+			affected(targetAdded, "moment", nil, "<2.0.0", ""),
+			affected(targetOS, "musl", alpine(), "<1.2.6", ""),
+		},
+		cpes: []*v6.AffectedCPEHandle{
+			affectedCPE(targetNVD, "widget", "<1.1"),
+			affectedCPE(targetNVD, "gizmo", "<3.0"),
+		},
+		kevs: []*v6.KnownExploitedVulnerabilityHandle{
+			{Cve: "CVE-2024-0001", BlobValue: &v6.KnownExploitedVulnerabilityBlob{Cve: "CVE-2024-0001", Product: "musl libc"}},
+			{Cve: "CVE-2024-0003", BlobValue: &v6.KnownExploitedVulnerabilityBlob{Cve: "CVE-2024-0003"}},
+		},
+		epss: []*v6.EpssHandle{
+			{Cve: "CVE-2024-0001", Epss: 0.1, Percentile: 0.5, Date: date},
+			{Cve: "CVE-2024-0002", Epss: 0.3, Percentile: 0.7, Date: date},
+		},
+	})
+
+	got, err := Compare(base, target)
+	require.NoError(t, err)
+
+	assert.Equal(t, []VulnerabilityDiff{
+		{ID: "GHSA-added", Provider: "github", Change: Added},
+		{ID: "GHSA-modified", Provider: "github", Change: Modified, Fields: []string{"description", "severities"}},
+		{ID: "GHSA-removed", Provider: "github", Change: Removed},
+	}, got.Vulnerabilities)
+
+	assert.Equal(t, []AffectedPackageDiff{
+		{Vulnerability: "CVE-2024-0001", Provider: "alpine", Ecosystem: "npm", Package: "musl", OS: "alpine@3.20", Change: Modified, Fields: []string{"ranges"}},
+		{Vulnerability: "GHSA-added", Provider: "github", Ecosystem: "npm", Package: "moment", Change: Added},
+		{Vulnerability: "GHSA-modified", Provider: "github", Ecosystem: "npm", Package: "lodash", Change: Modified, Fields: []string{"fix"}},
+		{Vulnerability: "GHSA-removed", Provider: "github", Ecosystem: "npm", Package: "moment", Change: Removed},
+	}, got.AffectedPackages)
+
+	assert.Equal(t, []AffectedCPEDiff{
+		{Vulnerability: "CVE-2024-0002", Provider: "nvd", CPE: "cpe:2.3:a:vendor:gadget:*:*:*:*:*:*:*:*", Vendor: "vendor", Product: "gadget", Change: Removed},
+		{Vulnerability: "CVE-2024-0002", Provider: "nvd", CPE: "cpe:2.3:a:vendor:gizmo:*:*:*:*:*:*:*:*", Vendor: "vendor", Product: "gizmo", Change: Added},
+		{Vulnerability: "CVE-2024-0002", Provider: "nvd", CPE: "cpe:2.3:a:vendor:widget:*:*:*:*:*:*:*:*", Vendor: "vendor", Product: "widget", Change: Modified, Fields: []string{"ranges"}},
+	}, got.AffectedCPEs)
+
+	assert.Equal(t, []ProviderSummary{
+		{Provider: "alpine", AffectedPackages: Counts{Modified: 1}},
+		{Provider: "github", Vulnerabilities: Counts{Added: 1, Removed: 1, Modified: 1}, AffectedPackages: Counts{Added: 1, Removed: 1, Modified: 1}},
+		{Provider: "nvd", AffectedCPEs: Counts{Added: 1, Removed: 1, Modified: 1}},
+	}, got.Providers)
+
+	assert.Equal(t, []KnownExploitedDiff{
+		{CVE: "CVE-2024-0001", Change: Modified},
+		{CVE: "CVE-2024-0002", Change: Removed},
+		{CVE: "CVE-2024-0003", Change: Added},
+	}, got.KnownExploited)
+
+	previous, current := 0.2, 0.3
+	assert.Equal(t, []EPSSDiff{
+		{CVE: "CVE-2024-0002", Change: Modified, Previous: &previous, Current: &current},
+	}, got.EPSS)
+
+	t.Run("identical databases", func(t *testing.T) {
+		same, err := Compare(base, base)
+		require.NoError(t, err)
+		assert.True(t, same.IsEmpty())
+		assert.Empty(t, same.Providers)
+	})
+
+	t.Run("present", func(t *testing.T) {
+		table := &bytes.Buffer{}
+		require.NoError(t, Present("table", got, table))
+		assert.Contains(t, table.String(), "GHSA-modified")
+		assert.Contains(t, table.String(), "description, severities")
+		assert.Contains(t, table.String(), "npm/musl (alpine@3.20)")
+		assert.Contains(t, table.String(), "0.2 -> 0.3")
+		assert.Contains(t, table.String(), "1/1/1")
+
+		doc := &bytes.Buffer{}
+		require.NoError(t, Present("json", got, doc))
+		var decoded Diff
+		require.NoError(t, json.Unmarshal(doc.Bytes(), &decoded))
+		assert.Equal(t, got.Vulnerabilities, decoded.Vulnerabilities)
+		assert.Equal(t, got.EPSS, decoded.EPSS)
+
+		require.ErrorContains(t, Present("xml", got, &bytes.Buffer{}), "unsupported output format")
+	})
+}
+
+func TestPresent_noDifferences(t *testing.T) {
+	buf := &bytes.Buffer{}
+	require.NoError(t, Present("table", &Diff{}, buf))
+	assert.Equal(t, "No differences found\n", buf.String())
+}
@@ -0,0 +1,138 @@
+package differ
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/olekukonko/tablewriter"
+	"github.com/olekukonko/tablewriter/renderer"
+	"github.com/olekukonko/tablewriter/tw"
+)
+
+// Present writes the diff in the given format (one of "table" or "json")
+func Present(outputFormat string, diff *Diff, output io.Writer) error {
+	if diff == nil {
+		return nil
+	}
+
+	switch outputFormat {
+	case "table":
+		return presentTable(diff, output)
+	case "json":
+		enc := json.NewEncoder(output)
+		enc.SetEscapeHTML(false)
+		enc.SetIndent("", " ")
+		if err := enc.Encode(diff); err != nil {
+			return fmt.Errorf("failed to encode diff information: %w", err)
+		}
+	default:
+		return fmt.Errorf("unsupported output format: %s", outputFormat)
+	}
+	return nil
+}
+
+func presentTable(diff *Diff, output io.Writer) error {
+	if diff.IsEmpty() {
+		_, err := io.WriteString(output, "No differences found\n")
+		return err
+	}
+
+	var rows [][]string
+	for _, v := range diff.Vulnerabilities {
+		rows = append(rows, []string{v.ID, v.Provider, "", string(v.Change), strings.Join(v.Fields, ", ")})
+	}
+	for _, p := range diff.AffectedPackages {
+		pkg := p.Package
+		if p.Ecosystem != "" {
+			pkg = fmt.Sprintf("%s/%s", p.Ecosystem, pkg)
+		}
+		if p.OS != "" {
+			pkg = fmt.Sprintf("%s (%s)", pkg, p.OS)
+		}
+		rows = append(rows, []string{p.Vulnerability, p.Provider, pkg, string(p.Change), strings.Join(p.Fields, ", ")})
+	}
+	for _, c := range diff.AffectedCPEs {
+		rows = append(rows, []string{c.Vulnerability, c.Provider, c.CPE, string(c.Change), strings.Join(c.Fields, ", ")})
+	}
+	for _, k := range diff.KnownExploited {
+		rows = append(rows, []string{k.CVE, "kev", "", string(k.Change), ""})
+	}
+	for _, e := range diff.EPSS {
+		rows = append(rows, []string{e.CVE, "epss", "", string(e.Change), fmt.Sprintf("%s -> %s", formatScore(e.Previous), formatScore(e.Current))})
+	}
+
+	table := newTable(output, []string{"ID", "Provider", "Package", "Change", "Detail"})
+	if err := table.Bulk(rows); err != nil {
+		return fmt.Errorf("failed to add table rows: %w", err)
+	}
+	if err := table.Render(); err != nil {
+		return err
+	}
+
+	if len(diff.Providers) == 0 {
+		return nil
+	}
+
+	rows = nil
+	for _, p := range diff.Providers {
+		rows = append(rows, []string{p.Provider, p.Vulnerabilities.String(), p.AffectedPackages.String(), p.AffectedCPEs.String()})
+	}
+
+	if _, err := io.WriteString(output, "\n"); err != nil {
+		return err
+	}
+
+	table = newTable(output, []string{"Provider", "Vulnerabilities (+/-/~)", "Affected Packages (+/-/~)", "Affected CPEs (+/-/~)"})
+	if err := table.Bulk(rows); err != nil {
+		return fmt.Errorf("failed to add table rows: %w", err)
+	}
+	return table.Render()
+}
+
+func (c Counts) String() string {
+	return fmt.Sprintf("%d/%d/%d", c.Added, c.Removed, c.Modified)
+}
+
+func formatScore(score *float64) string {
+	if score == nil {
+		return "-"
+	}
+	return strconv.FormatFloat(*score, 'f', -1, 64)
+}
+
+func newTable(output io.Writer, columns []string) *tablewriter.Table {
+	return tablewriter.NewTable(output,
+		tablewriter.WithHeader(columns),
+		tablewriter.WithHeaderAutoWrap(tw.WrapNone),
+		tablewriter.WithRowAutoWrap(tw.WrapNone),
+		tablewriter.WithAutoHide(tw.On),
+		tablewriter.WithRenderer(renderer.NewBlueprint()),
+		tablewriter.WithBehavior(
+			tw.Behavior{
+				TrimSpace: tw.On,
+				AutoHide:  tw.On,
+			},
+		),
+		tablewriter.WithPadding(
+			tw.Padding{
+				Right: "  ",
+			},
+		),
+		tablewriter.WithRendition(
+			tw.Rendition{
+				Symbols: tw.NewSymbols(tw.StyleNone),
+				Settings: tw.Settings{
+					Lines: tw.Lines{
+						ShowTop:        tw.Off,
+						ShowBottom:     tw.Off,
+						ShowHeaderLine: tw.Off,
+						ShowFooterLine: tw.Off,
+					},
+				},
+			},
+		),
+	)
+}
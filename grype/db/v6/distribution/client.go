@@ -1,13 +1,16 @@
 package distribution
 
 import (
+	"bytes"
 	"crypto/tls"
 	"crypto/x509"
+	"errors"
 	"fmt"
 	"net/http"
 	"net/url"
 	"os"
 	"path"
+	"path/filepath"
 	"strings"
 	"time"
 
@@ -31,6 +34,7 @@ type Config struct {
 
 	// validations
 	RequireUpdateCheck bool
+	Signature          SignatureConfig
 
 	// timeouts
 	CheckTimeout  time.Duration
@@ -42,12 +46,15 @@ type Client interface {
 	IsUpdateAvailable(current *v6.Description) (*Archive, error)
 	ResolveArchiveURL(archive Archive) (string, error)
 	Download(url, dest string, downloadProgress *progress.Manual) (string, error)
+	DownloadSigned(url, dest string, downloadProgress *progress.Manual) (string, error)
+	Mirror(dir string, downloadProgress *progress.Manual) (*LatestDocument, error)
 }
 
 type client struct {
 	fs                afero.Fs
 	dbDownloader      file.Getter
 	listingDownloader file.Getter
+	verifier          *SignatureVerifier
 	config            Config
 }
 
@@ -72,10 +79,16 @@ func NewClient(cfg Config) (Client, error) {
 		return client{}, err
 	}
 
+	verifier, err := NewSignatureVerifier(cfg.Signature)
+	if err != nil {
+		return client{}, err
+	}
+
 	return client{
 		fs:                fs,
 		listingDownloader: file.NewGetter(cfg.ID, latestClient),
 		dbDownloader:      file.NewGetter(cfg.ID, dbClient),
+		verifier:          verifier,
 		config:            cfg,
 	}, nil
 }
@@ -87,8 +100,9 @@ func (c client) IsUpdateAvailable(current *v6.Description) (*Archive, error) {
 
 	latestDoc, err := c.Latest()
 	if err != nil {
-		if c.config.RequireUpdateCheck {
-			return nil, fmt.Errorf("check for vulnerability database update failed: %+v", err)
+		// a listing that cannot be trusted should never be silently ignored
+		if c.config.RequireUpdateCheck || errors.Is(err, ErrInvalidSignature) || errors.Is(err, ErrSignatureRequired) {
+			return nil, fmt.Errorf("check for vulnerability database update failed: %w", err)
 		}
 		log.Warnf("unable to check for vulnerability database update")
 		log.Debugf("check for vulnerability update failed: %+v", err)
@@ -148,6 +162,22 @@ func (c client) ResolveArchiveURL(archive Archive) (string, error) {
 }
 
 func (c client) Download(archiveURL, dest string, downloadProgress *progress.Manual) (string, error) {
+	return c.download(archiveURL, dest, c.verifier, downloadProgress)
+}
+
+// DownloadSigned downloads the archive like Download, but always requires a valid signature for it (even when
+// signatures are not otherwise required), which is the case for DB deltas as they are applied to the existing DB.
+func (c client) DownloadSigned(archiveURL, dest string, downloadProgress *progress.Manual) (string, error) {
+	if c.verifier == nil {
+		downloadProgress.SetCompleted()
+		return "", fmt.Errorf("unable to download %s: no signature key is configured: %w", archiveURL, ErrSignatureRequired)
+	}
+	verifier := *c.verifier
+	verifier.required = true
+	return c.download(archiveURL, dest, &verifier, downloadProgress)
+}
+
+func (c client) download(archiveURL, dest string, verifier *SignatureVerifier, downloadProgress *progress.Manual) (string, error) {
 	defer downloadProgress.SetCompleted()
 
 	if err := os.MkdirAll(dest, 0700); err != nil {
@@ -160,8 +190,12 @@ func (c client) Download(archiveURL, dest string, downloadProgress *progress.Man
 		return "", fmt.Errorf("unable to create db client temp dir: %w", err)
 	}
 
-	// go-getter will automatically extract all files within the archive to the temp dir
-	err = c.dbDownloader.GetToDir(tempDir, archiveURL, downloadProgress)
+	if verifier != nil {
+		err = c.downloadVerified(archiveURL, tempDir, verifier, downloadProgress)
+	} else {
+		// go-getter will automatically extract all files within the archive to the temp dir
+		err = c.dbDownloader.GetToDir(tempDir, archiveURL, downloadProgress)
+	}
 	if err != nil {
 		removeAllOrLog(afero.NewOsFs(), tempDir)
 		return "", fmt.Errorf("unable to download db: %w", err)
@@ -170,8 +204,105 @@ func (c client) Download(archiveURL, dest string, downloadProgress *progress.Man
 	return tempDir, nil
 }
 
+// downloadVerified downloads the archive as-is to verify its signature before extracting it into the given directory.
+func (c client) downloadVerified(archiveURL, dir string, verifier *SignatureVerifier, downloadProgress *progress.Manual) error {
+	archiveDir, err := os.MkdirTemp(filepath.Dir(dir), "grype-db-archive")
+	if err != nil {
+		return fmt.Errorf("unable to create db archive temp dir: %w", err)
+	}
+	defer removeAllOrLog(afero.NewOsFs(), archiveDir)
+
+	rawURL, err := withoutExtraction(archiveURL)
+	if err != nil {
+		return err
+	}
+
+	// keep the name of the archive so that the extension can be used to determine how to extract it
+	u, err := url.Parse(archiveURL)
+	if err != nil {
+		return fmt.Errorf("unable to parse db URL %q: %w", archiveURL, err)
+	}
+	archivePath := filepath.Join(archiveDir, path.Base(u.Path))
+
+	if err := c.dbDownloader.GetFile(archivePath, rawURL, downloadProgress); err != nil {
+		return err
+	}
+
+	signature, err := c.getSignature(archiveURL)
+	if err != nil {
+		return err
+	}
+
+	if err := verifier.VerifyFile(archivePath, signature); err != nil {
+		return err
+	}
+
+	return c.dbDownloader.GetToDir(dir, archivePath)
+}
+
+// withoutExtraction returns the given URL with the go-getter option to keep archives as-is instead of extracting them
+// (the checksum is still verified).
+func withoutExtraction(archiveURL string) (string, error) {
+	u, err := url.Parse(archiveURL)
+	if err != nil {
+		return "", fmt.Errorf("unable to parse db URL %q: %w", archiveURL, err)
+	}
+	query := u.Query()
+	query.Set("archive", "false")
+	u.RawQuery = query.Encode()
+	return u.String(), nil
+}
+
 // Latest loads a LatestDocument from the configured URL.
 func (c client) Latest() (*LatestDocument, error) {
+	latest, _, _, err := c.latest()
+	return latest, err
+}
+
+// latest loads a LatestDocument from the configured URL, verifying the signature of the listing when configured. The
+// raw listing contents and signature (if it was fetched) are returned as well.
+func (c client) latest() (*LatestDocument, []byte, []byte, error) {
+	contents, err := c.getFile(c.latestURL())
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("unable to download listing: %w", err)
+	}
+
+	var signature []byte
+	if c.verifier != nil {
+		signature, err = c.getSignature(c.latestURL())
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		if err := c.verifier.Verify(LatestFileName, bytes.NewReader(contents), signature); err != nil {
+			return nil, nil, nil, err
+		}
+	}
+
+	latest, err := NewLatestFromReader(bytes.NewReader(contents))
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	return latest, contents, signature, nil
+}
+
+// getSignature fetches the detached signature for the file at the given URL, returning nil if there is none.
+func (c client) getSignature(fileURL string) ([]byte, error) {
+	sigURL, err := signatureURL(fileURL)
+	if err != nil {
+		return nil, err
+	}
+
+	signature, err := c.getFile(sigURL)
+	if err != nil {
+		// signatures are optional unless required, which is enforced by the verifier
+		log.WithFields("url", sigURL, "error", err).Debug("unable to fetch signature")
+		return nil, nil
+	}
+	return signature, nil
+}
+
+// getFile downloads the (small) file at the given URL with the listing downloader and returns its contents.
+func (c client) getFile(fileURL string) ([]byte, error) {
 	tempFile, err := afero.TempFile(c.fs, "", "grype-db-listing")
 	if err != nil {
 		return nil, fmt.Errorf("unable to create listing temp file: %w", err)
@@ -184,16 +315,15 @@ func (c client) Latest() (*LatestDocument, error) {
 		}
 	}()
 
-	err = c.listingDownloader.GetFile(tempFile.Name(), c.latestURL())
-	if err != nil {
-		return nil, fmt.Errorf("unable to download listing: %w", err)
+	if err := c.listingDownloader.GetFile(tempFile.Name(), fileURL); err != nil {
+		return nil, err
 	}
 
-	return NewLatestFromFile(c.fs, tempFile.Name())
+	return afero.ReadFile(c.fs, tempFile.Name())
 }
 
 func (c client) latestURL() string {
-	u := c.config.LatestURL
+	u := absFileURL(c.config.LatestURL)
 	// allow path to be specified directly to a json file, or the path without version information
 	if !strings.HasSuffix(u, ".json") {
 		u = strings.TrimRight(u, "/")
@@ -202,6 +332,27 @@ func (c client) latestURL() string {
 	return u
 }
 
+// absFileURL resolves a file:// URL with a relative path (e.g. "file://mirror") against the working directory, since
+// the path would otherwise be interpreted as a host. Any other URL is returned as-is.
+func absFileURL(u string) string {
+	const scheme = "file://"
+	if !strings.HasPrefix(u, scheme) {
+		return u
+	}
+
+	p := strings.TrimPrefix(u, scheme)
+	if filepath.IsAbs(p) || strings.HasPrefix(p, "/") {
+		return u
+	}
+
+	abs, err := filepath.Abs(p)
+	if err != nil {
+		log.WithFields("url", u, "error", err).Debug("unable to resolve relative file URL")
+		return u
+	}
+	return scheme + filepath.ToSlash(abs)
+}
+
 func withClientTimeout(timeout time.Duration) func(*http.Client) {
 	return func(c *http.Client) {
 		c.Timeout = timeout
@@ -0,0 +1,50 @@
+package distribution
+
+import (
+	db "github.com/anchore/grype/grype/db/v6"
+)
+
+// Delta is an archive of the changes between two consecutive database builds, which can be applied to a local copy of
+// the older build instead of downloading the full archive of the newer build.
+type Delta struct {
+	// From is the build timestamp of the database the delta applies to
+	From db.Time `json:"from"`
+
+	// To is the build timestamp of the database that results from applying the delta
+	To db.Time `json:"to"`
+
+	// Path is the path to a delta archive (containing a db.DeltaFileName file) relative to the listing file hosted location.
+	Path string `json:"path"`
+
+	// Checksum is the self describing digest of the delta archive referenced in path
+	Checksum string `json:"checksum"`
+}
+
+// DeltaChain returns the sequence of deltas that transforms the database built at the given time into this archive's
+// database, or nil if the advertised deltas do not connect the two builds.
+func (a Archive) DeltaChain(from db.Time) []Delta {
+	byFrom := make(map[int64]Delta)
+	for _, d := range a.Deltas {
+		byFrom[d.From.Unix()] = d
+	}
+
+	var chain []Delta
+	current := from.Unix()
+	// each delta must move forward in time, so the chain can never be longer than the number of deltas
+	for len(chain) < len(a.Deltas) {
+		if current == a.Built.Unix() {
+			break
+		}
+		d, ok := byFrom[current]
+		if !ok || d.To.Unix() <= current {
+			return nil
+		}
+		chain = append(chain, d)
+		current = d.To.Unix()
+	}
+
+	if current != a.Built.Unix() || len(chain) == 0 {
+		return nil
+	}
+	return chain
+}
@@ -0,0 +1,77 @@
+package distribution
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	db "github.com/anchore/grype/grype/db/v6"
+)
+
+func TestArchive_DeltaChain(t *testing.T) {
+	day := func(d int) db.Time {
+		return db.Time{Time: time.Date(2025, 1, d, 0, 0, 0, 0, time.UTC)}
+	}
+	delta := func(from, to int) Delta {
+		return Delta{From: day(from), To: day(to), Path: "delta.tar.zst"}
+	}
+
+	tests := []struct {
+		name   string
+		deltas []Delta
+		from   db.Time
+		want   []Delta
+	}{
+		{
+			name: "no deltas",
+			from: day(3),
+		},
+		{
+			name:   "single delta",
+			deltas: []Delta{delta(3, 4), delta(2, 3)},
+			from:   day(3),
+			want:   []Delta{delta(3, 4)},
+		},
+		{
+			name:   "chain of deltas",
+			deltas: []Delta{delta(3, 4), delta(1, 2), delta(2, 3)},
+			from:   day(1),
+			want:   []Delta{delta(1, 2), delta(2, 3), delta(3, 4)},
+		},
+		{
+			name:   "too old for the available deltas",
+			deltas: []Delta{delta(3, 4), delta(2, 3)},
+			from:   day(1),
+		},
+		{
+			name:   "gap in the chain",
+			deltas: []Delta{delta(3, 4), delta(1, 2)},
+			from:   day(1),
+		},
+		{
+			name:   "chain does not lead to the archive",
+			deltas: []Delta{delta(2, 3)},
+			from:   day(2),
+		},
+		{
+			name:   "already up to date",
+			deltas: []Delta{delta(3, 4)},
+			from:   day(4),
+		},
+		{
+			name:   "cycles are ignored",
+			deltas: []Delta{delta(2, 1), delta(1, 2)},
+			from:   day(1),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			archive := Archive{
+				Description: db.Description{Built: day(4)},
+				Deltas:      tt.deltas,
+			}
+			assert.Equal(t, tt.want, archive.DeltaChain(tt.from))
+		})
+	}
+}
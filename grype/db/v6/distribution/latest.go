@@ -36,6 +36,10 @@ type Archive struct {
 
 	// Checksum is the self describing digest of the database archive referenced in path
 	Checksum string `json:"checksum"`
+
+	// Deltas are the available delta archives between consecutive database builds leading up to this archive, which
+	// allow clients with a recent database to update without downloading the full archive
+	Deltas []Delta `json:"deltas,omitempty"`
 }
 
 func NewLatestDocument(entries ...Archive) *LatestDocument {
@@ -67,7 +71,7 @@ func NewLatestFromReader(reader io.Reader) (*LatestDocument, error) {
 		return nil, fmt.Errorf("unable to parse DB latest.json: %w", err)
 	}
 
-	if l == (LatestDocument{}) {
+	if l.Status == "" && l.Path == "" && l.Checksum == "" && l.Description == (db.Description{}) && len(l.Deltas) == 0 {
 		return nil, nil
 	}
 
@@ -0,0 +1,207 @@
+package distribution
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+
+	"github.com/wagoodman/go-progress"
+
+	v6 "github.com/anchore/grype/grype/db/v6"
+	"github.com/anchore/grype/internal/log"
+)
+
+// Mirror downloads the latest DB archive (as-is, without extracting it) into the given directory along with a listing
+// document that references the archive by a path relative to the listing. The directory has the same layout as the
+// distribution endpoint, so it can be used as the update URL for other clients, either directly with a file:// URL or
+// served by any static file server. Any detached signatures are mirrored (and verified when configured) as well.
+func (c client) Mirror(dir string, downloadProgress *progress.Manual) (*LatestDocument, error) { //nolint:funlen
+	defer downloadProgress.SetCompleted()
+
+	latest, contents, signature, err := c.latest()
+	if err != nil {
+		return nil, err
+	}
+	if latest == nil {
+		return nil, fmt.Errorf("no database listing found")
+	}
+	if c.verifier == nil {
+		// mirror the signature even when not verifying it, so that clients of the mirror are able to verify it themselves
+		signature, err = c.getSignature(c.latestURL())
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	versionDir := filepath.Join(dir, fmt.Sprintf("v%d", v6.ModelVersion))
+	if err := os.MkdirAll(versionDir, 0755); err != nil {
+		return nil, fmt.Errorf("unable to create mirror dir: %w", err)
+	}
+
+	mirrored := *latest
+	mirrored.Path, err = mirroredPath(latest.Path)
+	if err != nil {
+		return nil, err
+	}
+	rewritten := mirrored.Path != latest.Path
+
+	if err := c.mirrorArchive(latest.Archive, filepath.Join(versionDir, mirrored.Path), downloadProgress); err != nil {
+		return nil, err
+	}
+
+	// deltas are mirrored as well, so that clients of the mirror can keep updating incrementally
+	mirrored.Deltas = make([]Delta, len(latest.Deltas))
+	for i, delta := range latest.Deltas {
+		mirrored.Deltas[i] = delta
+		mirrored.Deltas[i].Path, err = mirroredPath(delta.Path)
+		if err != nil {
+			return nil, err
+		}
+		rewritten = rewritten || mirrored.Deltas[i].Path != delta.Path
+
+		deltaArchive := Archive{Path: delta.Path, Checksum: delta.Checksum}
+		if err := c.mirrorArchive(deltaArchive, filepath.Join(versionDir, mirrored.Deltas[i].Path), progress.NewManual(-1)); err != nil {
+			return nil, fmt.Errorf("unable to mirror delta: %w", err)
+		}
+	}
+	if len(mirrored.Deltas) == 0 {
+		mirrored.Deltas = nil
+	}
+
+	// the listing can only be mirrored verbatim (keeping its signature valid) when it already references the archives
+	// relative to the listing
+	writeListing := mirrored.Write
+	listingPath := filepath.Join(versionDir, LatestFileName)
+	if !rewritten {
+		writeListing = func(w io.Writer) error {
+			_, err := w.Write(contents)
+			return err
+		}
+	} else if signature != nil {
+		log.WithFields("path", latest.Path).Warn("the listing must be rewritten to reference the mirrored archive, so its signature cannot be mirrored")
+		signature = nil
+	}
+
+	// the listing is written last (and atomically) so that clients never see a listing that references an archive
+	// that has not been completely written
+	if err := writeSignature(listingPath, signature); err != nil {
+		return nil, err
+	}
+	if err := writeFileAtomically(listingPath, writeListing); err != nil {
+		return nil, fmt.Errorf("unable to write listing file: %w", err)
+	}
+
+	return &mirrored, nil
+}
+
+// mirroredPath returns the path of an archive within the mirror, which is always next to the listing
+func mirroredPath(p string) (string, error) {
+	base := path.Base(path.Clean(p))
+	if base == "." || base == "/" || base == ".." {
+		return "", fmt.Errorf("invalid archive path in database listing: %q", p)
+	}
+	return base, nil
+}
+
+func (c client) mirrorArchive(archive Archive, dest string, downloadProgress *progress.Manual) error {
+	archiveURL, err := c.ResolveArchiveURL(archive)
+	if err != nil {
+		return err
+	}
+
+	downloaded := dest
+	if isMirrored(dest, archive.Checksum) {
+		log.WithFields("path", dest).Debug("database archive is already mirrored")
+	} else {
+		rawURL, err := withoutExtraction(archiveURL)
+		if err != nil {
+			return err
+		}
+
+		downloaded = dest + ".partial"
+		defer removeAllOrLog(c.fs, downloaded)
+
+		if err := c.dbDownloader.GetFile(downloaded, rawURL, downloadProgress); err != nil {
+			return fmt.Errorf("unable to download db: %w", err)
+		}
+	}
+
+	// the signature is always refreshed, since it may have been published after the archive was mirrored
+	signature, err := c.getSignature(archiveURL)
+	if err != nil {
+		return err
+	}
+
+	if err := c.verifier.VerifyFile(downloaded, signature); err != nil {
+		return err
+	}
+
+	if err := writeSignature(dest, signature); err != nil {
+		return err
+	}
+
+	if downloaded == dest {
+		return nil
+	}
+
+	if err := os.Rename(downloaded, dest); err != nil {
+		return fmt.Errorf("unable to move db archive into place: %w", err)
+	}
+	return nil
+}
+
+// isMirrored indicates if the archive at the given path already matches the expected checksum
+func isMirrored(archivePath, checksum string) bool {
+	if _, err := os.Stat(archivePath); err != nil {
+		return false
+	}
+	digest, err := calculateArchiveDigest(archivePath)
+	if err != nil {
+		log.WithFields("path", archivePath, "error", err).Debug("unable to calculate checksum of mirrored archive")
+		return false
+	}
+	return digest == checksum
+}
+
+// writeSignature writes (or removes, when there is no signature) the detached signature for the file at the given path
+func writeSignature(path string, signature []byte) error {
+	sigPath := path + SignatureExtension
+	if signature == nil {
+		if err := os.Remove(sigPath); err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("unable to remove stale signature: %w", err)
+		}
+		return nil
+	}
+
+	return writeFileAtomically(sigPath, func(w io.Writer) error {
+		_, err := w.Write(signature)
+		return err
+	})
+}
+
+func writeFileAtomically(dest string, write func(io.Writer) error) error {
+	f, err := os.CreateTemp(filepath.Dir(dest), filepath.Base(dest)+".*.partial")
+	if err != nil {
+		return err
+	}
+
+	if err := write(f); err != nil {
+		_ = f.Close()
+		_ = os.Remove(f.Name())
+		return err
+	}
+
+	if err := f.Close(); err != nil {
+		_ = os.Remove(f.Name())
+		return err
+	}
+
+	if err := os.Chmod(f.Name(), 0644); err != nil {
+		_ = os.Remove(f.Name())
+		return err
+	}
+
+	return os.Rename(f.Name(), dest)
+}
@@ -0,0 +1,232 @@
+package distribution
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/wagoodman/go-progress"
+
+	db "github.com/anchore/grype/grype/db/v6"
+	"github.com/anchore/grype/internal/schemaver"
+)
+
+// writeTestDistribution writes a listing and DB archive to the given directory with the same layout as the
+// distribution endpoint, returning the listing
+func writeTestDistribution(t *testing.T, dir string) LatestDocument {
+	t.Helper()
+
+	archiveDir := filepath.Join(dir, "v6", "archives")
+	require.NoError(t, os.MkdirAll(archiveDir, 0755))
+
+	archivePath := filepath.Join(archiveDir, "vulnerability-db_v6.0.2.tar.gz")
+	fh, err := os.Create(archivePath)
+	require.NoError(t, err)
+
+	gz := gzip.NewWriter(fh)
+	tw := tar.NewWriter(gz)
+	contents := []byte("not really a database")
+	require.NoError(t, tw.WriteHeader(&tar.Header{Name: db.VulnerabilityDBFileName, Mode: 0644, Size: int64(len(contents))}))
+	_, err = tw.Write(contents)
+	require.NoError(t, err)
+	require.NoError(t, tw.Close())
+	require.NoError(t, gz.Close())
+	require.NoError(t, fh.Close())
+
+	checksum, err := calculateArchiveDigest(archivePath)
+	require.NoError(t, err)
+
+	latest := LatestDocument{
+		Status: StatusActive,
+		Archive: Archive{
+			Description: db.Description{
+				SchemaVersion: schemaver.New(db.ModelVersion, db.Revision, db.Addition),
+				Built:         db.Time{Time: time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)},
+			},
+			Path:     "archives/vulnerability-db_v6.0.2.tar.gz",
+			Checksum: checksum,
+		},
+	}
+
+	listing, err := os.Create(filepath.Join(dir, "v6", LatestFileName))
+	require.NoError(t, err)
+	require.NoError(t, latest.Write(listing))
+	require.NoError(t, listing.Close())
+
+	return latest
+}
+
+func TestClient_Mirror(t *testing.T) {
+	upstreamDir := t.TempDir()
+	upstream := writeTestDistribution(t, upstreamDir)
+
+	server := httptest.NewServer(http.FileServer(http.Dir(upstreamDir)))
+	t.Cleanup(server.Close)
+
+	tests := []struct {
+		name      string
+		latestURL string
+	}{
+		{
+			name:      "from an http server",
+			latestURL: server.URL,
+		},
+		{
+			name:      "from a file URL",
+			latestURL: "file://" + filepath.ToSlash(upstreamDir),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, err := NewClient(Config{LatestURL: tt.latestURL})
+			require.NoError(t, err)
+
+			mirrorDir := t.TempDir()
+			mirrored, err := c.Mirror(mirrorDir, progress.NewManual(-1))
+			require.NoError(t, err)
+
+			// the listing references the archive relative to the listing in the mirror
+			assert.Equal(t, "vulnerability-db_v6.0.2.tar.gz", mirrored.Path)
+			assert.Equal(t, upstream.Checksum, mirrored.Checksum)
+			assert.Equal(t, upstream.Built, mirrored.Built)
+
+			listing, err := NewLatestFromFile(c.(client).fs, filepath.Join(mirrorDir, "v6", LatestFileName))
+			require.NoError(t, err)
+			assert.Equal(t, mirrored, listing)
+
+			// the archive is mirrored as-is
+			upstreamArchive, err := os.ReadFile(filepath.Join(upstreamDir, "v6", "archives", "vulnerability-db_v6.0.2.tar.gz"))
+			require.NoError(t, err)
+			mirroredArchive, err := os.ReadFile(filepath.Join(mirrorDir, "v6", mirrored.Path))
+			require.NoError(t, err)
+			assert.Equal(t, upstreamArchive, mirroredArchive)
+
+			// mirroring again when the archive is already present is a no-op
+			_, err = c.Mirror(mirrorDir, progress.NewManual(-1))
+			require.NoError(t, err)
+			entries, err := os.ReadDir(filepath.Join(mirrorDir, "v6"))
+			require.NoError(t, err)
+			assert.Len(t, entries, 2)
+		})
+	}
+}
+
+func TestClient_Mirror_checksumMismatch(t *testing.T) {
+	upstreamDir := t.TempDir()
+	upstream := writeTestDistribution(t, upstreamDir)
+
+	upstream.Checksum = "sha256:0000000000000000000000000000000000000000000000000000000000000000"
+	listing, err := os.Create(filepath.Join(upstreamDir, "v6", LatestFileName))
+	require.NoError(t, err)
+	require.NoError(t, upstream.Write(listing))
+	require.NoError(t, listing.Close())
+
+	c, err := NewClient(Config{LatestURL: "file://" + filepath.ToSlash(upstreamDir)})
+	require.NoError(t, err)
+
+	mirrorDir := t.TempDir()
+	_, err = c.Mirror(mirrorDir, progress.NewManual(-1))
+	require.ErrorContains(t, err, "unable to download db")
+
+	// neither the listing nor a partial archive are left in the mirror
+	entries, err := os.ReadDir(filepath.Join(mirrorDir, "v6"))
+	require.NoError(t, err)
+	assert.Empty(t, entries)
+}
+
+func TestClient_Mirror_deltas(t *testing.T) {
+	upstreamDir := t.TempDir()
+	upstream := writeTestDistribution(t, upstreamDir)
+
+	deltaPath := filepath.Join(upstreamDir, "v6", "deltas", "vulnerability-db_v6.0.2_delta.tar.gz")
+	require.NoError(t, os.MkdirAll(filepath.Dir(deltaPath), 0755))
+	require.NoError(t, os.WriteFile(deltaPath, []byte("not really a delta"), 0644))
+	checksum, err := calculateArchiveDigest(deltaPath)
+	require.NoError(t, err)
+
+	upstream.Deltas = []Delta{{
+		From:     db.Time{Time: upstream.Built.Add(-24 * time.Hour)},
+		To:       upstream.Built,
+		Path:     "deltas/vulnerability-db_v6.0.2_delta.tar.gz",
+		Checksum: checksum,
+	}}
+	listing, err := os.Create(filepath.Join(upstreamDir, "v6", LatestFileName))
+	require.NoError(t, err)
+	require.NoError(t, upstream.Write(listing))
+	require.NoError(t, listing.Close())
+
+	c, err := NewClient(Config{LatestURL: "file://" + filepath.ToSlash(upstreamDir)})
+	require.NoError(t, err)
+
+	mirrorDir := t.TempDir()
+	mirrored, err := c.Mirror(mirrorDir, progress.NewManual(-1))
+	require.NoError(t, err)
+
+	// deltas are mirrored next to the listing as well
+	require.Len(t, mirrored.Deltas, 1)
+	assert.Equal(t, "vulnerability-db_v6.0.2_delta.tar.gz", mirrored.Deltas[0].Path)
+	assert.Equal(t, upstream.Deltas[0].From, mirrored.Deltas[0].From)
+	assert.Equal(t, checksum, mirrored.Deltas[0].Checksum)
+
+	contents, err := os.ReadFile(filepath.Join(mirrorDir, "v6", mirrored.Deltas[0].Path))
+	require.NoError(t, err)
+	assert.Equal(t, "not really a delta", string(contents))
+
+	got, err := NewLatestFromFile(c.(client).fs, filepath.Join(mirrorDir, "v6", LatestFileName))
+	require.NoError(t, err)
+	assert.Equal(t, mirrored, got)
+}
+
+func TestClient_fileURL(t *testing.T) {
+	mirrorDir := t.TempDir()
+	latest := writeTestDistribution(t, mirrorDir)
+
+	c, err := NewClient(Config{LatestURL: "file://" + filepath.ToSlash(mirrorDir)})
+	require.NoError(t, err)
+
+	got, err := c.Latest()
+	require.NoError(t, err)
+	require.Equal(t, &latest, got)
+
+	archiveURL, err := c.ResolveArchiveURL(got.Archive)
+	require.NoError(t, err)
+
+	dest, err := c.Download(archiveURL, t.TempDir(), progress.NewManual(-1))
+	require.NoError(t, err)
+	assert.FileExists(t, filepath.Join(dest, db.VulnerabilityDBFileName))
+
+	// the checksum is verified for file URLs as well
+	got.Checksum = "sha256:0000000000000000000000000000000000000000000000000000000000000000"
+	archiveURL, err = c.ResolveArchiveURL(got.Archive)
+	require.NoError(t, err)
+
+	_, err = c.Download(archiveURL, t.TempDir(), progress.NewManual(-1))
+	require.ErrorContains(t, err, "Checksums did not match")
+}
+
+func Test_absFileURL(t *testing.T) {
+	wd, err := os.Getwd()
+	require.NoError(t, err)
+
+	tests := []struct {
+		url  string
+		want string
+	}{
+		{url: "https://grype.anchore.io/databases", want: "https://grype.anchore.io/databases"},
+		{url: "file:///mnt/mirror", want: "file:///mnt/mirror"},
+		{url: "file://mirror", want: "file://" + filepath.ToSlash(filepath.Join(wd, "mirror"))},
+		{url: "file://./mirror/v6/latest.json", want: "file://" + filepath.ToSlash(filepath.Join(wd, "mirror", "v6", "latest.json"))},
+	}
+	for _, tt := range tests {
+		t.Run(tt.url, func(t *testing.T) {
+			assert.Equal(t, tt.want, absFileURL(tt.url))
+		})
+	}
+}
@@ -0,0 +1,228 @@
+package distribution
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+	"net/url"
+	"os"
+
+	"github.com/anchore/grype/internal/log"
+)
+
+// SignatureExtension is the extension of the detached signature published alongside a signed listing or DB archive
+// (e.g. "latest.json.sig"). Signatures are the base64 encoded signature of the file contents, as produced by
+// `cosign sign-blob --key <key> <file>` or `openssl dgst -sha256 -sign <key> <file> | base64`.
+const SignatureExtension = ".sig"
+
+var (
+	// ErrSignatureRequired indicates that a signature was required but not found
+	ErrSignatureRequired = errors.New("a signature is required but none was found")
+
+	// ErrInvalidSignature indicates that a signature was found but does not match the signed file
+	ErrInvalidSignature = errors.New("invalid signature")
+)
+
+type SignatureConfig struct {
+	// PublicKey is the path to a PEM encoded public key (ECDSA, Ed25519, or RSA) to verify signatures with
+	PublicKey string
+
+	// Required indicates that the listing and DB archives must have a valid signature
+	Required bool
+}
+
+// SignatureVerifier verifies the detached signatures of listings and DB archives
+type SignatureVerifier struct {
+	key      crypto.PublicKey
+	required bool
+}
+
+// NewSignatureVerifier returns a verifier for the configured public key, or nil when signatures are not verified.
+func NewSignatureVerifier(cfg SignatureConfig) (*SignatureVerifier, error) {
+	if cfg.PublicKey == "" {
+		if cfg.Required {
+			return nil, fmt.Errorf("a public key is required to verify signatures")
+		}
+		return nil, nil
+	}
+
+	contents, err := os.ReadFile(cfg.PublicKey)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read signature public key: %w", err)
+	}
+
+	key, err := parsePublicKey(contents)
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse signature public key %q: %w", cfg.PublicKey, err)
+	}
+
+	return &SignatureVerifier{
+		key:      key,
+		required: cfg.Required,
+	}, nil
+}
+
+func parsePublicKey(contents []byte) (crypto.PublicKey, error) {
+	block, _ := pem.Decode(contents)
+	if block == nil {
+		return nil, fmt.Errorf("no PEM block found")
+	}
+
+	key, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		return nil, err
+	}
+
+	switch key.(type) {
+	case *ecdsa.PublicKey, ed25519.PublicKey, *rsa.PublicKey:
+		return key, nil
+	default:
+		return nil, fmt.Errorf("unsupported key type %T", key)
+	}
+}
+
+// VerifyFile verifies the given signature of the file at path. A nil signature indicates that no signature was found,
+// which is only an error when signatures are required.
+func (v *SignatureVerifier) VerifyFile(path string, signature []byte) error {
+	if v == nil {
+		return nil
+	}
+
+	if signature == nil {
+		return v.Verify(path, nil, nil)
+	}
+
+	fh, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("unable to open file to verify signature: %w", err)
+	}
+	defer log.CloseAndLogError(fh, path)
+
+	return v.Verify(path, fh, signature)
+}
+
+// Verify verifies the given signature of the named content. A nil signature indicates that no signature was found,
+// which is only an error when signatures are required.
+func (v *SignatureVerifier) Verify(name string, content io.Reader, signature []byte) error {
+	if v == nil {
+		return nil
+	}
+
+	if signature == nil {
+		if v.required {
+			return fmt.Errorf("%s: %w", name, ErrSignatureRequired)
+		}
+		log.WithFields("file", name).Warn("no signature found, skipping signature verification")
+		return nil
+	}
+
+	if err := v.verify(content, decodeSignature(signature)); err != nil {
+		return fmt.Errorf("%w for %s: %w", ErrInvalidSignature, name, err)
+	}
+
+	log.WithFields("file", name).Debug("verified signature")
+	return nil
+}
+
+// ReadSignatureFile reads the detached signature for the file at the given path, returning nil if there is none
+func ReadSignatureFile(path string) ([]byte, error) {
+	signature, err := os.ReadFile(path + SignatureExtension)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("unable to read signature: %w", err)
+	}
+	return signature, nil
+}
+
+func (v *SignatureVerifier) verify(content io.Reader, signature []byte) error {
+	switch key := v.key.(type) {
+	case ed25519.PublicKey:
+		message, err := io.ReadAll(content)
+		if err != nil {
+			return err
+		}
+		if !ed25519.Verify(key, message, signature) {
+			return fmt.Errorf("signature does not match")
+		}
+		return nil
+	case *ecdsa.PublicKey:
+		h, err := ecdsaHash(key.Curve)
+		if err != nil {
+			return err
+		}
+		digest, err := digestOf(h.New(), content)
+		if err != nil {
+			return err
+		}
+		if !ecdsa.VerifyASN1(key, digest, signature) {
+			return fmt.Errorf("signature does not match")
+		}
+		return nil
+	case *rsa.PublicKey:
+		digest, err := digestOf(crypto.SHA256.New(), content)
+		if err != nil {
+			return err
+		}
+		if err = rsa.VerifyPKCS1v15(key, crypto.SHA256, digest, signature); err != nil {
+			return fmt.Errorf("signature does not match: %w", err)
+		}
+		return nil
+	default:
+		return fmt.Errorf("unsupported key type %T", key)
+	}
+}
+
+// ecdsaHash returns the hash used for signatures with the given curve (consistent with cosign)
+func ecdsaHash(curve elliptic.Curve) (crypto.Hash, error) {
+	switch curve {
+	case elliptic.P256():
+		return crypto.SHA256, nil
+	case elliptic.P384():
+		return crypto.SHA384, nil
+	case elliptic.P521():
+		return crypto.SHA512, nil
+	default:
+		return 0, fmt.Errorf("unsupported curve %s", curve.Params().Name)
+	}
+}
+
+func digestOf(h hash.Hash, content io.Reader) ([]byte, error) {
+	if _, err := io.Copy(h, content); err != nil {
+		return nil, err
+	}
+	return h.Sum(nil), nil
+}
+
+// decodeSignature decodes a base64 encoded signature, falling back to the raw bytes for binary signatures
+func decodeSignature(signature []byte) []byte {
+	trimmed := bytes.TrimSpace(signature)
+	decoded := make([]byte, base64.StdEncoding.DecodedLen(len(trimmed)))
+	n, err := base64.StdEncoding.Decode(decoded, trimmed)
+	if err != nil {
+		return signature
+	}
+	return decoded[:n]
+}
+
+// signatureURL returns the URL of the detached signature for the file at the given URL (without any query parameters)
+func signatureURL(fileURL string) (string, error) {
+	u, err := url.Parse(fileURL)
+	if err != nil {
+		return "", fmt.Errorf("unable to parse URL %q: %w", fileURL, err)
+	}
+	u.RawQuery = ""
+	u.Path += SignatureExtension
+	return u.String(), nil
+}
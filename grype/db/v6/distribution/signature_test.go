@@ -0,0 +1,334 @@
+package distribution
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/wagoodman/go-progress"
+
+	db "github.com/anchore/grype/grype/db/v6"
+)
+
+// testSigner signs files with a locally generated key pair, writing the PEM encoded public key to a file
+type testSigner struct {
+	publicKey string
+	sign      func(t *testing.T, content []byte) []byte
+}
+
+func newTestSigner(t *testing.T, keyType string) testSigner {
+	t.Helper()
+
+	var public crypto.PublicKey
+	var sign func(t *testing.T, content []byte) []byte
+	switch keyType {
+	case "ecdsa":
+		key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		require.NoError(t, err)
+		public = key.Public()
+		sign = func(t *testing.T, content []byte) []byte {
+			digest := sha256.Sum256(content)
+			signature, err := ecdsa.SignASN1(rand.Reader, key, digest[:])
+			require.NoError(t, err)
+			return signature
+		}
+	case "ed25519":
+		pub, key, err := ed25519.GenerateKey(rand.Reader)
+		require.NoError(t, err)
+		public = pub
+		sign = func(_ *testing.T, content []byte) []byte {
+			return ed25519.Sign(key, content)
+		}
+	case "rsa":
+		key, err := rsa.GenerateKey(rand.Reader, 2048)
+		require.NoError(t, err)
+		public = key.Public()
+		sign = func(t *testing.T, content []byte) []byte {
+			digest := sha256.Sum256(content)
+			signature, err := rsa.SignPKCS1v15(rand.Reader, key, crypto.SHA256, digest[:])
+			require.NoError(t, err)
+			return signature
+		}
+	default:
+		t.Fatalf("unknown key type %q", keyType)
+	}
+
+	der, err := x509.MarshalPKIXPublicKey(public)
+	require.NoError(t, err)
+
+	publicKey := filepath.Join(t.TempDir(), keyType+".pub")
+	require.NoError(t, os.WriteFile(publicKey, pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}), 0600))
+
+	return testSigner{publicKey: publicKey, sign: sign}
+}
+
+// signFile writes the base64 encoded detached signature for the file at the given path
+func (s testSigner) signFile(t *testing.T, path string) {
+	t.Helper()
+
+	content, err := os.ReadFile(path)
+	require.NoError(t, err)
+
+	signature := base64.StdEncoding.EncodeToString(s.sign(t, content))
+	require.NoError(t, os.WriteFile(path+SignatureExtension, []byte(signature+"\n"), 0600))
+}
+
+func TestNewSignatureVerifier(t *testing.T) {
+	signer := newTestSigner(t, "ecdsa")
+
+	notAKey := filepath.Join(t.TempDir(), "not-a-key.pub")
+	require.NoError(t, os.WriteFile(notAKey, []byte("not a key"), 0600))
+
+	tests := []struct {
+		name    string
+		cfg     SignatureConfig
+		wantNil bool
+		wantErr string
+	}{
+		{
+			name:    "no key",
+			wantNil: true,
+		},
+		{
+			name:    "required without a key",
+			cfg:     SignatureConfig{Required: true},
+			wantErr: "a public key is required",
+		},
+		{
+			name: "with a key",
+			cfg:  SignatureConfig{PublicKey: signer.publicKey},
+		},
+		{
+			name:    "missing key file",
+			cfg:     SignatureConfig{PublicKey: filepath.Join(t.TempDir(), "missing.pub")},
+			wantErr: "unable to read signature public key",
+		},
+		{
+			name:    "invalid key file",
+			cfg:     SignatureConfig{PublicKey: notAKey},
+			wantErr: "no PEM block found",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NewSignatureVerifier(tt.cfg)
+			if tt.wantErr != "" {
+				require.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantNil, got == nil)
+		})
+	}
+}
+
+func TestSignatureVerifier_VerifyFile(t *testing.T) {
+	for _, keyType := range []string{"ecdsa", "ed25519", "rsa"} {
+		t.Run(keyType, func(t *testing.T) {
+			signer := newTestSigner(t, keyType)
+
+			path := filepath.Join(t.TempDir(), "vulnerability-db_v6.0.2.tar.gz")
+			content := []byte("not really a database")
+			require.NoError(t, os.WriteFile(path, content, 0600))
+
+			verifier, err := NewSignatureVerifier(SignatureConfig{PublicKey: signer.publicKey})
+			require.NoError(t, err)
+			required, err := NewSignatureVerifier(SignatureConfig{PublicKey: signer.publicKey, Required: true})
+			require.NoError(t, err)
+
+			raw := signer.sign(t, content)
+			encoded := []byte(base64.StdEncoding.EncodeToString(raw) + "\n")
+			other := signer.sign(t, []byte("something else"))
+
+			// base64 encoded and raw signatures are supported
+			require.NoError(t, verifier.VerifyFile(path, encoded))
+			require.NoError(t, verifier.VerifyFile(path, raw))
+
+			err = verifier.VerifyFile(path, other)
+			require.ErrorIs(t, err, ErrInvalidSignature)
+
+			// a missing signature is only an error when signatures are required
+			require.NoError(t, verifier.VerifyFile(path, nil))
+			require.ErrorIs(t, required.VerifyFile(path, nil), ErrSignatureRequired)
+			require.NoError(t, required.VerifyFile(path, encoded))
+		})
+	}
+}
+
+func TestSignatureVerifier_VerifyFile_nilVerifier(t *testing.T) {
+	var verifier *SignatureVerifier
+	require.NoError(t, verifier.VerifyFile("does-not-exist", nil))
+	require.NoError(t, verifier.VerifyFile("does-not-exist", []byte("bogus")))
+}
+
+func TestReadSignatureFile(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "vulnerability.db")
+	require.NoError(t, os.WriteFile(path+SignatureExtension, []byte("signature"), 0600))
+
+	got, err := ReadSignatureFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, []byte("signature"), got)
+
+	got, err = ReadSignatureFile(filepath.Join(dir, "unsigned.db"))
+	require.NoError(t, err)
+	assert.Nil(t, got)
+}
+
+func TestClient_signatures(t *testing.T) {
+	signer := newTestSigner(t, "ecdsa")
+
+	upstreamDir := t.TempDir()
+	writeTestDistribution(t, upstreamDir)
+	listingPath := filepath.Join(upstreamDir, "v6", LatestFileName)
+	archivePath := filepath.Join(upstreamDir, "v6", "archives", "vulnerability-db_v6.0.2.tar.gz")
+
+	newClient := func(t *testing.T, required bool) Client {
+		c, err := NewClient(Config{
+			LatestURL: "file://" + filepath.ToSlash(upstreamDir),
+			Signature: SignatureConfig{PublicKey: signer.publicKey, Required: required},
+		})
+		require.NoError(t, err)
+		return c
+	}
+
+	download := func(t *testing.T, c Client) error {
+		latest, err := c.Latest()
+		require.NoError(t, err)
+		archiveURL, err := c.ResolveArchiveURL(latest.Archive)
+		require.NoError(t, err)
+		_, err = c.Download(archiveURL, t.TempDir(), progress.NewManual(-1))
+		return err
+	}
+
+	downloadSigned := func(t *testing.T, c Client) error {
+		latest, err := c.Latest()
+		require.NoError(t, err)
+		archiveURL, err := c.ResolveArchiveURL(latest.Archive)
+		require.NoError(t, err)
+		_, err = c.DownloadSigned(archiveURL, t.TempDir(), progress.NewManual(-1))
+		return err
+	}
+
+	t.Run("signed downloads require a key", func(t *testing.T) {
+		c, err := NewClient(Config{LatestURL: "file://" + filepath.ToSlash(upstreamDir)})
+		require.NoError(t, err)
+		require.ErrorIs(t, downloadSigned(t, c), ErrSignatureRequired)
+	})
+
+	t.Run("unsigned is allowed unless required", func(t *testing.T) {
+		require.NoError(t, download(t, newClient(t, false)))
+
+		_, err := newClient(t, true).Latest()
+		require.ErrorIs(t, err, ErrSignatureRequired)
+	})
+
+	signer.signFile(t, listingPath)
+
+	t.Run("unsigned archive", func(t *testing.T) {
+		require.NoError(t, download(t, newClient(t, false)))
+		require.ErrorIs(t, download(t, newClient(t, true)), ErrSignatureRequired)
+
+		// signed downloads (e.g. DB deltas) require a signature even when signatures are not otherwise required
+		require.ErrorIs(t, downloadSigned(t, newClient(t, false)), ErrSignatureRequired)
+	})
+
+	signer.signFile(t, archivePath)
+
+	t.Run("signed", func(t *testing.T) {
+		c := newClient(t, true)
+		latest, err := c.Latest()
+		require.NoError(t, err)
+		archiveURL, err := c.ResolveArchiveURL(latest.Archive)
+		require.NoError(t, err)
+		dest, err := c.Download(archiveURL, t.TempDir(), progress.NewManual(-1))
+		require.NoError(t, err)
+		assert.FileExists(t, filepath.Join(dest, db.VulnerabilityDBFileName))
+
+		require.NoError(t, downloadSigned(t, newClient(t, false)))
+	})
+
+	t.Run("mirrored signatures", func(t *testing.T) {
+		mirrorDir := t.TempDir()
+		_, err := newClient(t, true).Mirror(mirrorDir, progress.NewManual(-1))
+		require.NoError(t, err)
+
+		// the listing is rewritten for the mirror, so only the archive signature can be mirrored
+		assert.FileExists(t, filepath.Join(mirrorDir, "v6", "vulnerability-db_v6.0.2.tar.gz"+SignatureExtension))
+		assert.NoFileExists(t, filepath.Join(mirrorDir, "v6", LatestFileName+SignatureExtension))
+
+		// a mirror of the mirror does not need to rewrite the listing, so the listing is copied verbatim with its signature
+		signer.signFile(t, filepath.Join(mirrorDir, "v6", LatestFileName))
+		c, err := NewClient(Config{
+			LatestURL: "file://" + filepath.ToSlash(mirrorDir),
+			Signature: SignatureConfig{PublicKey: signer.publicKey, Required: true},
+		})
+		require.NoError(t, err)
+
+		secondMirrorDir := t.TempDir()
+		_, err = c.Mirror(secondMirrorDir, progress.NewManual(-1))
+		require.NoError(t, err)
+
+		for _, name := range []string{LatestFileName, "vulnerability-db_v6.0.2.tar.gz"} {
+			for _, ext := range []string{"", SignatureExtension} {
+				want, err := os.ReadFile(filepath.Join(mirrorDir, "v6", name+ext))
+				require.NoError(t, err)
+				got, err := os.ReadFile(filepath.Join(secondMirrorDir, "v6", name+ext))
+				require.NoError(t, err)
+				assert.Equal(t, want, got, name+ext)
+			}
+		}
+	})
+
+	t.Run("tampered listing", func(t *testing.T) {
+		require.NoError(t, os.WriteFile(listingPath+SignatureExtension, []byte(base64.StdEncoding.EncodeToString(signer.sign(t, []byte("{}")))), 0600))
+		t.Cleanup(func() { signer.signFile(t, listingPath) })
+
+		c := newClient(t, false)
+		_, err := c.Latest()
+		require.ErrorIs(t, err, ErrInvalidSignature)
+
+		// an invalid signature fails the update check, even when the update check is not required
+		_, err = c.IsUpdateAvailable(nil)
+		require.ErrorIs(t, err, ErrInvalidSignature)
+	})
+
+	t.Run("tampered archive", func(t *testing.T) {
+		require.NoError(t, os.WriteFile(archivePath+SignatureExtension, []byte(base64.StdEncoding.EncodeToString(signer.sign(t, []byte("{}")))), 0600))
+
+		require.ErrorIs(t, download(t, newClient(t, false)), ErrInvalidSignature)
+		require.ErrorIs(t, downloadSigned(t, newClient(t, false)), ErrInvalidSignature)
+		_, err := newClient(t, false).Mirror(t.TempDir(), progress.NewManual(-1))
+		require.ErrorIs(t, err, ErrInvalidSignature)
+	})
+}
+
+func Test_signatureURL(t *testing.T) {
+	tests := []struct {
+		url  string
+		want string
+	}{
+		{url: "https://grype.anchore.io/databases/v6/latest.json", want: "https://grype.anchore.io/databases/v6/latest.json.sig"},
+		{url: "https://grype.anchore.io/databases/v6/db.tar.zst?checksum=sha256%3Aabc", want: "https://grype.anchore.io/databases/v6/db.tar.zst.sig"},
+		{url: "file:///mnt/mirror/v6/latest.json", want: "file:///mnt/mirror/v6/latest.json.sig"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.url, func(t *testing.T) {
+			got, err := signatureURL(tt.url)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
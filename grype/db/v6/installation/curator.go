@@ -46,6 +46,10 @@ type Config struct {
 	ValidateChecksum        bool
 	MaxAllowedBuiltAge      time.Duration
 	UpdateCheckMaxFrequency time.Duration
+	Signature               distribution.SignatureConfig
+
+	// PackageAliases are applied to the packages searched for within the DB (see db.PackageSpecifierAlias)
+	PackageAliases []db.PackageSpecifierAlias
 }
 
 func DefaultConfig(id clio.Identification) Config {
@@ -66,29 +70,41 @@ func (c Config) DBDirectoryPath() string {
 	return filepath.Join(c.DBRootDir, strconv.Itoa(db.ModelVersion))
 }
 
+// ReaderConfig returns the configuration for reading the database within the given directory (such as a copy of the
+// installed database) with the same options as the installed database is read with
+func (c Config) ReaderConfig(dbDirPath string) db.Config {
+	return db.Config{
+		DBDirPath:      dbDirPath,
+		Debug:          c.Debug,
+		PackageAliases: c.PackageAliases,
+	}
+}
+
 type curator struct {
 	fs       afero.Fs
 	client   distribution.Client
+	verifier *distribution.SignatureVerifier
 	config   Config
 	hydrator func(string) error
 }
 
 func NewCurator(cfg Config, downloader distribution.Client) (db.Curator, error) {
+	verifier, err := distribution.NewSignatureVerifier(cfg.Signature)
+	if err != nil {
+		return nil, err
+	}
+
 	return curator{
 		fs:       afero.NewOsFs(),
 		client:   downloader,
+		verifier: verifier,
 		config:   cfg,
 		hydrator: db.Hydrater(),
 	}, nil
 }
 
 func (c curator) Reader() (db.Reader, error) {
-	s, err := db.NewReader(
-		db.Config{
-			DBDirPath: c.config.DBDirectoryPath(),
-			Debug:     c.config.Debug,
-		},
-	)
+	s, err := db.NewReader(c.config.ReaderConfig(c.config.DBDirectoryPath()))
 	if err != nil {
 		return nil, err
 	}
@@ -136,12 +152,7 @@ func (c curator) Reader() (db.Reader, error) {
 		mon.Set("rehydrated")
 		mon.SetCompleted()
 
-		s, err = db.NewReader(
-			db.Config{
-				DBDirPath: c.config.DBDirectoryPath(),
-				Debug:     c.config.Debug,
-			},
-		)
+		s, err = db.NewReader(c.config.ReaderConfig(c.config.DBDirectoryPath()))
 		if err != nil {
 			return nil, fmt.Errorf("unable to create new reader after rehydration: %w", err)
 		}
@@ -292,6 +303,23 @@ func (c curator) update(current *db.Description) (*distribution.Archive, error)
 		return nil, checkErr
 	}
 
+	if current != nil {
+		if chain := update.DeltaChain(current.Built); chain != nil {
+			// deltas change the existing DB in place, so are only trusted when signed
+			if c.verifier == nil {
+				log.Debug("not updating the vulnerability DB with deltas, as no signature key is configured to verify them")
+			} else {
+				err := c.updateWithDeltas(current, update, chain, mon)
+				if err == nil {
+					mon.Set("updated")
+					c.setLastSuccessfulUpdateCheck()
+					return update, nil
+				}
+				log.WithFields("error", err).Warn("unable to update vulnerability DB with deltas, downloading the full archive instead")
+			}
+		}
+	}
+
 	log.Info("downloading new vulnerability DB")
 	mon.Set("downloading")
 	url, err := c.client.ResolveArchiveURL(*update)
@@ -327,6 +355,76 @@ func (c curator) update(current *db.Description) (*distribution.Archive, error)
 	return update, nil
 }
 
+// updateWithDeltas applies the given chain of deltas to a copy of the current DB, which is only activated if the result
+// is exactly the expected update.
+func (c curator) updateWithDeltas(current *db.Description, update *distribution.Archive, chain []distribution.Delta, mon monitor) error {
+	startTime := time.Now()
+
+	// deltas can only be applied to the exact DB they were created from
+	if _, err := c.validateIntegrity(current); err != nil {
+		return fmt.Errorf("current vulnerability DB cannot be updated with deltas: %w", err)
+	}
+
+	stageDir, err := os.MkdirTemp(c.config.DBRootDir, fmt.Sprintf("tmp-v%v-delta", db.ModelVersion))
+	if err != nil {
+		return fmt.Errorf("unable to create db delta temp dir: %w", err)
+	}
+	// note: once activated the stage dir no longer exists, so this is only cleaning up after failures
+	defer removeAllOrLog(c.fs, stageDir)
+
+	mon.Set("copying")
+	if err := file.CopyFile(c.fs, c.config.DBFilePath(), filepath.Join(stageDir, db.VulnerabilityDBFileName)); err != nil {
+		return fmt.Errorf("unable to copy current vulnerability DB: %w", err)
+	}
+
+	var url string
+	var result *db.Description
+	for _, delta := range chain {
+		url, err = c.client.ResolveArchiveURL(distribution.Archive{Path: delta.Path, Checksum: delta.Checksum})
+		if err != nil {
+			return fmt.Errorf("unable to resolve vulnerability DB delta URL: %w", err)
+		}
+
+		mon.Set("applying deltas")
+		result, err = c.applyDelta(stageDir, url, delta, mon)
+		if err != nil {
+			return err
+		}
+	}
+
+	if result == nil || result.SchemaVersion != update.SchemaVersion || !result.Built.Equal(update.Built.Time) {
+		return fmt.Errorf("vulnerability DB deltas resulted in %s, expected %s", result, update.Description)
+	}
+
+	log.WithFields("deltas", len(chain), "time", time.Since(startTime)).Info("applied vulnerability DB deltas")
+
+	mon.downloadProgress.SetCompleted()
+	if err := c.activate(stageDir, url, mon); err != nil {
+		return fmt.Errorf("unable to activate updated vulnerability database: %w", err)
+	}
+	return nil
+}
+
+// applyDelta downloads the delta at the given URL, which must have a valid signature, and applies it to the DB within
+// the given directory.
+func (c curator) applyDelta(dbDirPath, url string, delta distribution.Delta, mon monitor) (*db.Description, error) {
+	dir, err := c.client.DownloadSigned(url, c.config.DBRootDir, mon.downloadProgress.Manual)
+	if err != nil {
+		return nil, fmt.Errorf("unable to download vulnerability DB delta: %w", err)
+	}
+	defer removeAllOrLog(c.fs, dir)
+
+	result, err := db.ApplyDelta(dbDirPath, filepath.Join(dir, db.DeltaFileName))
+	if err != nil {
+		return nil, fmt.Errorf("unable to apply vulnerability DB delta from %s: %w", url, err)
+	}
+
+	if !result.Built.Equal(delta.To.Time) {
+		return nil, fmt.Errorf("vulnerability DB delta from %s resulted in %s, expected a DB built at %s", url, result, delta.To)
+	}
+	return result, nil
+}
+
 func isRehydrationNeeded(fs afero.Fs, dirPath string, currentDBVersion *schemaver.SchemaVer, currentClientVersion schemaver.SchemaVer) (bool, error) {
 	if currentDBVersion == nil {
 		// there is no DB to rehydrate
@@ -440,6 +538,11 @@ func (c curator) Import(reference string) error {
 
 		url = reference
 	} else {
+		// note: archives imported by URL are verified by the client
+		if err := c.verifyImport(reference); err != nil {
+			return err
+		}
+
 		// note: the temp directory is persisted upon download/validation/activation failure to allow for investigation
 		var err error
 		tempDir, err = os.MkdirTemp(c.config.DBRootDir, fmt.Sprintf("tmp-v%v-import", db.ModelVersion))
@@ -477,6 +580,20 @@ func (c curator) Import(reference string) error {
 	return nil
 }
 
+// verifyImport verifies the detached signature (e.g. "vulnerability-db_v6.0.2.tar.gz.sig") of a local DB archive or file
+func (c curator) verifyImport(reference string) error {
+	if c.verifier == nil {
+		return nil
+	}
+
+	signature, err := distribution.ReadSignatureFile(reference)
+	if err != nil {
+		return err
+	}
+
+	return c.verifier.VerifyFile(reference, signature)
+}
+
 var urlPrefixPattern = regexp.MustCompile("^[a-zA-Z]+://")
 
 func isURL(reference string) bool {
@@ -1,8 +1,14 @@
 package installation
 
 import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/base64"
 	"encoding/json"
+	"encoding/pem"
 	"errors"
+	"fmt"
 	"os"
 	"path/filepath"
 	"testing"
@@ -36,7 +42,10 @@ func (m *mockClient) IsUpdateAvailable(current *db.Description) (*distribution.A
 	return args.Get(0).(*distribution.Archive), nil
 }
 
-func (m *mockClient) ResolveArchiveURL(_ distribution.Archive) (string, error) {
+func (m *mockClient) ResolveArchiveURL(archive distribution.Archive) (string, error) {
+	if archive.Path != "" {
+		return "http://localhost/" + archive.Path, nil
+	}
 	return "http://localhost/archive.tar.zst", nil
 }
 
@@ -45,6 +54,16 @@ func (m *mockClient) Download(url, dest string, downloadProgress *progress.Manua
 	return args.String(0), args.Error(1)
 }
 
+func (m *mockClient) DownloadSigned(url, dest string, downloadProgress *progress.Manual) (string, error) {
+	args := m.Called(url, dest, downloadProgress)
+	return args.String(0), args.Error(1)
+}
+
+func (m *mockClient) Mirror(dir string, downloadProgress *progress.Manual) (*distribution.LatestDocument, error) {
+	args := m.Called(dir, downloadProgress)
+	return args.Get(0).(*distribution.LatestDocument), args.Error(1)
+}
+
 func (m *mockClient) Latest() (*distribution.LatestDocument, error) {
 	args := m.Called()
 	return args.Get(0).(*distribution.LatestDocument), args.Error(1)
@@ -258,6 +277,174 @@ func TestCurator_Update(t *testing.T) {
 	})
 }
 
+func TestCurator_Update_Deltas(t *testing.T) {
+	providers := func(t *testing.T, dir string) []string {
+		d, err := db.NewLowLevelDB(filepath.Join(dir, db.VulnerabilityDBFileName), false, false, false)
+		require.NoError(t, err)
+		defer func() {
+			sqlDB, err := d.DB()
+			require.NoError(t, err)
+			require.NoError(t, sqlDB.Close())
+		}()
+
+		var ids []string
+		require.NoError(t, d.Model(&db.Provider{}).Order("id").Pluck("id", &ids).Error)
+		return ids
+	}
+
+	writeDelta := func(t *testing.T, operations string) string {
+		dir := t.TempDir()
+		require.NoError(t, os.WriteFile(filepath.Join(dir, db.DeltaFileName), []byte(operations), 0600))
+		return dir
+	}
+
+	sqlTime := func(t db.Time) string {
+		return t.UTC().Format("2006-01-02 15:04:05+00:00")
+	}
+
+	setup := func(t *testing.T) (curator, *mockClient, db.Description) {
+		c := newTestCurator(t)
+		c.hydrator = nil
+		writeTestDB(t, c.fs, c.config.DBDirectoryPath())
+
+		current, err := db.ReadDescription(c.config.DBFilePath())
+		require.NoError(t, err)
+
+		// deltas are only applied when signatures are verified
+		verifier, err := distribution.NewSignatureVerifier(distribution.SignatureConfig{PublicKey: writeTestPublicKey(t)})
+		require.NoError(t, err)
+		c.verifier = verifier
+
+		return c, c.client.(*mockClient), *current
+	}
+
+	t.Run("applies a chain of deltas", func(t *testing.T) {
+		c, mc, current := setup(t)
+
+		intermediate := db.Time{Time: current.Built.Add(24 * time.Hour)}
+		update := &distribution.Archive{
+			Description: db.Description{SchemaVersion: current.SchemaVersion, Built: db.Time{Time: current.Built.Add(48 * time.Hour)}},
+			Path:        "vulnerability-db.tar.zst",
+			Deltas: []distribution.Delta{
+				{From: intermediate, To: db.Time{Time: current.Built.Add(48 * time.Hour)}, Path: "delta-2.tar.zst"},
+				{From: current.Built, To: intermediate, Path: "delta-1.tar.zst"},
+			},
+		}
+
+		mc.On("IsUpdateAvailable", mock.Anything).Return(update, nil)
+		mc.On("DownloadSigned", "http://localhost/delta-1.tar.zst", c.config.DBRootDir, mock.Anything).Return(writeDelta(t, fmt.Sprintf(`
+{"table": "db_metadata", "op": "update", "row": {"build_timestamp": "%s"}}
+{"table": "providers", "op": "insert", "row": {"id": "alpine", "version": "1"}}
+`, sqlTime(intermediate))), nil)
+		mc.On("DownloadSigned", "http://localhost/delta-2.tar.zst", c.config.DBRootDir, mock.Anything).Return(writeDelta(t, fmt.Sprintf(`
+{"table": "db_metadata", "op": "update", "row": {"build_timestamp": "%s"}}
+{"table": "providers", "op": "insert", "row": {"id": "debian", "version": "1"}}
+`, sqlTime(update.Built))), nil)
+
+		updated, err := c.Update()
+		require.NoError(t, err)
+		require.True(t, updated)
+		mc.AssertExpectations(t)
+		mc.AssertNotCalled(t, "Download", "http://localhost/vulnerability-db.tar.zst", mock.Anything, mock.Anything)
+
+		got, err := db.ReadDescription(c.config.DBFilePath())
+		require.NoError(t, err)
+		assert.True(t, got.Built.Equal(update.Built.Time))
+		assert.Equal(t, []string{"alpine", "debian"}, providers(t, c.config.DBDirectoryPath()))
+
+		// the import metadata reflects the updated DB
+		_, err = c.validateIntegrity(got)
+		require.NoError(t, err)
+		require.FileExists(t, filepath.Join(c.config.DBDirectoryPath(), lastUpdateCheckFileName))
+
+		// nothing is left staged
+		entries, err := os.ReadDir(c.config.DBRootDir)
+		require.NoError(t, err)
+		require.Len(t, entries, 1)
+	})
+
+	fullArchive := func(t *testing.T, c curator) string {
+		stageDir := filepath.Join(t.TempDir(), "staged")
+		writeTestDB(t, c.fs, stageDir)
+		return stageDir
+	}
+
+	t.Run("falls back to the full archive when the result does not match", func(t *testing.T) {
+		c, mc, current := setup(t)
+
+		update := &distribution.Archive{
+			Description: db.Description{SchemaVersion: current.SchemaVersion, Built: db.Time{Time: current.Built.Add(24 * time.Hour)}},
+			Path:        "vulnerability-db.tar.zst",
+			Deltas:      []distribution.Delta{{From: current.Built, To: db.Time{Time: current.Built.Add(24 * time.Hour)}, Path: "delta.tar.zst"}},
+		}
+
+		mc.On("IsUpdateAvailable", mock.Anything).Return(update, nil)
+		// the delta does not update the DB metadata to describe the update
+		mc.On("DownloadSigned", "http://localhost/delta.tar.zst", c.config.DBRootDir, mock.Anything).Return(writeDelta(t, `
+{"table": "providers", "op": "insert", "row": {"id": "alpine", "version": "1"}}
+`), nil)
+		mc.On("Download", "http://localhost/vulnerability-db.tar.zst", c.config.DBRootDir, mock.Anything).Return(fullArchive(t, c), nil)
+
+		updated, err := c.Update()
+		require.NoError(t, err)
+		require.True(t, updated)
+		mc.AssertExpectations(t)
+
+		assert.Empty(t, providers(t, c.config.DBDirectoryPath()))
+	})
+
+	t.Run("falls back to the full archive when the current DB does not match its checksum", func(t *testing.T) {
+		c, mc, current := setup(t)
+		writeTestImportMetadata(t, c.fs, c.config.DBDirectoryPath(), "xxh64:0000000000000000")
+
+		update := &distribution.Archive{
+			Description: db.Description{SchemaVersion: current.SchemaVersion, Built: db.Time{Time: current.Built.Add(24 * time.Hour)}},
+			Path:        "vulnerability-db.tar.zst",
+			Deltas:      []distribution.Delta{{From: current.Built, To: db.Time{Time: current.Built.Add(24 * time.Hour)}, Path: "delta.tar.zst"}},
+		}
+
+		mc.On("IsUpdateAvailable", mock.Anything).Return(update, nil)
+		mc.On("Download", "http://localhost/vulnerability-db.tar.zst", c.config.DBRootDir, mock.Anything).Return(fullArchive(t, c), nil)
+
+		updated, err := c.Update()
+		require.NoError(t, err)
+		require.True(t, updated)
+		mc.AssertExpectations(t)
+		mc.AssertNotCalled(t, "DownloadSigned", "http://localhost/delta.tar.zst", mock.Anything, mock.Anything)
+	})
+
+	t.Run("deltas are not applied without a signature key", func(t *testing.T) {
+		c, mc, current := setup(t)
+		c.verifier = nil
+
+		update := &distribution.Archive{
+			Description: db.Description{SchemaVersion: current.SchemaVersion, Built: db.Time{Time: current.Built.Add(24 * time.Hour)}},
+			Path:        "vulnerability-db.tar.zst",
+			Deltas:      []distribution.Delta{{From: current.Built, To: db.Time{Time: current.Built.Add(24 * time.Hour)}, Path: "delta.tar.zst"}},
+		}
+
+		mc.On("IsUpdateAvailable", mock.Anything).Return(update, nil)
+		mc.On("Download", "http://localhost/vulnerability-db.tar.zst", c.config.DBRootDir, mock.Anything).Return(fullArchive(t, c), nil)
+
+		updated, err := c.Update()
+		require.NoError(t, err)
+		require.True(t, updated)
+		mc.AssertExpectations(t)
+		mc.AssertNotCalled(t, "DownloadSigned", mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
+// writeTestPublicKey writes a PEM encoded public key for a new key pair, returning the path to it
+func writeTestPublicKey(t *testing.T) string {
+	public, _, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	der, err := x509.MarshalPKIXPublicKey(public)
+	require.NoError(t, err)
+	path := filepath.Join(t.TempDir(), "db.pub")
+	require.NoError(t, os.WriteFile(path, pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}), 0600))
+	return path
+}
+
 func TestCurator_IsUpdateCheckAllowed(t *testing.T) {
 
 	newCurator := func(t *testing.T) curator {
@@ -915,6 +1102,77 @@ func TestCurator_Import_URL_UsesDBRootDirForDownloadTempBaseAndCleansUp(t *testi
 	})
 }
 
+func TestCurator_Import_VerifiesSignature(t *testing.T) {
+	public, private, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	der, err := x509.MarshalPKIXPublicKey(public)
+	require.NoError(t, err)
+	publicKey := filepath.Join(t.TempDir(), "db.pub")
+	require.NoError(t, os.WriteFile(publicKey, pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}), 0600))
+
+	sourceDir := t.TempDir()
+	writeTestDB(t, afero.NewOsFs(), sourceDir)
+	dbFile := filepath.Join(sourceDir, db.VulnerabilityDBFileName)
+	contents, err := os.ReadFile(dbFile)
+	require.NoError(t, err)
+
+	writeSignature := func(t *testing.T, message []byte) {
+		signature := base64.StdEncoding.EncodeToString(ed25519.Sign(private, message))
+		require.NoError(t, os.WriteFile(dbFile+distribution.SignatureExtension, []byte(signature), 0600))
+	}
+
+	newSigningCurator := func(t *testing.T) curator {
+		cfg := testConfig()
+		cfg.DBRootDir = t.TempDir()
+		cfg.Signature = distribution.SignatureConfig{PublicKey: publicKey, Required: true}
+
+		ci, err := NewCurator(cfg, new(mockClient))
+		require.NoError(t, err)
+		c := ci.(curator)
+		c.hydrator = func(string) error { return nil }
+		return c
+	}
+
+	tests := []struct {
+		name    string
+		sign    func(t *testing.T)
+		wantErr error
+	}{
+		{
+			name:    "unsigned",
+			sign:    func(t *testing.T) { require.NoError(t, os.RemoveAll(dbFile+distribution.SignatureExtension)) },
+			wantErr: distribution.ErrSignatureRequired,
+		},
+		{
+			name:    "signature of other content",
+			sign:    func(t *testing.T) { writeSignature(t, []byte("something else")) },
+			wantErr: distribution.ErrInvalidSignature,
+		},
+		{
+			name: "signed",
+			sign: func(t *testing.T) { writeSignature(t, contents) },
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := newSigningCurator(t)
+			tt.sign(t)
+
+			err := c.Import(dbFile)
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+				// nothing is staged for an untrusted DB
+				entries, err := os.ReadDir(c.config.DBRootDir)
+				require.NoError(t, err)
+				assert.Empty(t, entries)
+				return
+			}
+			require.NoError(t, err)
+			assert.FileExists(t, c.config.DBFilePath())
+		})
+	}
+}
+
 func setupTestDB(t *testing.T, dbDir string) db.ReadWriter {
 	s, err := db.NewWriter(db.Config{
 		DBDirPath: dbDir,
@@ -0,0 +1,115 @@
+package osv
+
+import (
+	"strings"
+)
+
+// Advisory is a simple YAML advisory schema for describing vulnerabilities that are not (yet) published in any
+// upstream data source, for example:
+//
+//	id: ACME-2025-0001
+//	aliases: [CVE-2025-1234]
+//	summary: widget parser crashes on malformed input
+//	severity: high   # or a CVSS vector
+//	published: 2025-01-31T00:00:00Z
+//	references:
+//	  - https://acme.example.com/advisories/ACME-2025-0001
+//	packages:
+//	  - ecosystem: npm
+//	    name: acme-widget
+//	    introduced: 1.0.0
+//	    fixed: 1.4.2
+//
+// Multiple advisories may be given in one file as separate YAML documents.
+type Advisory struct {
+	ID          string            `yaml:"id"`
+	Aliases     []string          `yaml:"aliases"`
+	Summary     string            `yaml:"summary"`
+	Description string            `yaml:"description"`
+	Severity    string            `yaml:"severity"`
+	Published   string            `yaml:"published"`
+	Modified    string            `yaml:"modified"`
+	Withdrawn   string            `yaml:"withdrawn"`
+	References  []string          `yaml:"references"`
+	Packages    []AdvisoryPackage `yaml:"packages"`
+}
+
+type AdvisoryPackage struct {
+	// Ecosystem is an OSV ecosystem (e.g. "npm", "PyPI", "Maven", or "Debian:12")
+	Ecosystem string `yaml:"ecosystem"`
+	Name      string `yaml:"name"`
+	PURL      string `yaml:"purl"`
+
+	// Introduced is the first affected version (all versions before Fixed or LastAffected are affected when empty)
+	Introduced string `yaml:"introduced"`
+
+	// Fixed is the first version that is no longer affected
+	Fixed string `yaml:"fixed"`
+
+	// LastAffected is the last affected version, used when there is no fix
+	LastAffected string `yaml:"last-affected"`
+
+	// Versions are specific affected versions, used in place of a range
+	Versions []string `yaml:"versions"`
+}
+
+// Record converts the advisory to the equivalent OSV record
+func (a Advisory) Record() Record {
+	r := Record{
+		ID:        a.ID,
+		Aliases:   a.Aliases,
+		Summary:   a.Summary,
+		Details:   a.Description,
+		Published: a.Published,
+		Modified:  a.Modified,
+		Withdrawn: a.Withdrawn,
+	}
+
+	if a.Severity != "" {
+		if strings.HasPrefix(a.Severity, "CVSS:") {
+			r.Severity = []Severity{{Type: cvssType(a.Severity), Score: a.Severity}}
+		} else {
+			r.DatabaseSpecific = map[string]any{"severity": a.Severity}
+		}
+	}
+
+	for _, ref := range a.References {
+		r.References = append(r.References, Reference{Type: "WEB", URL: ref})
+	}
+
+	for _, p := range a.Packages {
+		affected := Affected{
+			Package:  Package{Ecosystem: p.Ecosystem, Name: p.Name, PURL: p.PURL},
+			Versions: p.Versions,
+		}
+
+		if p.Introduced != "" || p.Fixed != "" || p.LastAffected != "" {
+			introduced := p.Introduced
+			if introduced == "" {
+				introduced = "0"
+			}
+			events := []Event{{Introduced: introduced}}
+			switch {
+			case p.Fixed != "":
+				events = append(events, Event{Fixed: p.Fixed})
+			case p.LastAffected != "":
+				events = append(events, Event{LastAffected: p.LastAffected})
+			}
+			affected.Ranges = []Range{{Type: "ECOSYSTEM", Events: events}}
+		}
+
+		r.Affected = append(r.Affected, affected)
+	}
+
+	return r
+}
+
+func cvssType(vector string) string {
+	switch {
+	case strings.HasPrefix(vector, "CVSS:4"):
+		return "CVSS_V4"
+	case strings.HasPrefix(vector, "CVSS:3"):
+		return "CVSS_V3"
+	}
+	return "CVSS_V2"
+}
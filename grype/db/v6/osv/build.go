@@ -0,0 +1,107 @@
+package osv
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/spf13/afero"
+
+	v6 "github.com/anchore/grype/grype/db/v6"
+	"github.com/anchore/grype/internal/log"
+)
+
+type BuildConfig struct {
+	// Dirs are the directories containing the OSV JSON (and simple YAML) records to build the database from
+	Dirs []string
+
+	// Provider is the provider name that vulnerabilities are attributed to (and the prefix of their namespace)
+	Provider string
+
+	// Processor describes the tool that built the database (e.g. "grype@v0.90.0")
+	Processor string
+}
+
+// BuildResult describes a database built from OSV records
+type BuildResult struct {
+	Path            string
+	Provider        string
+	Vulnerabilities int
+	ImportMetadata  *v6.ImportMetadata
+}
+
+// Build writes a v6 database for the OSV records within the configured directories to the given directory, along with
+// import metadata. The database has the same form as a distributed database, so it can be imported with
+// "grype db import" (or shipped as part of an archive). An existing database within the directory is never replaced.
+func Build(dbDir string, cfg BuildConfig) (*BuildResult, error) {
+	providerName := cfg.Provider
+	if providerName == "" {
+		providerName = DefaultProvider
+	}
+
+	dbPath := filepath.Join(dbDir, v6.VulnerabilityDBFileName)
+	if _, err := os.Stat(dbPath); err == nil {
+		return nil, fmt.Errorf("a database already exists at %q", dbPath)
+	}
+
+	records, err := ReadDirs(cfg.Dirs...)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := os.MkdirAll(dbDir, 0o755); err != nil {
+		return nil, fmt.Errorf("unable to create database directory: %w", err)
+	}
+
+	now := time.Now().UTC()
+	count, err := writeDB(dbDir, v6.Provider{
+		ID:           providerName,
+		Version:      "1",
+		Processor:    cfg.Processor,
+		DateCaptured: &now,
+		InputDigest:  records.Digest,
+	}, records.Records)
+	if err != nil {
+		return nil, err
+	}
+
+	meta, err := v6.WriteImportMetadata(afero.NewOsFs(), dbDir, "osv")
+	if err != nil {
+		return nil, fmt.Errorf("unable to write import metadata: %w", err)
+	}
+
+	log.WithFields("path", dbPath, "provider", providerName, "vulnerabilities", count).Info("built database from OSV records")
+
+	return &BuildResult{
+		Path:            dbPath,
+		Provider:        providerName,
+		Vulnerabilities: count,
+		ImportMetadata:  meta,
+	}, nil
+}
+
+// writeDB writes a new database for the given records to the directory, returning the number of vulnerabilities
+// written. As with any new database, indexes are dropped when the database is closed.
+func writeDB(dir string, provider v6.Provider, records []Record) (int, error) {
+	w, err := v6.NewWriter(v6.Config{DBDirPath: dir})
+	if err != nil {
+		return 0, fmt.Errorf("unable to create database: %w", err)
+	}
+
+	count, err := Write(w, provider, records)
+	if err != nil {
+		log.CloseAndLogError(w, dir)
+		return 0, err
+	}
+
+	if err := w.SetDBMetadata(); err != nil {
+		log.CloseAndLogError(w, dir)
+		return 0, fmt.Errorf("unable to write database metadata: %w", err)
+	}
+
+	if err := w.Close(); err != nil {
+		return 0, fmt.Errorf("unable to write database: %w", err)
+	}
+	return count, nil
+}
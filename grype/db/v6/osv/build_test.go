@@ -0,0 +1,51 @@
+package osv
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	v6 "github.com/anchore/grype/grype/db/v6"
+	"github.com/anchore/grype/grype/db/v6/distribution"
+	"github.com/anchore/grype/grype/db/v6/installation"
+	"github.com/anchore/grype/grype/search"
+)
+
+func TestBuild(t *testing.T) {
+	dbDir := filepath.Join(t.TempDir(), "db")
+
+	result, err := Build(dbDir, BuildConfig{Dirs: []string{"testdata/advisories"}, Processor: "grype@test"})
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join(dbDir, v6.VulnerabilityDBFileName), result.Path)
+	assert.Equal(t, DefaultProvider, result.Provider)
+	assert.Equal(t, 3, result.Vulnerabilities)
+	assert.Regexp(t, "^xxh64:", result.ImportMetadata.Digest)
+
+	_, err = Build(dbDir, BuildConfig{Dirs: []string{"testdata/advisories"}})
+	require.ErrorContains(t, err, "a database already exists")
+
+	// the database can be imported as any other database
+	client, err := distribution.NewClient(distribution.DefaultConfig())
+	require.NoError(t, err)
+
+	c, err := installation.NewCurator(installation.Config{DBRootDir: t.TempDir(), ValidateChecksum: true}, client)
+	require.NoError(t, err)
+	require.NoError(t, c.Import(result.Path))
+	require.NoError(t, c.Status().Error)
+
+	reader, err := c.Reader()
+	require.NoError(t, err)
+	t.Cleanup(func() { require.NoError(t, reader.Close()) })
+
+	providers, err := reader.AllProviders()
+	require.NoError(t, err)
+	require.Len(t, providers, 1)
+	assert.Equal(t, "grype@test", providers[0].Processor)
+
+	vulns, err := v6.NewVulnerabilityProvider(reader).FindVulnerabilities(search.ByID("GHSA-test-0001"))
+	require.NoError(t, err)
+	require.NotEmpty(t, vulns)
+	assert.Equal(t, "local:language:javascript", vulns[0].Namespace)
+}
@@ -0,0 +1,153 @@
+package osv
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/hashicorp/go-multierror"
+
+	v6 "github.com/anchore/grype/grype/db/v6"
+	"github.com/anchore/grype/grype/pkg"
+	"github.com/anchore/grype/grype/vulnerability"
+	"github.com/anchore/grype/internal/log"
+)
+
+// DefaultProvider is the provider name that local advisories are attributed to when none is configured
+const DefaultProvider = "local"
+
+type OverlayConfig struct {
+	// Dirs are the directories containing OSV JSON and simple YAML advisories
+	Dirs []string
+
+	// Provider is the provider name that vulnerabilities from the advisories are attributed to (and the prefix of
+	// their namespace)
+	Provider string
+}
+
+// Overlay is a vulnerability provider for a set of local advisories, which are loaded into a temporary v6 database
+// so that vulnerabilities are matched (and reported) exactly as vulnerabilities from the main database are.
+type Overlay struct {
+	provider interface {
+		vulnerability.Provider
+		vulnerability.BatchProvider
+		vulnerability.Prefetcher
+		vulnerability.StoreMetadataProvider
+	}
+	reader v6.Reader
+	dir    string
+}
+
+var _ interface {
+	vulnerability.Provider
+	vulnerability.BatchProvider
+	vulnerability.Prefetcher
+	vulnerability.StoreMetadataProvider
+} = (*Overlay)(nil)
+
+// NewOverlay reads all advisories within the configured directories and loads them into a temporary database, which
+// is removed when the overlay is closed.
+func NewOverlay(cfg OverlayConfig) (*Overlay, error) {
+	providerName := cfg.Provider
+	if providerName == "" {
+		providerName = DefaultProvider
+	}
+
+	records, err := ReadDirs(cfg.Dirs...)
+	if err != nil {
+		return nil, err
+	}
+
+	dir, err := os.MkdirTemp("", "grype-advisories")
+	if err != nil {
+		return nil, fmt.Errorf("unable to create temp dir for local advisories: %w", err)
+	}
+
+	o, err := newOverlay(dir, providerName, records)
+	if err != nil {
+		if rmErr := os.RemoveAll(dir); rmErr != nil {
+			log.WithFields("error", rmErr, "dir", dir).Warn("unable to remove temp dir")
+		}
+		return nil, err
+	}
+	return o, nil
+}
+
+func newOverlay(dir, providerName string, records *Records) (*Overlay, error) {
+	now := time.Now().UTC()
+	count, err := writeDB(dir, v6.Provider{
+		ID:           providerName,
+		Version:      "1",
+		Processor:    "grype",
+		DateCaptured: &now,
+		InputDigest:  records.Digest,
+	}, records.Records)
+	if err != nil {
+		return nil, err
+	}
+
+	// closing the writer drops all indexes, which are needed for searching
+	if err := v6.Hydrater()(dir); err != nil {
+		return nil, fmt.Errorf("unable to index database for local advisories: %w", err)
+	}
+
+	reader, err := v6.NewReader(v6.Config{DBDirPath: dir})
+	if err != nil {
+		return nil, fmt.Errorf("unable to open database for local advisories: %w", err)
+	}
+
+	log.WithFields("provider", providerName, "vulnerabilities", count).Info("loaded local advisories")
+
+	return &Overlay{
+		provider: v6.NewVulnerabilityProvider(reader).(interface {
+			vulnerability.Provider
+			vulnerability.BatchProvider
+			vulnerability.Prefetcher
+			vulnerability.StoreMetadataProvider
+		}),
+		reader: reader,
+		dir:    dir,
+	}, nil
+}
+
+// Reader returns a reader for the database the advisories were loaded into (closed along with the overlay)
+func (o *Overlay) Reader() v6.Reader {
+	return o.reader
+}
+
+func (o *Overlay) PackageSearchNames(p pkg.Package) []string {
+	return o.provider.PackageSearchNames(p)
+}
+
+func (o *Overlay) FindVulnerabilities(criteria ...vulnerability.Criteria) ([]vulnerability.Vulnerability, error) {
+	return o.provider.FindVulnerabilities(criteria...)
+}
+
+func (o *Overlay) FindVulnerabilitiesBatch(queries ...[]vulnerability.Criteria) ([][]vulnerability.Vulnerability, error) {
+	return o.provider.FindVulnerabilitiesBatch(queries...)
+}
+
+func (o *Overlay) Prefetch(queries ...[]vulnerability.Criteria) (vulnerability.Provider, error) {
+	return o.provider.Prefetch(queries...)
+}
+
+// Deprecated: vulnerability.Vulnerability objects now have metadata included
+func (o *Overlay) VulnerabilityMetadata(ref vulnerability.Reference) (*vulnerability.Metadata, error) {
+	return o.provider.VulnerabilityMetadata(ref)
+}
+
+func (o *Overlay) DataProvenance() (map[string]vulnerability.DataProvenance, error) {
+	return o.provider.DataProvenance()
+}
+
+// Close closes the database the advisories were loaded into and removes it
+func (o *Overlay) Close() error {
+	var errs error
+	if err := o.provider.Close(); err != nil {
+		errs = multierror.Append(errs, err)
+	}
+	if err := os.RemoveAll(o.dir); err != nil {
+		errs = multierror.Append(errs, fmt.Errorf("unable to remove local advisory database: %w", err))
+	}
+	return errs
+}
@@ -0,0 +1,75 @@
+package osv
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/anchore/grype/grype/distro"
+	"github.com/anchore/grype/grype/search"
+	"github.com/anchore/grype/grype/version"
+	syftPkg "github.com/anchore/syft/syft/pkg"
+)
+
+func TestOverlay(t *testing.T) {
+	o, err := NewOverlay(OverlayConfig{Dirs: []string{"testdata/advisories"}, Provider: "acme"})
+	require.NoError(t, err)
+
+	vulns, err := o.FindVulnerabilities(
+		search.ByPackageName("acme-widget"),
+		search.ByEcosystem(syftPkg.JavaScript, syftPkg.NpmPkg),
+		search.ByVersion(*version.NewVersion("1.3.0", version.UnknownFormat)),
+	)
+	require.NoError(t, err)
+	require.Len(t, vulns, 1)
+	assert.Equal(t, "GHSA-test-0001", vulns[0].ID)
+	assert.Equal(t, "acme:language:javascript", vulns[0].Namespace)
+	assert.Equal(t, []string{"1.4.2", "2.0.1"}, vulns[0].Fix.Versions)
+
+	vulns, err = o.FindVulnerabilities(
+		search.ByPackageName("acme-widget"),
+		search.ByEcosystem(syftPkg.JavaScript, syftPkg.NpmPkg),
+		search.ByVersion(*version.NewVersion("1.4.2", version.UnknownFormat)),
+	)
+	require.NoError(t, err)
+	assert.Empty(t, vulns)
+
+	vulns, err = o.FindVulnerabilities(
+		search.ByPackageName("widget-server"),
+		search.ByDistro(*distro.New(distro.Alpine, "3.20.3", "")),
+		search.ByVersion(*version.NewVersion("2.0.1-r0", version.ApkFormat)),
+	)
+	require.NoError(t, err)
+	require.Len(t, vulns, 1)
+	assert.Equal(t, "ACME-2025-0002", vulns[0].ID)
+	assert.Equal(t, "acme:distro:alpine:3.20", vulns[0].Namespace)
+
+	meta, err := o.VulnerabilityMetadata(vulns[0].Reference)
+	require.NoError(t, err)
+	assert.Equal(t, "High", meta.Severity)
+
+	provenance, err := o.DataProvenance()
+	require.NoError(t, err)
+	require.Contains(t, provenance, "acme")
+	assert.Regexp(t, "^sha256:", provenance["acme"].InputDigest)
+
+	providers, err := o.Reader().AllProviders()
+	require.NoError(t, err)
+	require.Len(t, providers, 1)
+	assert.Equal(t, "grype", providers[0].Processor)
+
+	dir := o.dir
+	require.NoError(t, o.Close())
+	assert.NoDirExists(t, dir)
+}
+
+func TestOverlay_defaultProvider(t *testing.T) {
+	o, err := NewOverlay(OverlayConfig{Dirs: []string{t.TempDir()}})
+	require.NoError(t, err)
+	t.Cleanup(func() { require.NoError(t, o.Close()) })
+
+	provenance, err := o.DataProvenance()
+	require.NoError(t, err)
+	assert.Contains(t, provenance, DefaultProvider)
+}
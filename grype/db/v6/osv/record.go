@@ -0,0 +1,170 @@
+package osv
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Record is an OSV vulnerability record (see https://ossf.github.io/osv-schema/). Only the fields needed to describe
+// a vulnerability and the packages it affects are modeled.
+type Record struct {
+	SchemaVersion    string         `json:"schema_version,omitempty"`
+	ID               string         `json:"id"`
+	Modified         string         `json:"modified,omitempty"`
+	Published        string         `json:"published,omitempty"`
+	Withdrawn        string         `json:"withdrawn,omitempty"`
+	Aliases          []string       `json:"aliases,omitempty"`
+	Related          []string       `json:"related,omitempty"`
+	Summary          string         `json:"summary,omitempty"`
+	Details          string         `json:"details,omitempty"`
+	Severity         []Severity     `json:"severity,omitempty"`
+	Affected         []Affected     `json:"affected,omitempty"`
+	References       []Reference    `json:"references,omitempty"`
+	DatabaseSpecific map[string]any `json:"database_specific,omitempty"`
+}
+
+type Severity struct {
+	// Type is the scoring system (e.g. "CVSS_V3", "CVSS_V4", or "Ubuntu")
+	Type string `json:"type"`
+
+	// Score is the vector string for CVSS types or a qualitative severity for other types
+	Score string `json:"score"`
+}
+
+type Affected struct {
+	Package           Package        `json:"package"`
+	Severity          []Severity     `json:"severity,omitempty"`
+	Ranges            []Range        `json:"ranges,omitempty"`
+	Versions          []string       `json:"versions,omitempty"`
+	EcosystemSpecific map[string]any `json:"ecosystem_specific,omitempty"`
+	DatabaseSpecific  map[string]any `json:"database_specific,omitempty"`
+}
+
+type Package struct {
+	// Ecosystem is the OSV ecosystem (e.g. "npm", "PyPI", "Debian:12", or "Alpine:v3.20")
+	Ecosystem string `json:"ecosystem"`
+	Name      string `json:"name"`
+	PURL      string `json:"purl,omitempty"`
+}
+
+type Range struct {
+	// Type is one of "ECOSYSTEM", "SEMVER", or "GIT" (which is not supported for matching)
+	Type   string  `json:"type"`
+	Repo   string  `json:"repo,omitempty"`
+	Events []Event `json:"events"`
+}
+
+// Event is a single version event within a range, only one field is set per event
+type Event struct {
+	Introduced   string `json:"introduced,omitempty"`
+	Fixed        string `json:"fixed,omitempty"`
+	LastAffected string `json:"last_affected,omitempty"`
+	Limit        string `json:"limit,omitempty"`
+}
+
+type Reference struct {
+	Type string `json:"type"`
+	URL  string `json:"url"`
+}
+
+// Records are the records read from a set of files along with a digest of the file contents
+type Records struct {
+	Records []Record
+
+	// Digest is a self describing hash of all files the records were read from (e.g. "sha256:123...")
+	Digest string
+}
+
+// ReadDirs reads all OSV JSON (".json") and simple YAML advisory (".yaml" or ".yml") files found within the given
+// directories (recursively). Files are read in lexical order, so the digest is stable for the same set of files.
+func ReadDirs(dirs ...string) (*Records, error) {
+	var paths []string
+	for _, dir := range dirs {
+		err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+			if d.IsDir() {
+				return nil
+			}
+			switch strings.ToLower(filepath.Ext(path)) {
+			case ".json", ".yaml", ".yml":
+				paths = append(paths, path)
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, fmt.Errorf("unable to read advisories from %q: %w", dir, err)
+		}
+	}
+	sort.Strings(paths)
+
+	out := &Records{}
+	hasher := sha256.New()
+	for _, path := range paths {
+		contents, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("unable to read advisory: %w", err)
+		}
+		hasher.Write(contents)
+
+		records, err := parse(path, contents)
+		if err != nil {
+			return nil, fmt.Errorf("unable to parse advisory %q: %w", path, err)
+		}
+		out.Records = append(out.Records, records...)
+	}
+	out.Digest = fmt.Sprintf("sha256:%x", hasher.Sum(nil))
+
+	return out, nil
+}
+
+func parse(path string, contents []byte) ([]Record, error) {
+	var records []Record
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".json":
+		contents = bytes.TrimSpace(contents)
+		if bytes.HasPrefix(contents, []byte("[")) {
+			if err := json.Unmarshal(contents, &records); err != nil {
+				return nil, err
+			}
+			break
+		}
+		var r Record
+		if err := json.Unmarshal(contents, &r); err != nil {
+			return nil, err
+		}
+		records = append(records, r)
+	default:
+		dec := yaml.NewDecoder(bytes.NewReader(contents))
+		for {
+			var a Advisory
+			err := dec.Decode(&a)
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			if err != nil {
+				return nil, err
+			}
+			records = append(records, a.Record())
+		}
+	}
+
+	for _, r := range records {
+		if r.ID == "" {
+			return nil, fmt.Errorf("advisory has no ID")
+		}
+	}
+	return records, nil
+}
@@ -0,0 +1,50 @@
+package osv
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadDirs(t *testing.T) {
+	records, err := ReadDirs("testdata/advisories")
+	require.NoError(t, err)
+
+	var ids []string
+	for _, r := range records.Records {
+		ids = append(ids, r.ID)
+	}
+	assert.Equal(t, []string{"GHSA-test-0001", "ACME-2025-0001", "ACME-2025-0002"}, ids)
+	assert.Regexp(t, "^sha256:[0-9a-f]{64}$", records.Digest)
+
+	again, err := ReadDirs("testdata/advisories")
+	require.NoError(t, err)
+	assert.Equal(t, records.Digest, again.Digest)
+
+	yamlRecord := records.Records[1]
+	assert.Equal(t, []string{"CVE-2025-1234"}, yamlRecord.Aliases)
+	assert.Equal(t, "medium", yamlRecord.DatabaseSpecific["severity"])
+	assert.Equal(t, []Reference{{Type: "WEB", URL: "https://acme.example.com/advisories/ACME-2025-0001"}}, yamlRecord.References)
+	assert.Equal(t, []Affected{
+		{
+			Package: Package{Ecosystem: "PyPI", Name: "Acme_Parser"},
+			Ranges:  []Range{{Type: "ECOSYSTEM", Events: []Event{{Introduced: "1.0.0"}, {Fixed: "1.2.0"}}}},
+		},
+	}, yamlRecord.Affected)
+
+	assert.Equal(t, []Severity{{Type: "CVSS_V3", Score: "CVSS:3.1/AV:N/AC:L/PR:N/UI:N/S:U/C:H/I:N/A:N"}}, records.Records[2].Severity)
+}
+
+func TestReadDirs_invalid(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "missing-id.json"), []byte(`{"summary": "no id"}`), 0600))
+
+	_, err := ReadDirs(dir)
+	require.ErrorContains(t, err, "advisory has no ID")
+
+	_, err = ReadDirs(filepath.Join(dir, "does-not-exist"))
+	require.Error(t, err)
+}
@@ -0,0 +1,388 @@
+package osv
+
+import (
+	"fmt"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/scylladb/go-set/strset"
+
+	v6 "github.com/anchore/grype/grype/db/v6"
+	"github.com/anchore/grype/grype/db/v6/name"
+	"github.com/anchore/grype/internal/log"
+	syftPkg "github.com/anchore/syft/syft/pkg"
+)
+
+// Entry is a vulnerability and the packages it affects, as they are written to a v6 database
+type Entry struct {
+	Vulnerability    *v6.VulnerabilityHandle
+	AffectedPackages []*v6.AffectedPackageHandle
+}
+
+// Write transforms the given records and adds them to the database as data from the given provider, returning the
+// number of vulnerabilities written.
+func Write(w v6.Writer, provider v6.Provider, records []Record) (int, error) {
+	if len(records) == 0 {
+		// the provider is otherwise written along with the first vulnerability
+		if err := w.AddProvider(provider); err != nil {
+			return 0, fmt.Errorf("unable to add provider %q: %w", provider.ID, err)
+		}
+		return 0, nil
+	}
+
+	count := 0
+	for _, r := range records {
+		entry, err := Transform(&provider, r)
+		if err != nil {
+			return count, fmt.Errorf("unable to transform advisory %q: %w", r.ID, err)
+		}
+
+		if err := w.AddVulnerabilities(entry.Vulnerability); err != nil {
+			return count, fmt.Errorf("unable to write vulnerability %q: %w", r.ID, err)
+		}
+
+		if len(entry.AffectedPackages) > 0 {
+			if err := w.AddAffectedPackages(entry.AffectedPackages...); err != nil {
+				return count, fmt.Errorf("unable to write affected packages for %q: %w", r.ID, err)
+			}
+		}
+		count++
+	}
+	return count, nil
+}
+
+// Transform converts an OSV record to the v6 vulnerability and affected package handles for the given provider.
+// Affected packages from unsupported ecosystems and "GIT" ranges (which cannot be matched by version) are skipped.
+func Transform(provider *v6.Provider, r Record) (*Entry, error) {
+	published, err := parseTime(r.Published)
+	if err != nil {
+		return nil, fmt.Errorf("invalid published date: %w", err)
+	}
+	modified, err := parseTime(r.Modified)
+	if err != nil {
+		return nil, fmt.Errorf("invalid modified date: %w", err)
+	}
+	withdrawn, err := parseTime(r.Withdrawn)
+	if err != nil {
+		return nil, fmt.Errorf("invalid withdrawn date: %w", err)
+	}
+
+	status := v6.VulnerabilityActive
+	if withdrawn != nil {
+		status = v6.VulnerabilityRejected
+	}
+
+	description := r.Summary
+	if description == "" {
+		description = r.Details
+	}
+
+	entry := &Entry{
+		Vulnerability: &v6.VulnerabilityHandle{
+			Name:          r.ID,
+			Status:        status,
+			PublishedDate: published,
+			ModifiedDate:  modified,
+			WithdrawnDate: withdrawn,
+			ProviderID:    provider.ID,
+			Provider:      provider,
+			BlobValue: &v6.VulnerabilityBlob{
+				ID:          r.ID,
+				Description: description,
+				References:  references(r),
+				Aliases:     r.Aliases,
+				Severities:  severities(r),
+			},
+		},
+	}
+
+	cves := cveAliases(r)
+	for _, a := range r.Affected {
+		handle := affectedPackage(r.ID, a, cves)
+		if handle == nil {
+			continue
+		}
+		handle.Vulnerability = entry.Vulnerability
+		entry.AffectedPackages = append(entry.AffectedPackages, handle)
+	}
+
+	return entry, nil
+}
+
+func affectedPackage(id string, a Affected, cves []string) *v6.AffectedPackageHandle {
+	eco := parseEcosystem(a.Package)
+	if eco == nil {
+		log.WithFields("id", id, "ecosystem", a.Package.Ecosystem, "package", a.Package.Name).Warn("skipping affected package from unsupported ecosystem")
+		return nil
+	}
+
+	ranges := affectedRanges(eco.versionType, a)
+	if ranges == nil {
+		log.WithFields("id", id, "ecosystem", a.Package.Ecosystem, "package", a.Package.Name).Debug("skipping affected package without version ranges")
+		return nil
+	}
+
+	return &v6.AffectedPackageHandle{
+		OperatingSystem: eco.os,
+		Package: &v6.Package{
+			Ecosystem: string(eco.pkgType),
+			Name:      name.Normalize(a.Package.Name, eco.pkgType),
+		},
+		BlobValue: &v6.AffectedPackageBlob{
+			CVEs:   cves,
+			Ranges: ranges,
+		},
+	}
+}
+
+// affectedRanges converts the ECOSYSTEM and SEMVER ranges of an affected package to v6 ranges (in the version format
+// of the ecosystem), each with a fix state. Explicitly listed versions are only considered when there are no such ranges. A nil result indicates that
+// nothing can be matched, while an empty constraint indicates that all versions are affected.
+func affectedRanges(versionType string, a Affected) []v6.AffectedRange {
+	var out []v6.AffectedRange
+	for _, r := range a.Ranges {
+		switch strings.ToUpper(r.Type) {
+		case "ECOSYSTEM", "SEMVER":
+			// the version format is always that of the ecosystem, since it must match the format of package versions
+			out = append(out, rangeConstraints(versionType, r.Events)...)
+		}
+	}
+
+	if len(out) > 0 {
+		return out
+	}
+
+	for _, v := range a.Versions {
+		out = append(out, v6.AffectedRange{
+			Version: v6.AffectedVersion{Type: versionType, Constraint: "= " + v},
+			Fix:     &v6.Fix{State: v6.NotFixedStatus},
+		})
+	}
+	return out
+}
+
+// rangeConstraints converts the events of a range to constraints (one per introduced event)
+func rangeConstraints(versionType string, events []Event) []v6.AffectedRange {
+	var out []v6.AffectedRange
+	var lower string
+	open := false
+
+	closeRange := func(upper string, fix *v6.Fix) {
+		var parts []string
+		if lower != "" && lower != "0" {
+			parts = append(parts, ">= "+lower)
+		}
+		if upper != "" {
+			parts = append(parts, upper)
+		}
+		out = append(out, v6.AffectedRange{
+			Version: v6.AffectedVersion{Type: versionType, Constraint: strings.Join(parts, ", ")},
+			Fix:     fix,
+		})
+		lower = ""
+		open = false
+	}
+
+	for _, e := range events {
+		switch {
+		case e.Introduced != "":
+			if open {
+				// a new range starts without the previous range being closed, treat the previous range as unbounded
+				closeRange("", &v6.Fix{State: v6.NotFixedStatus})
+			}
+			lower = e.Introduced
+			open = true
+		case e.Fixed != "" && open:
+			closeRange("< "+e.Fixed, &v6.Fix{Version: e.Fixed, State: v6.FixedStatus})
+		case e.LastAffected != "" && open:
+			closeRange("<= "+e.LastAffected, nil)
+		case e.Limit != "" && open:
+			closeRange("< "+e.Limit, nil)
+		}
+	}
+
+	if open {
+		closeRange("", &v6.Fix{State: v6.NotFixedStatus})
+	}
+
+	return out
+}
+
+type ecosystem struct {
+	pkgType     syftPkg.Type
+	versionType string
+	os          *v6.OperatingSystem
+}
+
+// languageEcosystems maps OSV language ecosystems to the package type and the version format of packages of that type
+// (see version.FormatFromPkg), where ecosystems without a specific format are compared with fuzzy matching
+var languageEcosystems = map[string]ecosystem{
+	"npm":            {pkgType: syftPkg.NpmPkg},
+	"pypi":           {pkgType: syftPkg.PythonPkg, versionType: "python"},
+	"go":             {pkgType: syftPkg.GoModulePkg, versionType: "go"},
+	"maven":          {pkgType: syftPkg.JavaPkg, versionType: "maven"},
+	"rubygems":       {pkgType: syftPkg.GemPkg, versionType: "gem"},
+	"crates.io":      {pkgType: syftPkg.RustPkg},
+	"nuget":          {pkgType: syftPkg.DotnetPkg},
+	"packagist":      {pkgType: syftPkg.PhpComposerPkg},
+	"pub":            {pkgType: syftPkg.DartPubPkg},
+	"hex":            {pkgType: syftPkg.HexPkg},
+	"hackage":        {pkgType: syftPkg.HackagePkg},
+	"swifturl":       {pkgType: syftPkg.SwiftPkg},
+	"cran":           {pkgType: syftPkg.Rpkg},
+	"github actions": {pkgType: syftPkg.GithubActionPkg},
+	"bitnami":        {pkgType: syftPkg.BitnamiPkg, versionType: "bitnami"},
+}
+
+type osEcosystem struct {
+	name        string
+	pkgType     syftPkg.Type
+	versionType string
+
+	// majorOnly indicates that only the major version of the release is meaningful for matching
+	majorOnly bool
+}
+
+// osEcosystems maps OSV operating system ecosystems (the portion before the first ":") to the distro they describe
+var osEcosystems = map[string]osEcosystem{
+	"debian":     {name: "debian", pkgType: syftPkg.DebPkg, versionType: "dpkg"},
+	"ubuntu":     {name: "ubuntu", pkgType: syftPkg.DebPkg, versionType: "dpkg"},
+	"alpine":     {name: "alpine", pkgType: syftPkg.ApkPkg, versionType: "apk"},
+	"wolfi":      {name: "wolfi", pkgType: syftPkg.ApkPkg, versionType: "apk"},
+	"chainguard": {name: "chainguard", pkgType: syftPkg.ApkPkg, versionType: "apk"},
+	// rocky and alma linux are matched as rhel (see the OS specifier overrides of the v6 store)
+	"red hat":     {name: "rhel", pkgType: syftPkg.RpmPkg, versionType: "rpm", majorOnly: true},
+	"rocky linux": {name: "rhel", pkgType: syftPkg.RpmPkg, versionType: "rpm", majorOnly: true},
+	"almalinux":   {name: "rhel", pkgType: syftPkg.RpmPkg, versionType: "rpm", majorOnly: true},
+}
+
+// parseEcosystem resolves the OSV ecosystem of a package (falling back to the package URL type), returning nil for
+// unsupported ecosystems
+func parseEcosystem(p Package) *ecosystem {
+	if e, ok := languageEcosystems[strings.ToLower(p.Ecosystem)]; ok {
+		return &e
+	}
+
+	fields := strings.Split(p.Ecosystem, ":")
+	if e, ok := osEcosystems[strings.ToLower(fields[0])]; ok {
+		return &ecosystem{
+			pkgType:     e.pkgType,
+			versionType: e.versionType,
+			os:          operatingSystem(e, fields[1:]),
+		}
+	}
+
+	if p.PURL != "" {
+		if t := syftPkg.TypeFromPURL(p.PURL); t != syftPkg.UnknownPkg {
+			return &ecosystem{pkgType: t}
+		}
+	}
+
+	return nil
+}
+
+// operatingSystem describes the release given by the remaining fields of an OSV ecosystem (e.g. ["12"] for
+// "Debian:12", ["v3.20"] for "Alpine:v3.20", or ["enterprise_linux", "9", "", "appstream"] for Red Hat). The first
+// field that looks like a version is used, and rolling distros (without any version) are described by name alone.
+func operatingSystem(e osEcosystem, fields []string) *v6.OperatingSystem {
+	o := &v6.OperatingSystem{Name: e.name}
+	for _, f := range fields {
+		f = strings.TrimPrefix(strings.ToLower(f), "v")
+		if f == "" || !unicode.IsDigit(rune(f[0])) {
+			continue
+		}
+		parts := strings.Split(f, ".")
+		o.MajorVersion = parts[0]
+		if len(parts) > 1 && !e.majorOnly {
+			o.MinorVersion = parts[1]
+		}
+		break
+	}
+	return o
+}
+
+// severities returns the qualitative severity (when given) followed by all CVSS vectors of the record
+func severities(r Record) []v6.Severity {
+	var out []v6.Severity
+	add := func(scheme v6.SeverityScheme, value any) {
+		out = append(out, v6.Severity{Scheme: scheme, Value: value, Rank: len(out) + 1})
+	}
+
+	if sev, ok := r.DatabaseSpecific["severity"].(string); ok && sev != "" {
+		add(v6.SeveritySchemeCHMLN, strings.ToLower(sev))
+	}
+
+	all := append([]Severity{}, r.Severity...)
+	for _, a := range r.Affected {
+		all = append(all, a.Severity...)
+	}
+
+	seen := strset.New()
+	for _, s := range all {
+		if s.Score == "" || seen.Has(s.Score) {
+			continue
+		}
+		seen.Add(s.Score)
+
+		if !strings.HasPrefix(strings.ToUpper(s.Type), "CVSS") {
+			add(v6.SeveritySchemeCHMLN, strings.ToLower(s.Score))
+			continue
+		}
+		add(v6.SeveritySchemeCVSS, v6.CVSSSeverity{Vector: s.Score, Version: cvssVersion(s)})
+	}
+	return out
+}
+
+func cvssVersion(s Severity) string {
+	if strings.HasPrefix(s.Score, "CVSS:") {
+		if i := strings.Index(s.Score, "/"); i > 0 {
+			return s.Score[len("CVSS:"):i]
+		}
+	}
+	switch strings.ToUpper(s.Type) {
+	case "CVSS_V4":
+		return "4.0"
+	case "CVSS_V3":
+		return "3.1"
+	}
+	return "2.0"
+}
+
+func references(r Record) []v6.Reference {
+	var out []v6.Reference
+	for _, ref := range r.References {
+		if ref.URL == "" {
+			continue
+		}
+		var tags []string
+		if ref.Type != "" {
+			tags = []string{strings.ToLower(ref.Type)}
+		}
+		out = append(out, v6.Reference{URL: ref.URL, Tags: tags})
+	}
+	return out
+}
+
+func cveAliases(r Record) []string {
+	var out []string
+	for _, id := range append([]string{r.ID}, r.Aliases...) {
+		if strings.HasPrefix(strings.ToUpper(id), "CVE-") {
+			out = append(out, id)
+		}
+	}
+	return out
+}
+
+func parseTime(s string) (*time.Time, error) {
+	if s == "" {
+		return nil, nil
+	}
+	for _, layout := range []string{time.RFC3339Nano, time.DateOnly} {
+		if t, err := time.Parse(layout, s); err == nil {
+			t = t.UTC()
+			return &t, nil
+		}
+	}
+	return nil, fmt.Errorf("unable to parse time %q", s)
+}
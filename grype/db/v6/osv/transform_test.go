@@ -0,0 +1,154 @@
+package osv
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	v6 "github.com/anchore/grype/grype/db/v6"
+)
+
+func TestRangeConstraints(t *testing.T) {
+	tests := []struct {
+		name   string
+		events []Event
+		want   []v6.AffectedRange
+	}{
+		{
+			name:   "introduced and fixed",
+			events: []Event{{Introduced: "1.0.0"}, {Fixed: "1.2.0"}},
+			want: []v6.AffectedRange{
+				{Version: v6.AffectedVersion{Type: "semver", Constraint: ">= 1.0.0, < 1.2.0"}, Fix: &v6.Fix{Version: "1.2.0", State: v6.FixedStatus}},
+			},
+		},
+		{
+			name:   "introduced at zero is unbounded",
+			events: []Event{{Introduced: "0"}, {Fixed: "1.2.0"}, {Introduced: "2.0.0"}},
+			want: []v6.AffectedRange{
+				{Version: v6.AffectedVersion{Type: "semver", Constraint: "< 1.2.0"}, Fix: &v6.Fix{Version: "1.2.0", State: v6.FixedStatus}},
+				{Version: v6.AffectedVersion{Type: "semver", Constraint: ">= 2.0.0"}, Fix: &v6.Fix{State: v6.NotFixedStatus}},
+			},
+		},
+		{
+			name:   "last affected and limit",
+			events: []Event{{Introduced: "1.0.0"}, {LastAffected: "1.1.0"}, {Introduced: "3.0.0"}, {Limit: "4.0.0"}},
+			want: []v6.AffectedRange{
+				{Version: v6.AffectedVersion{Type: "semver", Constraint: ">= 1.0.0, <= 1.1.0"}},
+				{Version: v6.AffectedVersion{Type: "semver", Constraint: ">= 3.0.0, < 4.0.0"}},
+			},
+		},
+		{
+			name:   "all versions",
+			events: []Event{{Introduced: "0"}},
+			want: []v6.AffectedRange{
+				{Version: v6.AffectedVersion{Type: "semver"}, Fix: &v6.Fix{State: v6.NotFixedStatus}},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, rangeConstraints("semver", tt.events))
+		})
+	}
+}
+
+func TestParseEcosystem(t *testing.T) {
+	tests := []struct {
+		pkg  Package
+		want *ecosystem
+	}{
+		{
+			pkg:  Package{Ecosystem: "PyPI"},
+			want: &ecosystem{pkgType: "python", versionType: "python"},
+		},
+		{
+			pkg:  Package{Ecosystem: "Debian:12"},
+			want: &ecosystem{pkgType: "deb", versionType: "dpkg", os: &v6.OperatingSystem{Name: "debian", MajorVersion: "12"}},
+		},
+		{
+			pkg:  Package{Ecosystem: "Alpine:v3.20"},
+			want: &ecosystem{pkgType: "apk", versionType: "apk", os: &v6.OperatingSystem{Name: "alpine", MajorVersion: "3", MinorVersion: "20"}},
+		},
+		{
+			pkg:  Package{Ecosystem: "Red Hat:enterprise_linux:9.2::appstream"},
+			want: &ecosystem{pkgType: "rpm", versionType: "rpm", os: &v6.OperatingSystem{Name: "rhel", MajorVersion: "9"}},
+		},
+		{
+			pkg:  Package{Ecosystem: "Wolfi"},
+			want: &ecosystem{pkgType: "apk", versionType: "apk", os: &v6.OperatingSystem{Name: "wolfi"}},
+		},
+		{
+			pkg:  Package{Ecosystem: "Something", PURL: "pkg:cargo/widget@1.0.0"},
+			want: &ecosystem{pkgType: "rust-crate"},
+		},
+		{
+			pkg: Package{Ecosystem: "Something"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.pkg.Ecosystem, func(t *testing.T) {
+			assert.Equal(t, tt.want, parseEcosystem(tt.pkg))
+		})
+	}
+}
+
+func TestTransform(t *testing.T) {
+	records, err := ReadDirs("testdata/advisories")
+	require.NoError(t, err)
+
+	provider := &v6.Provider{ID: "local"}
+	entry, err := Transform(provider, records.Records[0])
+	require.NoError(t, err)
+
+	vuln := entry.Vulnerability
+	assert.Equal(t, "GHSA-test-0001", vuln.Name)
+	assert.Equal(t, v6.VulnerabilityActive, vuln.Status)
+	assert.Equal(t, "local", vuln.ProviderID)
+	assert.Equal(t, "2025-01-31T00:00:00Z", vuln.PublishedDate.Format("2006-01-02T15:04:05Z07:00"))
+	assert.Equal(t, "prototype pollution in acme-widget", vuln.BlobValue.Description)
+	assert.Equal(t, []string{"CVE-2025-0001"}, vuln.BlobValue.Aliases)
+	assert.Equal(t, []v6.Reference{
+		{URL: "https://github.com/advisories/GHSA-test-0001", Tags: []string{"advisory"}},
+		{URL: "https://acme.example.com/widget", Tags: []string{"web"}},
+	}, vuln.BlobValue.References)
+	assert.Equal(t, []v6.Severity{
+		{Scheme: v6.SeveritySchemeCHMLN, Value: "high", Rank: 1},
+		{Scheme: v6.SeveritySchemeCVSS, Value: v6.CVSSSeverity{Vector: "CVSS:3.1/AV:N/AC:L/PR:N/UI:N/S:U/C:H/I:H/A:H", Version: "3.1"}, Rank: 2},
+	}, vuln.BlobValue.Severities)
+
+	// the GIT range and the package from an unknown ecosystem are dropped
+	require.Len(t, entry.AffectedPackages, 2)
+
+	npm := entry.AffectedPackages[0]
+	assert.Equal(t, &v6.Package{Ecosystem: "npm", Name: "acme-widget"}, npm.Package)
+	assert.Nil(t, npm.OperatingSystem)
+	assert.Equal(t, []string{"CVE-2025-0001"}, npm.BlobValue.CVEs)
+	assert.Len(t, npm.BlobValue.Ranges, 2)
+
+	deb := entry.AffectedPackages[1]
+	assert.Equal(t, &v6.OperatingSystem{Name: "debian", MajorVersion: "12"}, deb.OperatingSystem)
+	assert.Equal(t, []v6.AffectedRange{{Version: v6.AffectedVersion{Type: "dpkg", Constraint: "<= 1.4.0-1"}}}, deb.BlobValue.Ranges)
+
+	// names are normalized for the ecosystem
+	entry, err = Transform(provider, records.Records[1])
+	require.NoError(t, err)
+	require.Len(t, entry.AffectedPackages, 1)
+	assert.Equal(t, "Acme-Parser", entry.AffectedPackages[0].Package.Name)
+
+	// explicit versions are used when there are no ranges
+	entry, err = Transform(provider, records.Records[2])
+	require.NoError(t, err)
+	require.Len(t, entry.AffectedPackages, 1)
+	assert.Equal(t, []v6.AffectedRange{
+		{Version: v6.AffectedVersion{Type: "apk", Constraint: "= 2.0.0-r0"}, Fix: &v6.Fix{State: v6.NotFixedStatus}},
+		{Version: v6.AffectedVersion{Type: "apk", Constraint: "= 2.0.1-r0"}, Fix: &v6.Fix{State: v6.NotFixedStatus}},
+	}, entry.AffectedPackages[0].BlobValue.Ranges)
+
+	withdrawn := records.Records[0]
+	withdrawn.Withdrawn = "2025-03-01T00:00:00Z"
+	entry, err = Transform(provider, withdrawn)
+	require.NoError(t, err)
+	assert.Equal(t, v6.VulnerabilityRejected, entry.Vulnerability.Status)
+	assert.NotNil(t, entry.Vulnerability.WithdrawnDate)
+}
@@ -0,0 +1,112 @@
+package v6
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// PackageSpecifierAlias is a user-provided alias for packages that are searched for, applied before the
+// PackageSpecifierOverride records found within the DB. This allows for matching packages that are known by a
+// different name or ecosystem than the upstream package (e.g. renamed packages on an internal mirror or a forked
+// scope) without changing the packages being matched.
+type PackageSpecifierAlias struct {
+	// Ecosystem is the ecosystem (or package type) that is aliased (matched without regard to case), or any ecosystem if empty.
+	Ecosystem string `yaml:"ecosystem" json:"ecosystem"`
+
+	// Name is the package name that is aliased (matched without regard to case), or any name if empty. A trailing
+	// "*" matches any name with the given prefix (e.g. "@acme/*").
+	Name string `yaml:"name" json:"name"`
+
+	// ReplacementEcosystem is the ecosystem to search for instead, or the original ecosystem if empty.
+	ReplacementEcosystem string `yaml:"replacement-ecosystem" json:"replacement-ecosystem"`
+
+	// ReplacementName is the package name to search for instead, or the original name if empty. A trailing "*" is
+	// replaced with the remainder of the name matched by a prefix Name (e.g. "@acme/*" to "*" maps "@acme/lodash"
+	// to "lodash").
+	ReplacementName string `yaml:"replacement-name" json:"replacement-name"`
+}
+
+// Validate returns an error for aliases that would match every package (or rename every package in an ecosystem), do
+// not replace anything, or have misplaced wildcards
+func (a PackageSpecifierAlias) Validate() error {
+	if a.Ecosystem == "" && a.Name == "" {
+		return fmt.Errorf("an ecosystem or name is required")
+	}
+	if a.ReplacementEcosystem == "" && a.ReplacementName == "" {
+		return fmt.Errorf("a replacement ecosystem or name is required")
+	}
+	if a.ReplacementName != "" && a.Name == "" {
+		return fmt.Errorf("replacement name %q requires a name", a.ReplacementName)
+	}
+	if strings.Contains(strings.TrimSuffix(a.Name, "*"), "*") {
+		return fmt.Errorf("name %q may only have a trailing wildcard", a.Name)
+	}
+	if strings.Contains(strings.TrimSuffix(a.ReplacementName, "*"), "*") {
+		return fmt.Errorf("replacement name %q may only have a trailing wildcard", a.ReplacementName)
+	}
+	if strings.HasSuffix(a.ReplacementName, "*") && !strings.HasSuffix(a.Name, "*") {
+		return fmt.Errorf("replacement name %q has a wildcard but name %q does not", a.ReplacementName, a.Name)
+	}
+	return nil
+}
+
+func (a PackageSpecifierAlias) matches(ecosystem, name string) bool {
+	if a.Ecosystem != "" && !strings.EqualFold(a.Ecosystem, ecosystem) {
+		return false
+	}
+	if a.Name == "" {
+		return true
+	}
+	if prefix, ok := strings.CutSuffix(a.Name, "*"); ok {
+		return len(name) >= len(prefix) && strings.EqualFold(name[:len(prefix)], prefix)
+	}
+	return strings.EqualFold(a.Name, name)
+}
+
+func (a PackageSpecifierAlias) apply(d *PackageSpecifier) {
+	if a.ReplacementName != "" {
+		if replacement, ok := strings.CutSuffix(a.ReplacementName, "*"); ok {
+			d.Name = replacement + d.Name[len(strings.TrimSuffix(a.Name, "*")):]
+		} else {
+			d.Name = a.ReplacementName
+		}
+	}
+	if a.ReplacementEcosystem != "" {
+		d.Ecosystem = a.ReplacementEcosystem
+	}
+}
+
+// ValidatePackageSpecifierAliases returns an error describing the first invalid alias
+func ValidatePackageSpecifierAliases(aliases []PackageSpecifierAlias) error {
+	for i, a := range aliases {
+		if err := a.Validate(); err != nil {
+			return fmt.Errorf("package alias %d: %w", i, err)
+		}
+	}
+	return nil
+}
+
+// ReadPackageSpecifierAliases reads a YAML file with a list of aliases (using the field names "ecosystem", "name",
+// "replacement-ecosystem" and "replacement-name"), returning an error if any alias is invalid.
+func ReadPackageSpecifierAliases(path string) ([]PackageSpecifierAlias, error) {
+	contents, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read package aliases: %w", err)
+	}
+
+	var aliases []PackageSpecifierAlias
+	if err := yaml.NewDecoder(bytes.NewReader(contents)).Decode(&aliases); err != nil && !errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("unable to parse package aliases %q: %w", path, err)
+	}
+
+	if err := ValidatePackageSpecifierAliases(aliases); err != nil {
+		return nil, fmt.Errorf("invalid package aliases %q: %w", path, err)
+	}
+	return aliases, nil
+}
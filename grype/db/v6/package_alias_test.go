@@ -0,0 +1,96 @@
+package v6
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPackageSpecifierAlias_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		alias   PackageSpecifierAlias
+		wantErr require.ErrorAssertionFunc
+	}{
+		{
+			name:  "name",
+			alias: PackageSpecifierAlias{Ecosystem: "python", Name: "acme-requests", ReplacementName: "requests"},
+		},
+		{
+			name:  "prefix",
+			alias: PackageSpecifierAlias{Ecosystem: "npm", Name: "@acme/*", ReplacementName: "*"},
+		},
+		{
+			name:  "ecosystem",
+			alias: PackageSpecifierAlias{Ecosystem: "acme-wheel", ReplacementEcosystem: "python"},
+		},
+		{
+			name:    "matches everything",
+			alias:   PackageSpecifierAlias{ReplacementEcosystem: "python"},
+			wantErr: require.Error,
+		},
+		{
+			name:    "no replacement",
+			alias:   PackageSpecifierAlias{Ecosystem: "acme-wheel"},
+			wantErr: require.Error,
+		},
+		{
+			name:    "replacement name without a name",
+			alias:   PackageSpecifierAlias{Ecosystem: "npm", ReplacementName: "lodash"},
+			wantErr: require.Error,
+		},
+		{
+			name:    "wildcard within name",
+			alias:   PackageSpecifierAlias{Name: "@acme/*-fork", ReplacementName: "*"},
+			wantErr: require.Error,
+		},
+		{
+			name:    "wildcard replacement without a prefix name",
+			alias:   PackageSpecifierAlias{Name: "acme-requests", ReplacementName: "*"},
+			wantErr: require.Error,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.wantErr == nil {
+				tt.wantErr = require.NoError
+			}
+			tt.wantErr(t, tt.alias.Validate())
+		})
+	}
+}
+
+func TestReadPackageSpecifierAliases(t *testing.T) {
+	dir := t.TempDir()
+	write := func(name, contents string) string {
+		path := filepath.Join(dir, name)
+		require.NoError(t, os.WriteFile(path, []byte(contents), 0o600))
+		return path
+	}
+
+	aliases, err := ReadPackageSpecifierAliases(write("aliases.yaml", `
+- ecosystem: npm
+  name: "@acme/*"
+  replacement-name: "*"
+- ecosystem: acme-wheel
+  replacement-ecosystem: python
+`))
+	require.NoError(t, err)
+	assert.Equal(t, []PackageSpecifierAlias{
+		{Ecosystem: "npm", Name: "@acme/*", ReplacementName: "*"},
+		{Ecosystem: "acme-wheel", ReplacementEcosystem: "python"},
+	}, aliases)
+
+	aliases, err = ReadPackageSpecifierAliases(write("empty.yaml", ""))
+	require.NoError(t, err)
+	assert.Empty(t, aliases)
+
+	_, err = ReadPackageSpecifierAliases(write("invalid.yaml", "- ecosystem: acme-wheel\n"))
+	require.ErrorContains(t, err, "package alias 0")
+
+	_, err = ReadPackageSpecifierAliases(filepath.Join(dir, "missing.yaml"))
+	require.Error(t, err)
+}
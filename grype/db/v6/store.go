@@ -89,7 +89,7 @@ func newStore(cfg Config, empty, writable bool) (*store, error) {
 		providerStore:               newProviderStore(db),
 		vulnerabilityStore:          newVulnerabilityStore(db, bs),
 		operatingSystemStore:        osStore,
-		affectedPackageStore:        newAffectedPackageStore(db, bs, osStore),
+		affectedPackageStore:        newAffectedPackageStore(db, bs, osStore, cfg.PackageAliases...),
 		affectedCPEStore:            newAffectedCPEStore(db, bs),
 		vulnerabilityDecoratorStore: newVulnerabilityDecoratorStore(db, bs, dbVersion),
 		blobStore:                   bs,
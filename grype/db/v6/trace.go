@@ -0,0 +1,368 @@
+package v6
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/anchore/grype/grype/db/v6/name"
+	"github.com/anchore/grype/grype/pkg"
+	"github.com/anchore/grype/grype/pkg/qualifier/platformcpe"
+	"github.com/anchore/grype/grype/pkg/qualifier/rpmmodularity"
+	"github.com/anchore/grype/grype/search"
+	"github.com/anchore/grype/grype/version"
+	syftPkg "github.com/anchore/syft/syft/pkg"
+)
+
+// TraceStage is a decision point when considering an affected package or CPE record for a package
+type TraceStage string
+
+const (
+	// StatusTraceStage is the check that the vulnerability is not withdrawn or rejected
+	StatusTraceStage TraceStage = "status"
+
+	// NameTraceStage is the resolution of the package names searched for (see name.Resolver)
+	NameTraceStage TraceStage = "name"
+
+	// EcosystemTraceStage is the selection of records within the ecosystem of the package (including package aliases)
+	EcosystemTraceStage TraceStage = "ecosystem"
+
+	// DistroTraceStage is the selection of records for the distro of the package
+	DistroTraceStage TraceStage = "distro"
+
+	// CPETraceStage is the comparison of the package CPEs with the CPE of the record
+	CPETraceStage TraceStage = "cpe"
+
+	// RangeTraceStage is the evaluation of the package version against a single affected range
+	RangeTraceStage TraceStage = "range"
+
+	// QualifierTraceStage is the evaluation of a package qualifier (platform CPE or RPM modularity)
+	QualifierTraceStage TraceStage = "qualifier"
+)
+
+// TraceDecision is the outcome of a single decision point for a record
+type TraceDecision struct {
+	Stage  TraceStage `json:"stage"`
+	Passed bool       `json:"passed"`
+	Detail string     `json:"detail"`
+}
+
+// AffectedTrace describes how an affected package or CPE record of a vulnerability was considered for a package
+type AffectedTrace struct {
+	Vulnerability string          `json:"vulnerability"`
+	Provider      string          `json:"provider"`
+	Namespace     string          `json:"namespace"`
+	Package       string          `json:"package,omitempty"`
+	Ecosystem     string          `json:"ecosystem,omitempty"`
+	CPE           string          `json:"cpe,omitempty"`
+	OS            string          `json:"os,omitempty"`
+	Selected      bool            `json:"selected"`
+	Decisions     []TraceDecision `json:"decisions"`
+}
+
+// TraceAffected evaluates every affected package and CPE record of the given vulnerability (or vulnerabilities aliased
+// by it) against the package, reporting each decision point that the vulnerability provider and matchers apply when
+// searching for matches. A record is selected when all decision points pass, with at least one affected range
+// (if any) containing the package version.
+func TraceAffected(reader Reader, p pkg.Package, vulnID string) ([]AffectedTrace, error) {
+	vulnSpecs := VulnerabilitySpecifiers{{Name: vulnID, IncludeAliases: true}}
+
+	packageHandles, err := reader.GetAffectedPackages(AnyPackageSpecified, &GetAffectedPackageOptions{
+		OSs:             OSSpecifiers{AnyOSSpecified},
+		Vulnerabilities: vulnSpecs,
+		PreloadBlob:     true,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("unable to fetch affected packages for %q: %w", vulnID, err)
+	}
+	if err = fillAffectedPackageHandles(reader, ptrs(packageHandles)); err != nil {
+		return nil, err
+	}
+
+	cpeHandles, err := reader.GetAffectedCPEs(nil, &GetAffectedCPEOptions{
+		Vulnerabilities: vulnSpecs,
+		PreloadBlob:     true,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("unable to fetch affected CPEs for %q: %w", vulnID, err)
+	}
+	if err = fillAffectedCPEHandles(reader, ptrs(cpeHandles)); err != nil {
+		return nil, err
+	}
+
+	t, err := newAffectedTracer(reader, p, vulnSpecs)
+	if err != nil {
+		return nil, err
+	}
+
+	var out []AffectedTrace
+	for _, h := range packageHandles {
+		out = append(out, t.tracePackage(h))
+	}
+	for _, h := range cpeHandles {
+		out = append(out, t.traceCPE(h))
+	}
+	return out, nil
+}
+
+// affectedTracer holds what was resolved for the package from the DB, which is shared by all records
+type affectedTracer struct {
+	pkg              pkg.Package
+	version          *version.Version
+	ecosystem        string
+	ecosystemHandles map[ID]struct{}
+	distro           *OSSpecifier
+	distroOSs        map[ID]OperatingSystem
+	distroErr        error
+}
+
+func newAffectedTracer(reader Reader, p pkg.Package, vulnSpecs VulnerabilitySpecifiers) (*affectedTracer, error) {
+	t := &affectedTracer{
+		pkg:     p,
+		version: version.NewVersionFromPkg(p),
+	}
+
+	// this mirrors how ecosystem criteria are searched for by the vulnerability provider
+	switch {
+	case p.Type != "" && p.Type != syftPkg.UnknownPkg:
+		t.ecosystem = string(p.Type)
+	case p.Language != "":
+		t.ecosystem = string(p.Language)
+	default:
+		t.ecosystem = string(p.Type)
+	}
+
+	// let the store resolve ecosystem aliases, so the trace selects the same records as a search would
+	handles, err := reader.GetAffectedPackages(&PackageSpecifier{Ecosystem: t.ecosystem}, &GetAffectedPackageOptions{
+		OSs:             OSSpecifiers{NoOSSpecified},
+		Vulnerabilities: vulnSpecs,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("unable to fetch affected packages within ecosystem %q: %w", t.ecosystem, err)
+	}
+	t.ecosystemHandles = make(map[ID]struct{})
+	for _, h := range handles {
+		t.ecosystemHandles[h.ID] = struct{}{}
+	}
+
+	if p.Distro != nil {
+		t.distro = &OSSpecifier{
+			Name:             p.Distro.Name(),
+			MajorVersion:     p.Distro.MajorVersion(),
+			MinorVersion:     p.Distro.MinorVersion(),
+			RemainingVersion: p.Distro.RemainingVersion(),
+			LabelVersion:     p.Distro.Codename,
+		}
+		oss, err := reader.GetOperatingSystems(*t.distro)
+		switch {
+		case errors.Is(err, ErrMissingOSIdentification):
+			t.distroErr = err
+		case err != nil:
+			return nil, fmt.Errorf("unable to resolve distro %s: %w", t.distro, err)
+		}
+		t.distroOSs = make(map[ID]OperatingSystem)
+		for _, o := range oss {
+			t.distroOSs[o.ID] = o
+		}
+	}
+
+	return t, nil
+}
+
+func (t affectedTracer) tracePackage(h AffectedPackageHandle) AffectedTrace {
+	out := AffectedTrace{
+		Vulnerability: h.vulnerability(),
+	}
+	if h.Vulnerability != nil {
+		out.Provider = h.Vulnerability.ProviderID
+		out.Namespace = MimicV5Namespace(h.Vulnerability, &h)
+	}
+	if h.Package != nil {
+		out.Package = h.Package.Name
+		out.Ecosystem = h.Package.Ecosystem
+	}
+	if h.OperatingSystem != nil {
+		out.OS = h.OperatingSystem.String()
+	}
+
+	if h.OperatingSystem != nil {
+		// distro packages (and their upstream source packages) are searched for by name within the distro only
+		out.Decisions = append(out.Decisions, t.distroDecision(h.OperatingSystem))
+		out.Decisions = append(out.Decisions, t.nameDecision(out.Package, t.distroNames()))
+	} else {
+		// withdrawn vulnerabilities are only dropped when searching by ecosystem or CPE
+		out.Decisions = append(out.Decisions, statusDecision(h.Vulnerability))
+		out.Decisions = append(out.Decisions, t.ecosystemDecision(h.ID, out.Ecosystem))
+		out.Decisions = append(out.Decisions, t.nameDecision(out.Package, name.PackageNames(t.pkg)))
+	}
+
+	out.Decisions = append(out.Decisions, t.rangeDecisions(h.BlobValue)...)
+	out.Decisions = append(out.Decisions, t.qualifierDecisions(h.BlobValue)...)
+	out.Selected = isSelected(out.Decisions)
+	return out
+}
+
+func (t affectedTracer) traceCPE(h AffectedCPEHandle) AffectedTrace {
+	out := AffectedTrace{
+		Vulnerability: h.vulnerability(),
+	}
+	if h.Vulnerability != nil {
+		out.Provider = h.Vulnerability.ProviderID
+		out.Namespace = MimicV5Namespace(h.Vulnerability, nil)
+	}
+	if h.CPE != nil {
+		out.CPE = h.CPE.String()
+	}
+
+	out.Decisions = append(out.Decisions, statusDecision(h.Vulnerability))
+	out.Decisions = append(out.Decisions, t.cpeDecision(h))
+	out.Decisions = append(out.Decisions, t.rangeDecisions(h.BlobValue)...)
+	out.Selected = isSelected(out.Decisions)
+	return out
+}
+
+func statusDecision(vuln *VulnerabilityHandle) TraceDecision {
+	// the matchers consider both the v5 and v6 terms for a vulnerability that should not be acted upon
+	if vuln != nil && (vuln.Status == VulnerabilityRejected || vuln.Status == "withdrawn") {
+		return TraceDecision{Stage: StatusTraceStage, Detail: fmt.Sprintf("vulnerability is %s", vuln.Status)}
+	}
+	return TraceDecision{Stage: StatusTraceStage, Passed: true, Detail: "vulnerability is not withdrawn or rejected"}
+}
+
+// distroNames are the names a distro package is searched for by: the package itself and any upstream (source) packages
+func (t affectedTracer) distroNames() []string {
+	names := []string{t.pkg.Name}
+	for _, u := range t.pkg.Upstreams {
+		names = append(names, u.Name)
+	}
+	return names
+}
+
+func (t affectedTracer) nameDecision(recordName string, searchNames []string) TraceDecision {
+	normalized := name.Normalize(recordName, t.pkg.Type)
+	for _, n := range searchNames {
+		if strings.EqualFold(name.Normalize(n, t.pkg.Type), normalized) {
+			return TraceDecision{Stage: NameTraceStage, Passed: true, Detail: fmt.Sprintf("record package %q matches searched name %q", recordName, n)}
+		}
+	}
+	return TraceDecision{Stage: NameTraceStage, Detail: fmt.Sprintf("record package %q does not match any searched name (%s)", recordName, strings.Join(searchNames, ", "))}
+}
+
+func (t affectedTracer) ecosystemDecision(handleID ID, recordEcosystem string) TraceDecision {
+	if _, ok := t.ecosystemHandles[handleID]; ok {
+		return TraceDecision{Stage: EcosystemTraceStage, Passed: true, Detail: fmt.Sprintf("record ecosystem %q is selected when searching within %q", recordEcosystem, t.ecosystem)}
+	}
+	return TraceDecision{Stage: EcosystemTraceStage, Detail: fmt.Sprintf("record ecosystem %q is not selected when searching within %q", recordEcosystem, t.ecosystem)}
+}
+
+func (t affectedTracer) distroDecision(recordOS *OperatingSystem) TraceDecision {
+	switch {
+	case t.distro == nil:
+		return TraceDecision{Stage: DistroTraceStage, Detail: fmt.Sprintf("record is for distro %s, but the package has no distro", recordOS)}
+	case t.distroErr != nil:
+		return TraceDecision{Stage: DistroTraceStage, Detail: fmt.Sprintf("package distro %s cannot be resolved: %v", t.distro, t.distroErr)}
+	case len(t.distroOSs) == 0:
+		return TraceDecision{Stage: DistroTraceStage, Detail: fmt.Sprintf("package distro %s is not present in the database", t.distro)}
+	}
+
+	if _, ok := t.distroOSs[recordOS.ID]; ok {
+		return TraceDecision{Stage: DistroTraceStage, Passed: true, Detail: fmt.Sprintf("package distro %s resolves to record distro %s", t.distro, recordOS)}
+	}
+
+	var resolved []string
+	for _, o := range t.distroOSs {
+		resolved = append(resolved, o.String())
+	}
+	return TraceDecision{Stage: DistroTraceStage, Detail: fmt.Sprintf("record is for distro %s, but package distro %s resolves to %s", recordOS, t.distro, strings.Join(resolved, ", "))}
+}
+
+func (t affectedTracer) cpeDecision(h AffectedCPEHandle) TraceDecision {
+	if len(t.pkg.CPEs) == 0 {
+		return TraceDecision{Stage: CPETraceStage, Detail: "package has no CPEs to search by"}
+	}
+	v, err := newVulnerabilityFromAffectedCPEHandle(h, nil)
+	if err != nil || v == nil {
+		return TraceDecision{Stage: CPETraceStage, Detail: fmt.Sprintf("record CPE cannot be read: %v", err)}
+	}
+	for _, c := range t.pkg.CPEs {
+		if matches, _, _ := search.ByCPE(c).MatchesVulnerability(*v); matches {
+			return TraceDecision{Stage: CPETraceStage, Passed: true, Detail: fmt.Sprintf("package CPE %s matches the record CPE", c.Attributes.BindToFmtString())}
+		}
+	}
+	return TraceDecision{Stage: CPETraceStage, Detail: fmt.Sprintf("none of the %d package CPEs match the record CPE", len(t.pkg.CPEs))}
+}
+
+func (t affectedTracer) rangeDecisions(b *AffectedPackageBlob) []TraceDecision {
+	if b == nil || len(b.Ranges) == 0 {
+		return []TraceDecision{{Stage: RangeTraceStage, Passed: true, Detail: "record has no ranges, so all versions are affected"}}
+	}
+
+	var out []TraceDecision
+	for _, r := range b.Ranges {
+		d := TraceDecision{Stage: RangeTraceStage}
+		constraint, err := version.GetConstraint(r.Version.Constraint, version.ParseFormat(r.Version.Type))
+		switch {
+		case err != nil || constraint == nil:
+			d.Detail = fmt.Sprintf("constraint %q (%s) cannot be parsed: %v", r.Version.Constraint, r.Version.Type, err)
+		default:
+			satisfied, err := constraint.Satisfied(t.version)
+			switch {
+			case err != nil:
+				d.Detail = fmt.Sprintf("version %s cannot be compared with %q (%s): %v", t.pkg.Version, r.Version.Constraint, r.Version.Type, err)
+			case satisfied:
+				d.Passed = true
+				d.Detail = fmt.Sprintf("version %s is within %q (%s)", t.pkg.Version, r.Version.Constraint, r.Version.Type)
+			default:
+				d.Detail = fmt.Sprintf("version %s is not within %q (%s)", t.pkg.Version, r.Version.Constraint, r.Version.Type)
+			}
+		}
+		if r.Fix != nil && r.Fix.Version != "" {
+			d.Detail += fmt.Sprintf(", fixed in %s", r.Fix.Version)
+		}
+		out = append(out, d)
+	}
+	return out
+}
+
+func (t affectedTracer) qualifierDecisions(b *AffectedPackageBlob) []TraceDecision {
+	if b == nil || b.Qualifiers == nil {
+		return nil
+	}
+
+	decide := func(what string, satisfied bool, err error) TraceDecision {
+		switch {
+		case err != nil:
+			return TraceDecision{Stage: QualifierTraceStage, Detail: fmt.Sprintf("%s cannot be evaluated: %v", what, err)}
+		case satisfied:
+			return TraceDecision{Stage: QualifierTraceStage, Passed: true, Detail: fmt.Sprintf("%s is satisfied", what)}
+		}
+		return TraceDecision{Stage: QualifierTraceStage, Detail: fmt.Sprintf("%s is not satisfied", what)}
+	}
+
+	var out []TraceDecision
+	for _, c := range b.Qualifiers.PlatformCPEs {
+		satisfied, err := platformcpe.New(c).Satisfied(t.pkg)
+		out = append(out, decide(fmt.Sprintf("platform CPE %s", c), satisfied, err))
+	}
+	if m := b.Qualifiers.RpmModularity; m != nil {
+		satisfied, err := rpmmodularity.New(*m).Satisfied(t.pkg)
+		out = append(out, decide(fmt.Sprintf("RPM modularity %q", *m), satisfied, err))
+	}
+	return out
+}
+
+// isSelected indicates if all decisions passed, where only one of the affected ranges needs to contain the version
+func isSelected(decisions []TraceDecision) bool {
+	var hasRange, inRange bool
+	for _, d := range decisions {
+		if d.Stage == RangeTraceStage {
+			hasRange = true
+			inRange = inRange || d.Passed
+			continue
+		}
+		if !d.Passed {
+			return false
+		}
+	}
+	return !hasRange || inRange
+}
@@ -0,0 +1,214 @@
+package v6
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/anchore/grype/grype/distro"
+	"github.com/anchore/grype/grype/pkg"
+	"github.com/anchore/grype/internal/log"
+	"github.com/anchore/syft/syft/cpe"
+	syftPkg "github.com/anchore/syft/syft/pkg"
+)
+
+func testTraceReader(t *testing.T) Reader {
+	t.Helper()
+	tmp := t.TempDir()
+	w, err := NewWriter(Config{DBDirPath: tmp})
+	require.NoError(t, err)
+
+	debianProvider := &Provider{ID: "debian"}
+	githubProvider := &Provider{ID: "github"}
+	nvdProvider := &Provider{ID: "nvd"}
+
+	newVuln := func(name string, prov *Provider, status VulnerabilityStatus) *VulnerabilityHandle {
+		return &VulnerabilityHandle{
+			Name:       name,
+			Status:     status,
+			ProviderID: prov.ID,
+			Provider:   prov,
+			BlobValue:  &VulnerabilityBlob{ID: name},
+		}
+	}
+
+	debianVuln := newVuln("CVE-2024-0001", debianProvider, VulnerabilityActive)
+	githubVuln := newVuln("GHSA-2222-2222-2222", githubProvider, VulnerabilityRejected)
+	githubVuln.BlobValue.Aliases = []string{"CVE-2024-0001"}
+	nvdVuln := newVuln("CVE-2024-0001", nvdProvider, VulnerabilityActive)
+	require.NoError(t, w.AddVulnerabilities(debianVuln, githubVuln, nvdVuln))
+
+	modularity := "nodejs:18"
+	require.NoError(t, w.AddAffectedPackages(
+		&AffectedPackageHandle{
+			Vulnerability:   debianVuln,
+			OperatingSystem: &OperatingSystem{Name: "debian", MajorVersion: "12", ReleaseID: "debian"},
+			Package:         &Package{Name: "openssl", Ecosystem: "deb"},
+			BlobValue: &AffectedPackageBlob{
+				Ranges: []AffectedRange{
+					{
+						Version: AffectedVersion{Type: "deb", Constraint: "< 3.0.11-1"},
+						Fix:     &Fix{Version: "3.0.11-1", State: FixedStatus},
+					},
+				},
+			},
+		},
+		&AffectedPackageHandle{
+			Vulnerability:   debianVuln,
+			OperatingSystem: &OperatingSystem{Name: "debian", MajorVersion: "11", ReleaseID: "debian"},
+			Package:         &Package{Name: "openssl", Ecosystem: "deb"},
+			BlobValue: &AffectedPackageBlob{
+				Ranges: []AffectedRange{{Version: AffectedVersion{Type: "deb", Constraint: "< 1.1.1w-0"}}},
+			},
+		},
+		&AffectedPackageHandle{
+			Vulnerability: githubVuln,
+			Package:       &Package{Name: "openssl", Ecosystem: "npm"},
+			BlobValue: &AffectedPackageBlob{
+				Qualifiers: &AffectedPackageQualifiers{RpmModularity: &modularity},
+				Ranges: []AffectedRange{
+					{Version: AffectedVersion{Constraint: ">= 2.0.0, < 2.1.0"}},
+					{Version: AffectedVersion{Constraint: ">= 3.0.0, < 3.0.12"}},
+				},
+			},
+		},
+	))
+
+	require.NoError(t, w.AddAffectedCPEs(&AffectedCPEHandle{
+		Vulnerability: nvdVuln,
+		CPE:           &Cpe{Part: "a", Vendor: "openssl", Product: "openssl"},
+		BlobValue: &AffectedPackageBlob{
+			Ranges: []AffectedRange{{Version: AffectedVersion{Constraint: "< 3.0.12"}}},
+		},
+	}))
+
+	require.NoError(t, w.Close())
+
+	rdr := setupReadOnlyTestStore(t, tmp)
+	t.Cleanup(func() { log.CloseAndLogError(rdr, tmp) })
+	return rdr
+}
+
+func TestTraceAffected(t *testing.T) {
+	rdr := testTraceReader(t)
+
+	tests := []struct {
+		name     string
+		pkg      pkg.Package
+		expected map[string][]TraceDecision // by namespace and OS of each record
+	}{
+		{
+			name: "distro package",
+			pkg: pkg.Package{
+				Name:    "openssl",
+				Version: "3.0.10-1",
+				Type:    syftPkg.DebPkg,
+				Distro:  distro.New(distro.Debian, "12", ""),
+				CPEs:    []cpe.CPE{cpe.Must("cpe:2.3:a:openssl:openssl:3.0.10-1:*:*:*:*:*:*:*", cpe.GeneratedSource)},
+			},
+			expected: map[string][]TraceDecision{
+				"debian:distro:debian:12 debian@12": {
+					{Stage: DistroTraceStage, Passed: true, Detail: "package distro debian@12 resolves to record distro debian@12"},
+					{Stage: NameTraceStage, Passed: true, Detail: `record package "openssl" matches searched name "openssl"`},
+					{Stage: RangeTraceStage, Passed: true, Detail: `version 3.0.10-1 is within "< 3.0.11-1" (deb), fixed in 3.0.11-1`},
+				},
+				"debian:distro:debian:11 debian@11": {
+					{Stage: DistroTraceStage, Detail: "record is for distro debian@11, but package distro debian@12 resolves to debian@12"},
+					{Stage: NameTraceStage, Passed: true, Detail: `record package "openssl" matches searched name "openssl"`},
+					{Stage: RangeTraceStage, Detail: `version 3.0.10-1 is not within "< 1.1.1w-0" (deb)`},
+				},
+				"github:language:javascript ": {
+					{Stage: StatusTraceStage, Detail: "vulnerability is rejected"},
+					{Stage: EcosystemTraceStage, Detail: `record ecosystem "npm" is not selected when searching within "deb"`},
+					{Stage: NameTraceStage, Passed: true, Detail: `record package "openssl" matches searched name "openssl"`},
+					{Stage: RangeTraceStage, Detail: `version 3.0.10-1 is not within ">= 2.0.0, < 2.1.0" ()`},
+					{Stage: RangeTraceStage, Passed: true, Detail: `version 3.0.10-1 is within ">= 3.0.0, < 3.0.12" ()`},
+					{Stage: QualifierTraceStage, Passed: true, Detail: `RPM modularity "nodejs:18" is satisfied`},
+				},
+				"nvd:cpe ": {
+					{Stage: StatusTraceStage, Passed: true, Detail: "vulnerability is not withdrawn or rejected"},
+					{Stage: CPETraceStage, Passed: true, Detail: "package CPE cpe:2.3:a:openssl:openssl:3.0.10-1:*:*:*:*:*:*:* matches the record CPE"},
+					{Stage: RangeTraceStage, Passed: true, Detail: `version 3.0.10-1 is within "< 3.0.12" ()`},
+				},
+			},
+		},
+		{
+			name: "language package without CPEs",
+			pkg: pkg.Package{
+				Name:     "openssl",
+				Version:  "3.0.5",
+				Type:     syftPkg.NpmPkg,
+				Language: syftPkg.JavaScript,
+			},
+			expected: map[string][]TraceDecision{
+				"debian:distro:debian:12 debian@12": {
+					{Stage: DistroTraceStage, Detail: "record is for distro debian@12, but the package has no distro"},
+					{Stage: NameTraceStage, Passed: true, Detail: `record package "openssl" matches searched name "openssl"`},
+					{Stage: RangeTraceStage, Detail: `version 3.0.5 cannot be compared with "< 3.0.11-1" (deb): (Deb) unsupported version comparison: value="3.0.5" format="Unknown", fixed in 3.0.11-1`},
+				},
+				"debian:distro:debian:11 debian@11": {
+					{Stage: DistroTraceStage, Detail: "record is for distro debian@11, but the package has no distro"},
+					{Stage: NameTraceStage, Passed: true, Detail: `record package "openssl" matches searched name "openssl"`},
+					{Stage: RangeTraceStage, Detail: `version 3.0.5 cannot be compared with "< 1.1.1w-0" (deb): (Deb) unsupported version comparison: value="3.0.5" format="Unknown"`},
+				},
+				"github:language:javascript ": {
+					{Stage: StatusTraceStage, Detail: "vulnerability is rejected"},
+					{Stage: EcosystemTraceStage, Passed: true, Detail: `record ecosystem "npm" is selected when searching within "npm"`},
+					{Stage: NameTraceStage, Passed: true, Detail: `record package "openssl" matches searched name "openssl"`},
+					{Stage: RangeTraceStage, Detail: `version 3.0.5 is not within ">= 2.0.0, < 2.1.0" ()`},
+					{Stage: RangeTraceStage, Passed: true, Detail: `version 3.0.5 is within ">= 3.0.0, < 3.0.12" ()`},
+					{Stage: QualifierTraceStage, Passed: true, Detail: `RPM modularity "nodejs:18" is satisfied`},
+				},
+				"nvd:cpe ": {
+					{Stage: StatusTraceStage, Passed: true, Detail: "vulnerability is not withdrawn or rejected"},
+					{Stage: CPETraceStage, Detail: "package has no CPEs to search by"},
+					{Stage: RangeTraceStage, Passed: true, Detail: `version 3.0.5 is within "< 3.0.12" ()`},
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			traces, err := TraceAffected(rdr, tt.pkg, "CVE-2024-0001")
+			require.NoError(t, err)
+
+			actual := make(map[string][]TraceDecision)
+			for _, tr := range traces {
+				actual[tr.Namespace+" "+tr.OS] = tr.Decisions
+			}
+			assert.Equal(t, tt.expected, actual)
+		})
+	}
+}
+
+func TestTraceAffected_Selected(t *testing.T) {
+	rdr := testTraceReader(t)
+
+	traces, err := TraceAffected(rdr, pkg.Package{
+		Name:    "openssl",
+		Version: "3.0.10-1",
+		Type:    syftPkg.DebPkg,
+		Distro:  distro.New(distro.Debian, "12", ""),
+	}, "CVE-2024-0001")
+	require.NoError(t, err)
+
+	selected := make(map[string]bool)
+	for _, tr := range traces {
+		selected[tr.Namespace+" "+tr.OS] = tr.Selected
+	}
+	assert.Equal(t, map[string]bool{
+		"debian:distro:debian:12 debian@12": true,
+		"debian:distro:debian:11 debian@11": false,
+		"github:language:javascript ":       false,
+		"nvd:cpe ":                          false,
+	}, selected)
+}
+
+func TestTraceAffected_NoRecords(t *testing.T) {
+	rdr := testTraceReader(t)
+
+	traces, err := TraceAffected(rdr, pkg.Package{Name: "openssl", Version: "3.0.10-1", Type: syftPkg.DebPkg}, "CVE-2099-0001")
+	require.NoError(t, err)
+	assert.Empty(t, traces)
+}
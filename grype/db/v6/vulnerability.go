@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"sort"
 	"strings"
+	"time"
 
 	"github.com/scylladb/go-set/strset"
 
@@ -254,6 +255,7 @@ func toPackageQualifiers(qualifiers *AffectedPackageQualifiers) []qualifier.Qual
 func toFix(affectedRanges []AffectedRange) vulnerability.Fix {
 	var state vulnerability.FixState
 	var versions []string
+	var date *time.Time
 	for _, r := range affectedRanges {
 		if r.Fix == nil {
 			continue
@@ -262,6 +264,11 @@ func toFix(affectedRanges []AffectedRange) vulnerability.Fix {
 		case FixedStatus:
 			state = vulnerability.FixStateFixed
 			versions = append(versions, r.Fix.Version)
+			if r.Fix.Detail != nil && r.Fix.Detail.Timestamp != nil {
+				if date == nil || r.Fix.Detail.Timestamp.Before(*date) {
+					date = r.Fix.Detail.Timestamp
+				}
+			}
 		case NotAffectedFixStatus:
 			// TODO: not handled yet
 		case WontFixStatus:
@@ -280,6 +287,7 @@ func toFix(affectedRanges []AffectedRange) vulnerability.Fix {
 	return vulnerability.Fix{
 		Versions: versions,
 		State:    state,
+		Date:     date,
 	}
 }
 
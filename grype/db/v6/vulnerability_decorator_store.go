@@ -1,6 +1,7 @@
 package v6
 
 import (
+	"errors"
 	"fmt"
 	"time"
 
@@ -21,6 +22,13 @@ type VulnerabilityDecoratorStoreReader interface {
 	GetEpss(cve string) ([]EpssHandle, error)
 }
 
+// VulnerabilityDecoratorListStoreReader fetches all decorator records at once, which is useful when comparing
+// databases (as opposed to decorating specific vulnerabilities).
+type VulnerabilityDecoratorListStoreReader interface {
+	AllKnownExploitedVulnerabilities() ([]KnownExploitedVulnerabilityHandle, error)
+	AllEpss() ([]EpssHandle, error)
+}
+
 type vulnerabilityDecoratorStore struct {
 	db          *gorm.DB
 	blobStore   *blobStore
@@ -101,14 +109,26 @@ func (s *vulnerabilityDecoratorStore) getEPSSMetadata() (*EpssMetadata, error) {
 }
 
 func (s *vulnerabilityDecoratorStore) GetEpss(cve string) ([]EpssHandle, error) {
+	return s.findEpss(s.db.Where("cve = ? collate nocase", cve), logger.Fields{"cve": cve})
+}
+
+// AllEpss returns the EPSS records for all CVEs.
+func (s *vulnerabilityDecoratorStore) AllEpss() ([]EpssHandle, error) {
+	if s.epssEnabled && s.epssDate == nil {
+		// databases without any EPSS data (e.g. those built from OSV records) have no EPSS metadata
+		if _, err := s.getEPSSMetadata(); errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+	}
+	return s.findEpss(s.db, logger.Fields{"cve": "*"})
+}
+
+func (s *vulnerabilityDecoratorStore) findEpss(query *gorm.DB, fields logger.Fields) ([]EpssHandle, error) {
 	if !s.epssEnabled {
 		// capability incompatibilities should gracefully degrade, returning no data or errors
 		return nil, nil
 	}
 
-	fields := logger.Fields{
-		"cve": cve,
-	}
 	start := time.Now()
 	var count int
 	defer func() {
@@ -129,7 +149,7 @@ func (s *vulnerabilityDecoratorStore) GetEpss(cve string) ([]EpssHandle, error)
 		s.epssDate = &metadata.Date
 	}
 
-	if err := s.db.Where("cve = ? collate nocase", cve).FindInBatches(&results, batchSize, func(_ *gorm.DB, _ int) error {
+	if err := query.FindInBatches(&results, batchSize, func(_ *gorm.DB, _ int) error {
 		for _, r := range results {
 			r.Date = *s.epssDate
 			models = append(models, *r)
@@ -166,14 +186,20 @@ func (s *vulnerabilityDecoratorStore) AddKnownExploitedVulnerabilities(kevs ...*
 }
 
 func (s *vulnerabilityDecoratorStore) GetKnownExploitedVulnerabilities(cve string) ([]KnownExploitedVulnerabilityHandle, error) {
+	return s.findKnownExploitedVulnerabilities(s.db.Where("cve = ? collate nocase", cve), logger.Fields{"cve": cve})
+}
+
+// AllKnownExploitedVulnerabilities returns the KEV records for all CVEs.
+func (s *vulnerabilityDecoratorStore) AllKnownExploitedVulnerabilities() ([]KnownExploitedVulnerabilityHandle, error) {
+	return s.findKnownExploitedVulnerabilities(s.db, logger.Fields{"cve": "*"})
+}
+
+func (s *vulnerabilityDecoratorStore) findKnownExploitedVulnerabilities(query *gorm.DB, fields logger.Fields) ([]KnownExploitedVulnerabilityHandle, error) {
 	if !s.kevEnabled {
 		// capability incompatibilities should gracefully degrade, returning no data or errors
 		return nil, nil
 	}
 
-	fields := logger.Fields{
-		"cve": cve,
-	}
 	start := time.Now()
 	var count int
 	defer func() {
@@ -185,7 +211,7 @@ func (s *vulnerabilityDecoratorStore) GetKnownExploitedVulnerabilities(cve strin
 	var models []KnownExploitedVulnerabilityHandle
 	var results []*KnownExploitedVulnerabilityHandle
 
-	if err := s.db.Where("cve = ? collate nocase", cve).FindInBatches(&results, batchSize, func(_ *gorm.DB, _ int) error {
+	if err := query.FindInBatches(&results, batchSize, func(_ *gorm.DB, _ int) error {
 		var blobs []blobable
 		for _, r := range results {
 			blobs = append(blobs, r)
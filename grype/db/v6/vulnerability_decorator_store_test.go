@@ -132,10 +132,48 @@ func TestVulnerabilityDecoratorStore(t *testing.T) {
 			if d := cmp.Diff(tt.input, actual); d != "" {
 				t.Errorf("unexpected known exploited vulnerabilities (-expected, +actual): %s", d)
 			}
+
+			all, err := s.AllKnownExploitedVulnerabilities()
+			require.NoError(t, err)
+			assert.Len(t, all, len(tt.input))
 		})
 	}
 }
 
+func TestVulnerabilityDecoratorStore_Epss(t *testing.T) {
+	db := setupTestStore(t).db
+	s := &vulnerabilityDecoratorStore{
+		db:          db,
+		blobStore:   newBlobStore(db),
+		epssEnabled: true,
+	}
+
+	// no EPSS data has been written yet
+	all, err := s.AllEpss()
+	require.NoError(t, err)
+	assert.Empty(t, all)
+
+	date := time.Date(2025, 1, 2, 0, 0, 0, 0, time.UTC)
+	require.NoError(t, s.AddEpss(
+		&EpssHandle{Cve: "CVE-2023-1234", Epss: 0.5, Percentile: 0.9, Date: date},
+		&EpssHandle{Cve: "CVE-2023-5678", Epss: 0.1, Percentile: 0.2, Date: date},
+	))
+
+	// reading from a new store ensures the date is read from the EPSS metadata
+	s = &vulnerabilityDecoratorStore{db: db, blobStore: newBlobStore(db), epssEnabled: true}
+
+	got, err := s.GetEpss("cve-2023-1234")
+	require.NoError(t, err)
+	require.Len(t, got, 1)
+	assert.Equal(t, 0.5, got[0].Epss)
+	assert.True(t, date.Equal(got[0].Date))
+
+	all, err = s.AllEpss()
+	require.NoError(t, err)
+	require.Len(t, all, 2)
+	assert.ElementsMatch(t, []string{"CVE-2023-1234", "CVE-2023-5678"}, []string{all[0].Cve, all[1].Cve})
+}
+
 func TestVulnerabilityDecoratorStore_AddKnownExploitedVulnerabilities_VersionCompatibility(t *testing.T) {
 	tests := []struct {
 		name          string
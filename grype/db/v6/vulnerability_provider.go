@@ -35,10 +35,16 @@ func NewVulnerabilityProvider(rdr Reader) vulnerability.Provider {
 
 type vulnerabilityProvider struct {
 	reader Reader
+
+	// prefetched holds the affected package records found for each package name searched for with Prefetch (keyed by
+	// criteriaQuery.prefetchKey)
+	prefetched map[string][]AffectedPackageHandle
 }
 
 var _ interface {
 	vulnerability.Provider
+	vulnerability.BatchProvider
+	vulnerability.Prefetcher
 } = (*vulnerabilityProvider)(nil)
 
 // Deprecated: vulnerability.Vulnerability objects now have metadata included
@@ -192,162 +198,316 @@ func (vp vulnerabilityProvider) Close() error {
 	return vp.reader.(io.Closer).Close()
 }
 
-//nolint:funlen,gocognit,gocyclo
 func (vp vulnerabilityProvider) FindVulnerabilities(criteria ...vulnerability.Criteria) ([]vulnerability.Vulnerability, error) {
 	if err := search.ValidateCriteria(criteria); err != nil {
 		return nil, err
 	}
 
-	var err error
-
 	var out []vulnerability.Vulnerability
 	for _, criteriaSet := range search.CriteriaIterator(criteria) {
-		var vulnSpecs VulnerabilitySpecifiers
-		var osSpecs OSSpecifiers
-		var pkgSpec *PackageSpecifier
-		var cpeSpec *cpe.Attributes
-		var pkgType syftPkg.Type
-
-		for i := 0; i < len(criteriaSet); i++ {
-			applied := false
-			switch c := criteriaSet[i].(type) {
-			case *search.PackageNameCriteria:
-				if pkgSpec == nil {
-					pkgSpec = &PackageSpecifier{}
-				}
-				pkgSpec.Name = c.PackageName
-				applied = true
-			case *search.EcosystemCriteria:
-				if pkgSpec == nil {
-					pkgSpec = &PackageSpecifier{}
-				}
-				// the v6 store normalizes ecosystems around the syft package type, so that field is preferred
-				switch {
-				case c.PackageType != "" && c.PackageType != syftPkg.UnknownPkg:
-					// prefer to match by a non-blank, known package type
-					pkgType = c.PackageType
-					pkgSpec.Ecosystem = string(c.PackageType)
-				case c.Language != "":
-					// if there's no known package type, but there is a non-blank language
-					// try that.
-					pkgSpec.Ecosystem = string(c.Language)
-				case c.PackageType == syftPkg.UnknownPkg:
-					// if language is blank, and package type is explicitly "UnknownPkg" and not
-					// just blank, use that.
-					pkgType = c.PackageType
-					pkgSpec.Ecosystem = string(c.PackageType)
-				}
-				applied = true
-			case *search.IDCriteria:
-				vulnSpecs = append(vulnSpecs, VulnerabilitySpecifier{
-					Name: c.ID,
-				})
-				applied = true
-			case *search.CPECriteria:
-				if cpeSpec == nil {
-					cpeSpec = &cpe.Attributes{}
-				}
-				*cpeSpec = c.CPE.Attributes
-				if cpeSpec.Product == cpe.Any {
-					return nil, fmt.Errorf("must specify product to search by CPE; got: %s", c.CPE.Attributes.BindToFmtString())
-				}
-				if pkgSpec == nil {
-					pkgSpec = &PackageSpecifier{}
-				}
-				pkgSpec.CPE = &c.CPE.Attributes
-				applied = true
-			case *search.DistroCriteria:
-				for _, d := range c.Distros {
-					osSpecs = append(osSpecs, &OSSpecifier{
-						Name:             d.Name(),
-						MajorVersion:     d.MajorVersion(),
-						MinorVersion:     d.MinorVersion(),
-						RemainingVersion: d.RemainingVersion(),
-						LabelVersion:     d.Codename,
-					})
-				}
-				applied = true
-			}
+		q, err := newCriteriaQuery(criteriaSet)
+		if err != nil {
+			return nil, err
+		}
 
-			// remove fully applied criteria from later checks
-			if applied {
-				criteriaSet = append(criteriaSet[0:i], criteriaSet[i+1:]...)
-				i--
+		vulns, err := vp.findVulnerabilities(q)
+		if err != nil {
+			if errors.Is(err, ErrOSNotPresent) {
+				log.WithFields("os", q.osSpecs).Debug("no OS found in the DB for the given criteria")
+				return nil, nil
 			}
+			return nil, err
 		}
 
-		if len(osSpecs) == 0 {
-			// we don't want to search across all distros, instead if the user did not specify a distro we should assume that
-			// they want to search across affected packages not associated with any distro.
-			osSpecs = append(osSpecs, NoOSSpecified)
-		}
+		out = append(out, vulns...)
+	}
 
-		// if there is an ecosystem provided and a name, we need to make certain that we're using the name normalization
-		// rules specific to the ecosystem before searching.
-		if pkgType != "" && pkgSpec.Name != "" {
-			pkgSpec.Name = name.Normalize(pkgSpec.Name, pkgType)
-		}
+	return out, nil
+}
 
-		versionMatcher, remainingCriteria := splitConstraintMatcher(criteriaSet...)
+// FindVulnerabilitiesBatch returns the vulnerabilities for each of the given queries. The affected package records
+// (and their blobs) for all queries that search by package name are prefetched together, while other queries (e.g.
+// by CPE or vulnerability ID) are searched individually.
+func (vp vulnerabilityProvider) FindVulnerabilitiesBatch(queries ...[]vulnerability.Criteria) ([][]vulnerability.Vulnerability, error) {
+	prefetched, err := vp.Prefetch(queries...)
+	if err != nil {
+		return nil, err
+	}
+
+	out := make([][]vulnerability.Vulnerability, len(queries))
+	for i, criteria := range queries {
+		out[i], err = prefetched.FindVulnerabilities(criteria...)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return out, nil
+}
 
-		var affectedPackages []AffectedPackageHandle
-		var affectedCPEs []AffectedCPEHandle
+// Prefetch returns a provider that has already fetched the affected package records (and their blobs) for all queries
+// that search by package name. These are grouped by ecosystem and distro so that each group is fetched with a small
+// number of queries, rather than with several queries for each package. The returned provider shares the reader of
+// this provider, so is not to be closed.
+func (vp vulnerabilityProvider) Prefetch(queries ...[]vulnerability.Criteria) (vulnerability.Provider, error) {
+	// group the criteria sets that can be searched together by package name
+	groups := make(map[string][]*criteriaQuery)
+	var groupKeys []string
+	for _, criteria := range queries {
+		if err := search.ValidateCriteria(criteria); err != nil {
+			return nil, err
+		}
 
-		if pkgSpec != nil || len(vulnSpecs) > 0 {
-			affectedPackages, err = vp.reader.GetAffectedPackages(pkgSpec, &GetAffectedPackageOptions{
-				OSs:             osSpecs,
-				Vulnerabilities: vulnSpecs,
-				PreloadBlob:     true,
-			})
+		for _, criteriaSet := range search.CriteriaIterator(criteria) {
+			q, err := newCriteriaQuery(criteriaSet)
 			if err != nil {
-				if errors.Is(err, ErrOSNotPresent) {
-					log.WithFields("os", osSpecs).Debug("no OS found in the DB for the given criteria")
-					return nil, nil
-				}
 				return nil, err
 			}
 
-			affectedPackages = filterAffectedPackageVersions(versionMatcher, affectedPackages)
+			if !q.batchable() || vp.hasPackageNameAlias(q) {
+				continue
+			}
 
-			// after filtering, read vulnerability data
-			if err = fillAffectedPackageHandles(vp.reader, ptrs(affectedPackages)); err != nil {
-				return nil, err
+			key := q.batchKey()
+			if _, ok := groups[key]; !ok {
+				groupKeys = append(groupKeys, key)
 			}
+			groups[key] = append(groups[key], q)
 		}
+	}
 
-		if cpeSpec != nil {
-			affectedCPEs, err = vp.reader.GetAffectedCPEs(cpeSpec, &GetAffectedCPEOptions{
-				Vulnerabilities: vulnSpecs,
-				PreloadBlob:     true,
-			})
-			if err != nil {
-				return nil, err
+	prefetched := make(map[string][]AffectedPackageHandle)
+	for _, key := range groupKeys {
+		group := groups[key]
+
+		var names []string
+		for _, q := range group {
+			names = append(names, q.pkgSpec.Name)
+		}
+
+		first := group[0]
+		handles, err := vp.reader.GetAffectedPackagesByName(first.pkgSpec.Ecosystem, names, &GetAffectedPackageOptions{
+			OSs:         first.osSpecs,
+			PreloadBlob: true,
+		})
+		if err != nil {
+			if errors.Is(err, ErrOSNotPresent) {
+				// not prefetched, so this is reported when searching for any of these packages
+				continue
 			}
+			return nil, err
+		}
 
-			affectedCPEs = filterAffectedCPEVersions(versionMatcher, affectedCPEs, cpeSpec)
+		// unlike when searching for a single package, the vulnerability data is read for all handles before
+		// filtering by version, as the versions searched for are not known yet
+		if err = fillAffectedPackageHandles(vp.reader, ptrs(handles)); err != nil {
+			return nil, err
+		}
 
-			// after filtering, read vulnerability data
-			if err = fillAffectedCPEHandles(vp.reader, ptrs(affectedCPEs)); err != nil {
-				return nil, err
+		handlesByName := make(map[string][]AffectedPackageHandle)
+		for _, h := range handles {
+			if h.Package == nil {
+				continue
+			}
+			name := strings.ToLower(h.Package.Name)
+			handlesByName[name] = append(handlesByName[name], h)
+		}
+
+		// record every name searched for, so that packages without any records are not searched for again
+		for _, q := range group {
+			prefetched[q.prefetchKey()] = handlesByName[strings.ToLower(q.pkgSpec.Name)]
+		}
+	}
+
+	return &vulnerabilityProvider{
+		reader:     vp.reader,
+		prefetched: prefetched,
+	}, nil
+}
+
+// prefetchedPackages returns the (already filled) affected package records that were prefetched for the given query
+func (vp vulnerabilityProvider) prefetchedPackages(q *criteriaQuery) ([]AffectedPackageHandle, bool) {
+	if vp.prefetched == nil || !q.batchable() {
+		return nil, false
+	}
+	handles, ok := vp.prefetched[q.prefetchKey()]
+	return handles, ok
+}
+
+// criteriaQuery describes how a single set of criteria is searched for in the DB
+type criteriaQuery struct {
+	vulnSpecs         VulnerabilitySpecifiers
+	osSpecs           OSSpecifiers
+	pkgSpec           *PackageSpecifier
+	cpeSpec           *cpe.Attributes
+	versionMatcher    search.VersionConstraintMatcher
+	remainingCriteria []vulnerability.Criteria
+}
+
+//nolint:funlen
+func newCriteriaQuery(criteriaSet []vulnerability.Criteria) (*criteriaQuery, error) {
+	q := &criteriaQuery{}
+	var pkgType syftPkg.Type
+
+	for i := 0; i < len(criteriaSet); i++ {
+		applied := false
+		switch c := criteriaSet[i].(type) {
+		case *search.PackageNameCriteria:
+			if q.pkgSpec == nil {
+				q.pkgSpec = &PackageSpecifier{}
+			}
+			q.pkgSpec.Name = c.PackageName
+			applied = true
+		case *search.EcosystemCriteria:
+			if q.pkgSpec == nil {
+				q.pkgSpec = &PackageSpecifier{}
+			}
+			// the v6 store normalizes ecosystems around the syft package type, so that field is preferred
+			switch {
+			case c.PackageType != "" && c.PackageType != syftPkg.UnknownPkg:
+				// prefer to match by a non-blank, known package type
+				pkgType = c.PackageType
+				q.pkgSpec.Ecosystem = string(c.PackageType)
+			case c.Language != "":
+				// if there's no known package type, but there is a non-blank language
+				// try that.
+				q.pkgSpec.Ecosystem = string(c.Language)
+			case c.PackageType == syftPkg.UnknownPkg:
+				// if language is blank, and package type is explicitly "UnknownPkg" and not
+				// just blank, use that.
+				pkgType = c.PackageType
+				q.pkgSpec.Ecosystem = string(c.PackageType)
 			}
+			applied = true
+		case *search.IDCriteria:
+			q.vulnSpecs = append(q.vulnSpecs, VulnerabilitySpecifier{
+				Name: c.ID,
+			})
+			applied = true
+		case *search.CPECriteria:
+			if q.cpeSpec == nil {
+				q.cpeSpec = &cpe.Attributes{}
+			}
+			*q.cpeSpec = c.CPE.Attributes
+			if q.cpeSpec.Product == cpe.Any {
+				return nil, fmt.Errorf("must specify product to search by CPE; got: %s", c.CPE.Attributes.BindToFmtString())
+			}
+			if q.pkgSpec == nil {
+				q.pkgSpec = &PackageSpecifier{}
+			}
+			q.pkgSpec.CPE = &c.CPE.Attributes
+			applied = true
+		case *search.DistroCriteria:
+			for _, d := range c.Distros {
+				q.osSpecs = append(q.osSpecs, &OSSpecifier{
+					Name:             d.Name(),
+					MajorVersion:     d.MajorVersion(),
+					MinorVersion:     d.MinorVersion(),
+					RemainingVersion: d.RemainingVersion(),
+					LabelVersion:     d.Codename,
+				})
+			}
+			applied = true
+		}
+
+		// remove fully applied criteria from later checks
+		if applied {
+			criteriaSet = append(criteriaSet[0:i], criteriaSet[i+1:]...)
+			i--
 		}
+	}
+
+	if len(q.osSpecs) == 0 {
+		// we don't want to search across all distros, instead if the user did not specify a distro we should assume that
+		// they want to search across affected packages not associated with any distro.
+		q.osSpecs = append(q.osSpecs, NoOSSpecified)
+	}
+
+	// if there is an ecosystem provided and a name, we need to make certain that we're using the name normalization
+	// rules specific to the ecosystem before searching.
+	if pkgType != "" && q.pkgSpec.Name != "" {
+		q.pkgSpec.Name = name.Normalize(q.pkgSpec.Name, pkgType)
+	}
 
-		// fill complete vulnerabilities for this set -- these should have already had all properties lazy loaded
-		vulns, err := vp.toVulnerabilities(affectedPackages, affectedCPEs)
+	q.versionMatcher, q.remainingCriteria = splitConstraintMatcher(criteriaSet...)
+
+	return q, nil
+}
+
+// batchable indicates if the query searches only by package name (optionally within an ecosystem and distro), which
+// can be combined with other such queries into a single search
+func (q criteriaQuery) batchable() bool {
+	return q.pkgSpec != nil && q.pkgSpec.Name != "" && q.pkgSpec.CPE == nil && q.cpeSpec == nil && len(q.vulnSpecs) == 0
+}
+
+// hasPackageNameAlias indicates if the package searched for is aliased by name, so cannot be searched for by name in a batch
+func (vp vulnerabilityProvider) hasPackageNameAlias(q *criteriaQuery) bool {
+	aliaser, ok := vp.reader.(interface {
+		hasPackageNameAlias(ecosystem, name string) bool
+	})
+	return ok && aliaser.hasPackageNameAlias(q.pkgSpec.Ecosystem, q.pkgSpec.Name)
+}
+
+// batchKey identifies the queries that can be searched together by package name
+func (q criteriaQuery) batchKey() string {
+	return strings.ToLower(q.pkgSpec.Ecosystem) + "|" + q.osSpecs.String()
+}
+
+// prefetchKey identifies the package (within an ecosystem and distro) that a batchable query searches for
+func (q criteriaQuery) prefetchKey() string {
+	return q.batchKey() + "|" + strings.ToLower(q.pkgSpec.Name)
+}
+
+func (vp vulnerabilityProvider) findVulnerabilities(q *criteriaQuery) ([]vulnerability.Vulnerability, error) {
+	var err error
+	var affectedPackages []AffectedPackageHandle
+	var affectedCPEs []AffectedCPEHandle
+
+	if handles, ok := vp.prefetchedPackages(q); ok {
+		// prefetched records have already had all properties lazy loaded
+		affectedPackages = filterAffectedPackageVersions(q.versionMatcher, handles)
+	} else if q.pkgSpec != nil || len(q.vulnSpecs) > 0 {
+		affectedPackages, err = vp.reader.GetAffectedPackages(q.pkgSpec, &GetAffectedPackageOptions{
+			OSs:             q.osSpecs,
+			Vulnerabilities: q.vulnSpecs,
+			PreloadBlob:     true,
+		})
 		if err != nil {
 			return nil, err
 		}
 
-		// filter vulnerabilities by any remaining criteria such as ByQualifiedPackages
-		vulns, err = vp.filterVulnerabilities(vulns, remainingCriteria...)
+		affectedPackages = filterAffectedPackageVersions(q.versionMatcher, affectedPackages)
+
+		// after filtering, read vulnerability data
+		if err = fillAffectedPackageHandles(vp.reader, ptrs(affectedPackages)); err != nil {
+			return nil, err
+		}
+	}
+
+	if q.cpeSpec != nil {
+		affectedCPEs, err = vp.reader.GetAffectedCPEs(q.cpeSpec, &GetAffectedCPEOptions{
+			Vulnerabilities: q.vulnSpecs,
+			PreloadBlob:     true,
+		})
 		if err != nil {
 			return nil, err
 		}
 
-		out = append(out, vulns...)
+		affectedCPEs = filterAffectedCPEVersions(q.versionMatcher, affectedCPEs, q.cpeSpec)
+
+		// after filtering, read vulnerability data
+		if err = fillAffectedCPEHandles(vp.reader, ptrs(affectedCPEs)); err != nil {
+			return nil, err
+		}
 	}
 
-	return out, nil
+	// fill complete vulnerabilities for this set -- these should have already had all properties lazy loaded
+	vulns, err := vp.toVulnerabilities(affectedPackages, affectedCPEs)
+	if err != nil {
+		return nil, err
+	}
+
+	// filter vulnerabilities by any remaining criteria such as ByQualifiedPackages
+	return vp.filterVulnerabilities(vulns, q.remainingCriteria...)
 }
 
 func (vp vulnerabilityProvider) filterVulnerabilities(vulns []vulnerability.Vulnerability, criteria ...vulnerability.Criteria) ([]vulnerability.Vulnerability, error) {
@@ -0,0 +1,92 @@
+package v6
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/anchore/grype/grype/search"
+	"github.com/anchore/grype/grype/version"
+	"github.com/anchore/grype/grype/vulnerability"
+	"github.com/anchore/grype/internal/log"
+	syftPkg "github.com/anchore/syft/syft/pkg"
+)
+
+// this benchmark compares searching for the vulnerabilities of each package in turn (as matchers do) with prefetching
+// the records for all packages at once (as is done for each chunk of packages being matched)
+func BenchmarkVulnerabilityProvider_Prefetch(b *testing.B) {
+	const packageCount = 500
+	provider := benchmarkVulnerabilityProvider(b, packageCount)
+
+	// search for a mix of packages with and without known vulnerabilities, as the language matchers do
+	var queries [][]vulnerability.Criteria
+	for i := range packageCount {
+		queries = append(queries, []vulnerability.Criteria{
+			search.ByEcosystem(syftPkg.JavaScript, syftPkg.NpmPkg),
+			search.ByPackageName(fmt.Sprintf("package-%d", i*2)),
+			search.ByVersion(*version.NewVersion("1.0.0", version.SemanticFormat)),
+		})
+	}
+
+	b.Run("per-package", func(b *testing.B) {
+		for range b.N {
+			for _, criteria := range queries {
+				if _, err := provider.FindVulnerabilities(criteria...); err != nil {
+					b.Fatal(err)
+				}
+			}
+		}
+	})
+
+	b.Run("prefetch", func(b *testing.B) {
+		for range b.N {
+			prefetched, err := vulnerability.Prefetch(provider, queries...)
+			if err != nil {
+				b.Fatal(err)
+			}
+			for _, criteria := range queries {
+				if _, err := prefetched.FindVulnerabilities(criteria...); err != nil {
+					b.Fatal(err)
+				}
+			}
+		}
+	})
+}
+
+// benchmarkVulnerabilityProvider returns a provider for an (indexed) DB with a vulnerability for each of the given
+// number of npm packages
+func benchmarkVulnerabilityProvider(b *testing.B, packageCount int) vulnerability.Provider {
+	b.Helper()
+	dir := b.TempDir()
+	w, err := NewWriter(Config{DBDirPath: dir})
+	require.NoError(b, err)
+
+	provider := &Provider{ID: "github", Version: "1"}
+	for i := range packageCount {
+		vuln := &VulnerabilityHandle{
+			Name:       fmt.Sprintf("GHSA-%d", i),
+			ProviderID: provider.ID,
+			Provider:   provider,
+			BlobValue:  &VulnerabilityBlob{ID: fmt.Sprintf("GHSA-%d", i)},
+		}
+		require.NoError(b, w.AddVulnerabilities(vuln))
+		require.NoError(b, w.AddAffectedPackages(&AffectedPackageHandle{
+			Vulnerability: vuln,
+			Package:       &Package{Ecosystem: string(syftPkg.NpmPkg), Name: fmt.Sprintf("package-%d", i)},
+			BlobValue: &AffectedPackageBlob{
+				Ranges: []AffectedRange{{Version: AffectedVersion{Type: "semver", Constraint: "< 2.0.0"}}},
+			},
+		}))
+	}
+	require.NoError(b, w.Close())
+
+	// closing the writer drops all indexes, which are needed for searching
+	require.NoError(b, Hydrater()(dir))
+
+	r, err := NewReader(Config{DBDirPath: dir})
+	require.NoError(b, err)
+	b.Cleanup(func() { log.CloseAndLogError(r, dir) })
+
+	return NewVulnerabilityProvider(r)
+}
@@ -16,7 +16,7 @@ import (
 	"github.com/anchore/syft/syft/cpe"
 )
 
-func testVulnerabilityProvider(t *testing.T) vulnerability.Provider {
+func testVulnerabilityProvider(t *testing.T, aliases ...PackageSpecifierAlias) vulnerability.Provider {
 	t.Helper()
 	tmp := t.TempDir()
 	w, err := NewWriter(Config{
@@ -266,5 +266,8 @@ func testVulnerabilityProvider(t *testing.T) vulnerability.Provider {
 		}
 	}
 
-	return NewVulnerabilityProvider(setupReadOnlyTestStore(t, tmp))
+	s, err := newStore(Config{DBDirPath: tmp, PackageAliases: aliases}, false, false)
+	require.NoError(t, err)
+
+	return NewVulnerabilityProvider(s)
 }
@@ -9,6 +9,7 @@ import (
 	"github.com/google/go-cmp/cmp/cmpopts"
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
 
 	"github.com/anchore/grype/grype/distro"
 	"github.com/anchore/grype/grype/pkg"
@@ -376,6 +377,193 @@ func Test_FindVulnerabilitiesByEcosystem_UnknownPackageType(t *testing.T) {
 	}
 }
 
+func Test_FindVulnerabilitiesBatch(t *testing.T) {
+	provider := testVulnerabilityProvider(t)
+	require.Implements(t, (*vulnerability.BatchProvider)(nil), provider)
+
+	debian := distro.New(distro.Debian, "8", "")
+	ubuntu := distro.New(distro.Ubuntu, "20.04", "")
+	neutron := pkg.Package{
+		Name:    "neutron",
+		Version: "2014.0.0-1",
+		Type:    syftPkg.DebPkg,
+	}
+
+	queries := [][]vulnerability.Criteria{
+		{search.ByDistro(*debian), search.ByPackageName(neutron.Name), search.ByVersion(*version.NewVersionFromPkg(neutron))},
+		{search.ByDistro(*debian), search.ByPackageName("NEUTRON")},
+		{search.ByDistro(*debian), search.ByPackageName("does-not-exist")},
+		{search.ByDistro(*ubuntu), search.ByPackageName(neutron.Name)},
+		{search.ByEcosystem(syftPkg.Dotnet, syftPkg.DotnetPkg), search.ByPackageName("Newtonsoft.Json")},
+		{search.ByCPE(cpe.Must("cpe:2.3:*:activerecord:activerecord:*:*:*:*:*:rails:*:*", ""))},
+		{search.ByID("CVE-2014-fake-1")},
+	}
+
+	actual, err := vulnerability.FindVulnerabilitiesBatch(provider, queries...)
+	require.NoError(t, err)
+	require.Len(t, actual, len(queries))
+
+	for idx, criteria := range queries {
+		expected, err := provider.FindVulnerabilities(criteria...)
+		require.NoError(t, err)
+		if d := cmp.Diff(vulnIDs(expected), vulnIDs(actual[idx])); d != "" {
+			t.Errorf("query %d: unexpected vulnerability IDs: %s", idx, d)
+		}
+		if d := cmp.Diff(expected, actual[idx], cmpOpts()...); d != "" {
+			t.Errorf("query %d: unexpected vulnerabilities: %s", idx, d)
+		}
+	}
+
+	// ensure the comparison is meaningful
+	require.Equal(t, []string{"CVE-2014-fake-1"}, vulnIDs(actual[0]))
+	require.Empty(t, actual[2])
+	require.Empty(t, actual[3])
+	require.Equal(t, []string{"GHSA-5crp-9r3c-p9vr"}, vulnIDs(actual[4]))
+}
+
+func Test_Prefetch(t *testing.T) {
+	provider := testVulnerabilityProvider(t)
+	require.Implements(t, (*vulnerability.Prefetcher)(nil), provider)
+
+	debian := distro.New(distro.Debian, "8", "")
+	neutron := pkg.Package{
+		Name:    "neutron",
+		Version: "2014.0.0-1",
+		Type:    syftPkg.DebPkg,
+	}
+
+	queries := [][]vulnerability.Criteria{
+		{search.ByDistro(*debian), search.ByPackageName(neutron.Name)},
+		{search.ByDistro(*debian), search.ByPackageName("does-not-exist")},
+		{search.ByEcosystem(syftPkg.Dotnet, syftPkg.DotnetPkg), search.ByPackageName("Newtonsoft.Json")},
+	}
+
+	// searches as the matchers do, with additional criteria for the prefetched packages
+	searches := [][]vulnerability.Criteria{
+		{search.ByDistro(*debian), search.ByPackageName(neutron.Name), search.ByVersion(*version.NewVersionFromPkg(neutron))},
+		{search.ByDistro(*debian), search.ByPackageName("NEUTRON")},
+		{search.ByDistro(*debian), search.ByPackageName("does-not-exist")},
+		{search.ByEcosystem(syftPkg.Dotnet, syftPkg.DotnetPkg), search.ByPackageName("Newtonsoft.Json")},
+	}
+
+	reader := &countingReader{Reader: provider.(*vulnerabilityProvider).reader}
+	prefetched, err := (&vulnerabilityProvider{reader: reader}).Prefetch(queries...)
+	require.NoError(t, err)
+	require.Zero(t, reader.getAffectedPackages)
+
+	for idx, criteria := range searches {
+		expected, err := provider.FindVulnerabilities(criteria...)
+		require.NoError(t, err)
+		actual, err := prefetched.FindVulnerabilities(criteria...)
+		require.NoError(t, err)
+		if d := cmp.Diff(expected, actual, cmpOpts()...); d != "" {
+			t.Errorf("search %d: unexpected vulnerabilities: %s", idx, d)
+		}
+	}
+	require.Zero(t, reader.getAffectedPackages, "prefetched packages should not be searched for again")
+
+	// ensure the comparison is meaningful
+	actual, err := prefetched.FindVulnerabilities(searches[0]...)
+	require.NoError(t, err)
+	require.Equal(t, []string{"CVE-2014-fake-1"}, vulnIDs(actual))
+
+	// packages that were not prefetched are still searched for
+	_, err = prefetched.FindVulnerabilities(search.ByDistro(*debian), search.ByPackageName("other"))
+	require.NoError(t, err)
+	require.Equal(t, 1, reader.getAffectedPackages)
+}
+
+// countingReader counts the searches for affected packages one package at a time
+type countingReader struct {
+	Reader
+	getAffectedPackages int
+}
+
+func (r *countingReader) GetAffectedPackages(pkg *PackageSpecifier, config *GetAffectedPackageOptions) ([]AffectedPackageHandle, error) {
+	r.getAffectedPackages++
+	return r.Reader.GetAffectedPackages(pkg, config)
+}
+
+func (r *countingReader) GetDB() *gorm.DB {
+	return r.Reader.(lowLevelReader).GetDB()
+}
+
+func Test_FindVulnerabilities_PackageAliases(t *testing.T) {
+	provider := testVulnerabilityProvider(t,
+		PackageSpecifierAlias{Ecosystem: "dotnet", Name: "Acme.Newtonsoft.*", ReplacementName: "Newtonsoft.*"},
+		PackageSpecifierAlias{Ecosystem: "dotnet", Name: "Acme.Json", ReplacementName: "Newtonsoft.Json"},
+		PackageSpecifierAlias{Ecosystem: "acme-nuget", ReplacementEcosystem: "dotnet"},
+	)
+
+	acmeNuget := syftPkg.Type("acme-nuget")
+	queries := [][]vulnerability.Criteria{
+		{search.ByEcosystem(syftPkg.Dotnet, syftPkg.DotnetPkg), search.ByPackageName("Acme.Newtonsoft.Json")},
+		{search.ByEcosystem(syftPkg.Dotnet, syftPkg.DotnetPkg), search.ByPackageName("acme.json")},
+		{search.ByEcosystem("", acmeNuget), search.ByPackageName("Newtonsoft.Json")},
+		{search.ByEcosystem(syftPkg.Dotnet, syftPkg.DotnetPkg), search.ByPackageName("Newtonsoft.Json")},
+		// only the ecosystem of the alias is replaced, so the name must still match
+		{search.ByEcosystem("", acmeNuget), search.ByPackageName("Acme.Json")},
+		// aliases are only applied within the ecosystem they are for
+		{search.ByEcosystem(syftPkg.Java, syftPkg.JavaPkg), search.ByPackageName("Acme.Newtonsoft.Json")},
+	}
+	expected := [][]string{
+		{"GHSA-5crp-9r3c-p9vr"},
+		{"GHSA-5crp-9r3c-p9vr"},
+		{"GHSA-5crp-9r3c-p9vr"},
+		{"GHSA-5crp-9r3c-p9vr"},
+		nil,
+		nil,
+	}
+
+	batch, err := vulnerability.FindVulnerabilitiesBatch(provider, queries...)
+	require.NoError(t, err)
+	require.Len(t, batch, len(queries))
+
+	for idx, criteria := range queries {
+		actual, err := provider.FindVulnerabilities(criteria...)
+		require.NoError(t, err)
+		if d := cmp.Diff(expected[idx], vulnIDs(actual)); d != "" {
+			t.Errorf("query %d: unexpected vulnerability IDs: %s", idx, d)
+		}
+		if d := cmp.Diff(expected[idx], vulnIDs(batch[idx])); d != "" {
+			t.Errorf("query %d: unexpected vulnerability IDs from batch: %s", idx, d)
+		}
+	}
+}
+
+func Test_FindVulnerabilitiesBatch_EcosystemRenameAlias(t *testing.T) {
+	// this alias is rejected by Validate, but must still give the same results when batched as when searched for alone
+	alias := PackageSpecifierAlias{Ecosystem: "dotnet", ReplacementName: "Newtonsoft.Json"}
+	require.Error(t, alias.Validate())
+	provider := testVulnerabilityProvider(t, alias)
+
+	queries := [][]vulnerability.Criteria{
+		{search.ByEcosystem(syftPkg.Dotnet, syftPkg.DotnetPkg), search.ByPackageName("Acme.Json")},
+		{search.ByEcosystem(syftPkg.Dotnet, syftPkg.DotnetPkg), search.ByPackageName("Newtonsoft.Json")},
+	}
+
+	batch, err := vulnerability.FindVulnerabilitiesBatch(provider, queries...)
+	require.NoError(t, err)
+	require.Len(t, batch, len(queries))
+
+	for idx, criteria := range queries {
+		expected, err := provider.FindVulnerabilities(criteria...)
+		require.NoError(t, err)
+		require.Equal(t, []string{"GHSA-5crp-9r3c-p9vr"}, vulnIDs(expected))
+		if d := cmp.Diff(expected, batch[idx], cmpOpts()...); d != "" {
+			t.Errorf("query %d: unexpected vulnerabilities from batch: %s", idx, d)
+		}
+	}
+}
+
+func vulnIDs(vulns []vulnerability.Vulnerability) []string {
+	var ids []string
+	for _, v := range vulns {
+		ids = append(ids, v.ID)
+	}
+	return ids
+}
+
 func Test_DataSource(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -3,6 +3,7 @@ package v6
 import (
 	"strings"
 	"testing"
+	"time"
 	"unicode"
 
 	"github.com/stretchr/testify/assert"
@@ -637,3 +638,51 @@ func majorMinorPatch(ver string) (string, string, string) {
 	}
 	return parts[0], parts[1], parts[2]
 }
+
+func Test_toFix(t *testing.T) {
+	early := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	late := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name   string
+		ranges []AffectedRange
+		want   vulnerability.Fix
+	}{
+		{
+			name: "no fix",
+			want: vulnerability.Fix{},
+		},
+		{
+			name: "not fixed",
+			ranges: []AffectedRange{
+				{Fix: &Fix{State: NotFixedStatus}},
+			},
+			want: vulnerability.Fix{State: vulnerability.FixStateNotFixed},
+		},
+		{
+			name: "fixed without date",
+			ranges: []AffectedRange{
+				{Fix: &Fix{State: FixedStatus, Version: "1.0.1"}},
+			},
+			want: vulnerability.Fix{State: vulnerability.FixStateFixed, Versions: []string{"1.0.1"}},
+		},
+		{
+			name: "earliest fix date is used",
+			ranges: []AffectedRange{
+				{Fix: &Fix{State: FixedStatus, Version: "2.0.1", Detail: &FixDetail{Timestamp: &late}}},
+				{Fix: &Fix{State: FixedStatus, Version: "1.0.1", Detail: &FixDetail{Timestamp: &early}}},
+				{Fix: &Fix{State: FixedStatus, Version: "3.0.1"}},
+			},
+			want: vulnerability.Fix{
+				State:    vulnerability.FixStateFixed,
+				Versions: []string{"2.0.1", "1.0.1", "3.0.1"},
+				Date:     &early,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, toFix(tt.ranges))
+		})
+	}
+}
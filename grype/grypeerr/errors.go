@@ -1,10 +1,34 @@
 package grypeerr
 
+import "errors"
+
 var (
 	// ErrAboveSeverityThreshold indicates when a vulnerability severity is discovered that is equal
 	// or above the given --fail-on severity value.
 	ErrAboveSeverityThreshold = NewExpectedErr("discovered vulnerabilities at or above the severity threshold")
 
+	// ErrAboveRiskThreshold indicates when a vulnerability is discovered with a risk score that is equal
+	// or above the given --fail-on-risk value.
+	ErrAboveRiskThreshold = NewExpectedErr("discovered vulnerabilities at or above the risk threshold")
+
+	// ErrKnownExploited indicates when a known exploited vulnerability is discovered and --fail-on-kev is set.
+	ErrKnownExploited = NewExpectedErr("discovered known exploited vulnerabilities")
+
+	// ErrAboveEPSSPercentileThreshold indicates when a vulnerability is discovered with an EPSS percentile that
+	// is equal or above the given --fail-on-epss-percentile value.
+	ErrAboveEPSSPercentileThreshold = NewExpectedErr("discovered vulnerabilities at or above the EPSS percentile threshold")
+
+	// ErrPolicyViolation indicates when a finding matches a policy rule with the "fail" action.
+	ErrPolicyViolation = NewExpectedErr("discovered vulnerabilities that violate the policy")
+
 	// ErrDBUpgradeAvailable indicates that a DB upgrade is available.
 	ErrDBUpgradeAvailable = NewExpectedErr("db upgrade available")
 )
+
+// IsAboveFailThreshold indicates if the error was caused by a finding that tripped one of the --fail-on thresholds
+func IsAboveFailThreshold(err error) bool {
+	return errors.Is(err, ErrAboveSeverityThreshold) ||
+		errors.Is(err, ErrAboveRiskThreshold) ||
+		errors.Is(err, ErrKnownExploited) ||
+		errors.Is(err, ErrAboveEPSSPercentileThreshold)
+}
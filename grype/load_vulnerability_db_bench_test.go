@@ -1,12 +1,18 @@
 package grype
 
 import (
+	"fmt"
 	"math"
 	"path/filepath"
+	"runtime"
 	"testing"
 
 	"github.com/anchore/grype/grype/db/v6/distribution"
 	"github.com/anchore/grype/grype/db/v6/installation"
+	"github.com/anchore/grype/grype/matcher"
+	"github.com/anchore/grype/grype/pkg"
+	"github.com/anchore/grype/internal/log"
+	syftPkg "github.com/anchore/syft/syft/pkg"
 )
 
 // this benchmark was added to measure the performance
@@ -14,17 +20,83 @@ import (
 // https://github.com/anchore/grype/issues/1502
 func BenchmarkLoadVulnerabilityDB(b *testing.B) {
 	for range b.N {
-		_, _, err := LoadVulnerabilityDB(distribution.Config{
-			LatestURL: distribution.DefaultConfig().LatestURL,
-		}, installation.Config{
-			DBRootDir:               filepath.Join(".tmp", "grype-db"),
-			ValidateAge:             false,
-			ValidateChecksum:        true,
-			MaxAllowedBuiltAge:      math.MaxInt32,
-			UpdateCheckMaxFrequency: math.MaxInt32,
-		}, true)
+		_, _, err := LoadVulnerabilityDB(benchmarkDBConfig())
 		if err != nil {
 			b.Fatal(err)
 		}
 	}
 }
+
+// this benchmark measures the speedup of searching for matches for many packages in parallel
+// (as seen with large Java and Node images) over searching one package at a time.
+func BenchmarkVulnerabilityMatcher_FindMatches(b *testing.B) {
+	vp, status, err := LoadVulnerabilityDB(benchmarkDBConfig())
+	if err != nil {
+		b.Fatal(err)
+	}
+	defer log.CloseAndLogError(vp, status.Path)
+
+	packages := benchmarkPackages(5000)
+
+	for _, concurrency := range []int{1, 2, 4, runtime.NumCPU()} {
+		b.Run(fmt.Sprintf("concurrency=%d", concurrency), func(b *testing.B) {
+			m := VulnerabilityMatcher{
+				VulnerabilityProvider: vp,
+				Matchers:              matcher.NewDefaultMatchers(matcher.Config{}),
+				Concurrency:           concurrency,
+			}
+			for range b.N {
+				if _, _, err := m.FindMatches(packages, pkg.Context{}); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+func benchmarkDBConfig() (distribution.Config, installation.Config, bool) {
+	return distribution.Config{
+		LatestURL: distribution.DefaultConfig().LatestURL,
+	}, installation.Config{
+		DBRootDir:               filepath.Join(".tmp", "grype-db"),
+		ValidateAge:             false,
+		ValidateChecksum:        true,
+		MaxAllowedBuiltAge:      math.MaxInt32,
+		UpdateCheckMaxFrequency: math.MaxInt32,
+	}, true
+}
+
+// benchmarkPackages returns the given number of language packages, a mix of packages with and without known
+// vulnerabilities
+func benchmarkPackages(count int) []pkg.Package {
+	candidates := []struct {
+		name     string
+		version  string
+		language syftPkg.Language
+		typ      syftPkg.Type
+	}{
+		{"lodash", "4.17.15", syftPkg.JavaScript, syftPkg.NpmPkg},
+		{"minimist", "1.2.0", syftPkg.JavaScript, syftPkg.NpmPkg},
+		{"left-pad", "1.3.0", syftPkg.JavaScript, syftPkg.NpmPkg},
+		{"log4j-core", "2.14.1", syftPkg.Java, syftPkg.JavaPkg},
+		{"jackson-databind", "2.9.8", syftPkg.Java, syftPkg.JavaPkg},
+		{"commons-lang3", "3.12.0", syftPkg.Java, syftPkg.JavaPkg},
+		{"django", "2.2.0", syftPkg.Python, syftPkg.PythonPkg},
+		{"requests", "2.31.0", syftPkg.Python, syftPkg.PythonPkg},
+		{"rails", "5.2.0", syftPkg.Ruby, syftPkg.GemPkg},
+		{"github.com/gin-gonic/gin", "v1.6.0", syftPkg.Go, syftPkg.GoModulePkg},
+	}
+
+	packages := make([]pkg.Package, 0, count)
+	for i := range count {
+		c := candidates[i%len(candidates)]
+		packages = append(packages, pkg.Package{
+			ID:       pkg.ID(fmt.Sprintf("benchmark-%d", i)),
+			Name:     c.name,
+			Version:  c.version,
+			Language: c.language,
+			Type:     c.typ,
+		})
+	}
+	return packages
+}
@@ -0,0 +1,24 @@
+package grype
+
+import (
+	"github.com/anchore/grype/grype/db/v6/osv"
+	"github.com/anchore/grype/grype/vulnerability"
+)
+
+// WithLocalAdvisories loads the OSV JSON and simple YAML advisories within the configured directories and composes
+// them with the given provider, so that vulnerabilities are found from both sources. Vulnerabilities from the
+// advisories are attributed to the configured provider name (reflected in their namespace), and take priority over
+// vulnerabilities with the same reference from the given provider. The returned provider takes ownership of the given
+// provider (closing it when closed).
+func WithLocalAdvisories(base vulnerability.Provider, cfg osv.OverlayConfig) (vulnerability.Provider, error) {
+	if len(cfg.Dirs) == 0 {
+		return base, nil
+	}
+
+	overlay, err := osv.NewOverlay(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	return vulnerability.NewCompositeProvider(overlay, base), nil
+}
@@ -0,0 +1,74 @@
+package grype
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/anchore/grype/grype/db/v6/osv"
+	"github.com/anchore/grype/grype/search"
+	"github.com/anchore/grype/grype/version"
+	"github.com/anchore/grype/grype/vulnerability"
+	"github.com/anchore/grype/grype/vulnerability/mock"
+	syftPkg "github.com/anchore/syft/syft/pkg"
+)
+
+func TestWithLocalAdvisories(t *testing.T) {
+	base := mock.VulnerabilityProvider(vulnerability.Vulnerability{
+		Reference: vulnerability.Reference{
+			ID:        "GHSA-upstream",
+			Namespace: "github:language:javascript",
+			Internal:  vulnerability.Metadata{Severity: "Low"},
+		},
+		PackageName: "acme-widget",
+		Constraint:  version.MustGetConstraint("< 2.0.0", version.UnknownFormat),
+	})
+
+	unchanged, err := WithLocalAdvisories(base, osv.OverlayConfig{})
+	require.NoError(t, err)
+	assert.Same(t, base, unchanged)
+
+	vp, err := WithLocalAdvisories(base, osv.OverlayConfig{Dirs: []string{"db/v6/osv/testdata/advisories"}})
+	require.NoError(t, err)
+	t.Cleanup(func() { require.NoError(t, vp.Close()) })
+
+	vulns, err := vp.FindVulnerabilities(
+		search.ByPackageName("acme-widget"),
+		search.ByEcosystem(syftPkg.JavaScript, syftPkg.NpmPkg),
+		search.ByVersion(*version.NewVersion("1.3.0", version.UnknownFormat)),
+	)
+	require.NoError(t, err)
+
+	namespaces := map[string]string{}
+	for _, v := range vulns {
+		namespaces[v.ID] = v.Namespace
+	}
+	assert.Equal(t, map[string]string{
+		"GHSA-upstream":  "github:language:javascript",
+		"GHSA-test-0001": "local:language:javascript",
+	}, namespaces)
+
+	batch, err := vulnerability.FindVulnerabilitiesBatch(vp, []vulnerability.Criteria{
+		search.ByPackageName("acme-widget"),
+		search.ByEcosystem(syftPkg.JavaScript, syftPkg.NpmPkg),
+		search.ByVersion(*version.NewVersion("1.3.0", version.UnknownFormat)),
+	})
+	require.NoError(t, err)
+	require.Len(t, batch, 1)
+	assert.Len(t, batch[0], 2)
+
+	meta, err := vp.VulnerabilityMetadata(vulnerability.Reference{ID: "GHSA-test-0001", Namespace: "local:language:javascript"})
+	require.NoError(t, err)
+	assert.Equal(t, "High", meta.Severity)
+
+	meta, err = vp.VulnerabilityMetadata(vulnerability.Reference{ID: "GHSA-upstream", Namespace: "github:language:javascript"})
+	require.NoError(t, err)
+	assert.Equal(t, "Low", meta.Severity)
+
+	dpr, ok := vp.(vulnerability.StoreMetadataProvider)
+	require.True(t, ok)
+	provenance, err := dpr.DataProvenance()
+	require.NoError(t, err)
+	assert.Contains(t, provenance, "local")
+}
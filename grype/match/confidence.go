@@ -0,0 +1,53 @@
+package match
+
+import (
+	"fmt"
+	"strings"
+)
+
+const (
+	// ExactDirectMatchConfidence is the confidence of a match on the package itself within its ecosystem or distro
+	ExactDirectMatchConfidence = 1.0
+
+	// ExactIndirectMatchConfidence is the confidence of a match on a package related to the package (e.g. the upstream
+	// source package of a distro package), which may not ship the vulnerable code
+	ExactIndirectMatchConfidence = 0.9
+
+	// DeclaredCPEMatchConfidence is the highest confidence of a match on a CPE that was declared for the package (e.g.
+	// within an SBOM or found in the NVD CPE dictionary), reduced by how specifically the CPE matched
+	DeclaredCPEMatchConfidence = 0.85
+
+	// GeneratedCPEMatchConfidence is the highest confidence of a match on a CPE that was generated from the package
+	// metadata, reduced by how specifically the CPE matched
+	GeneratedCPEMatchConfidence = 0.7
+)
+
+// LowConfidenceReasonPrefix starts the reason given on the synthetic ignore rule attached to matches with a confidence
+// below the configured minimum
+const LowConfidenceReasonPrefix = "low-confidence"
+
+var _ IgnoreFilter = MinConfidence(0)
+
+// MinConfidence is an ignore filter for matches with a confidence below the given minimum (0-1). Matches without a
+// confidence (e.g. those not found by a matcher) are never ignored.
+type MinConfidence float64
+
+// IgnoreMatch returns a synthetic ignore rule when the confidence of the match is below the minimum
+func (c MinConfidence) IgnoreMatch(m Match) []IgnoreRule {
+	confidence := m.Confidence()
+	if confidence == 0 || confidence >= float64(c) {
+		return nil
+	}
+	return []IgnoreRule{
+		{
+			Vulnerability: m.Vulnerability.ID,
+			Reason:        fmt.Sprintf("%s: %.2f is below the minimum of %.2f", LowConfidenceReasonPrefix, confidence, float64(c)),
+		},
+	}
+}
+
+// IsLowConfidenceReason indicates if the given ignore rule reason was given because the confidence of the match was
+// below the configured minimum
+func IsLowConfidenceReason(reason string) bool {
+	return strings.HasPrefix(reason, LowConfidenceReasonPrefix)
+}
@@ -0,0 +1,65 @@
+package match
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/anchore/grype/grype/vulnerability"
+)
+
+func TestMinConfidence(t *testing.T) {
+	newMatch := func(confidences ...float64) Match {
+		m := Match{
+			Vulnerability: vulnerability.Vulnerability{
+				Reference: vulnerability.Reference{ID: "CVE-2025-0001"},
+			},
+		}
+		for _, c := range confidences {
+			m.Details = append(m.Details, Detail{Type: CPEMatch, Confidence: c})
+		}
+		return m
+	}
+
+	tests := []struct {
+		name    string
+		match   Match
+		ignored bool
+	}{
+		{
+			name:  "above the minimum",
+			match: newMatch(0.8),
+		},
+		{
+			name:  "at the minimum",
+			match: newMatch(0.7),
+		},
+		{
+			name:    "below the minimum",
+			match:   newMatch(0.58),
+			ignored: true,
+		},
+		{
+			name:  "highest confidence of all details is considered",
+			match: newMatch(0.3, ExactIndirectMatchConfidence),
+		},
+		{
+			name:  "unknown confidence",
+			match: newMatch(),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rules := MinConfidence(0.7).IgnoreMatch(tt.match)
+			if !tt.ignored {
+				assert.Empty(t, rules)
+				return
+			}
+			if assert.Len(t, rules, 1) {
+				assert.Equal(t, "CVE-2025-0001", rules[0].Vulnerability)
+				assert.Equal(t, "low-confidence: 0.58 is below the minimum of 0.70", rules[0].Reason)
+				assert.True(t, IsLowConfidenceReason(rules[0].Reason))
+			}
+		})
+	}
+}
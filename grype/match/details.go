@@ -14,7 +14,7 @@ type Detail struct {
 	SearchedBy interface{} // The specific attributes that were used to search (other than package name and version) --this indicates "how" the match was made.
 	Found      interface{} // The specific attributes on the vulnerability object that were matched with --this indicates "what" was matched on / within.
 	Matcher    MatcherType // The matcher object that discovered the match.
-	Confidence float64     // The certainty of the match as a ratio (currently unused, reserved for future use).
+	Confidence float64     // The certainty of the match as a ratio (0-1): exact matches are the most certain, while CPE matches are scored by how specifically (and from which CPE source) the CPE matched.
 }
 
 // String is the string representation of select match fields.
@@ -32,6 +32,17 @@ func (m Details) Matchers() (tys []MatcherType) {
 	return tys
 }
 
+// Confidence returns the highest confidence of all details
+func (m Details) Confidence() float64 {
+	var confidence float64
+	for _, d := range m {
+		if d.Confidence > confidence {
+			confidence = d.Confidence
+		}
+	}
+	return confidence
+}
+
 func (m Details) Types() (tys []Type) {
 	if len(m) == 0 {
 		return nil
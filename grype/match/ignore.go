@@ -1,7 +1,9 @@
 package match
 
 import (
+	"fmt"
 	"regexp"
+	"time"
 
 	"github.com/bmatcuk/doublestar/v2"
 
@@ -37,6 +39,10 @@ type IgnoreRule struct {
 	VexStatus        string            `yaml:"vex-status" json:"vex-status" mapstructure:"vex-status"`
 	VexJustification string            `yaml:"vex-justification" json:"vex-justification" mapstructure:"vex-justification"`
 	MatchType        Type              `yaml:"match-type" json:"match-type" mapstructure:"match-type"`
+	Expires          string            `yaml:"expires" json:"expires" mapstructure:"expires"` // a date (e.g. 2024-12-31) or RFC3339 timestamp, which must be quoted in YAML
+	Owner            string            `yaml:"owner" json:"owner" mapstructure:"owner"`
+	Ticket           string            `yaml:"ticket" json:"ticket" mapstructure:"ticket"`
+	ExpiresAt        *time.Time        `yaml:"-" json:"-" mapstructure:"-"` // the time the rule stops applying, as parsed from Expires by ParseExpiration
 }
 
 // IgnoreRulePackage describes the Package-specific fields that comprise the IgnoreRule.
@@ -94,6 +100,10 @@ func (r IgnoreRule) IgnoreMatch(match Match) []IgnoreRule {
 		return nil
 	}
 
+	if r.IsExpired(time.Now()) {
+		return nil
+	}
+
 	ignoreConditions := getIgnoreConditionsForRule(r)
 	if len(ignoreConditions) == 0 {
 		// this rule specifies no criteria, so it doesn't apply to the Match
@@ -111,6 +121,52 @@ func (r IgnoreRule) IgnoreMatch(match Match) []IgnoreRule {
 	return []IgnoreRule{r}
 }
 
+// ParseExpiration parses the expires value of the rule once (e.g. when the configuration is loaded), returning an
+// error if it is invalid, so that it is not parsed again each time the rule is applied.
+func (r *IgnoreRule) ParseExpiration() error {
+	expiration, err := parseExpiration(r.Expires)
+	if err != nil {
+		return err
+	}
+	r.ExpiresAt = &expiration
+	return nil
+}
+
+// Expiration returns the time at which the rule stops applying, or the zero time if the rule does not expire.
+// The expires value may be a date (e.g. 2024-12-31), in which case the rule applies through the end of that
+// day (UTC), or an RFC3339 timestamp.
+func (r IgnoreRule) Expiration() (time.Time, error) {
+	if r.ExpiresAt != nil {
+		return *r.ExpiresAt, nil
+	}
+	return parseExpiration(r.Expires)
+}
+
+func parseExpiration(expires string) (time.Time, error) {
+	if expires == "" {
+		return time.Time{}, nil
+	}
+	if t, err := time.Parse(time.DateOnly, expires); err == nil {
+		return t.AddDate(0, 0, 1), nil
+	}
+	t, err := time.Parse(time.RFC3339, expires)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid expires value %q (expected a date like 2006-01-02 or an RFC3339 timestamp)", expires)
+	}
+	return t, nil
+}
+
+// IsExpired indicates if the rule has expired as of the given time. Rules with an expiration that cannot be
+// parsed are considered to be expired, so that a typo never silently extends a suppression.
+func (r IgnoreRule) IsExpired(now time.Time) bool {
+	expiration, err := r.Expiration()
+	if err != nil {
+		log.WithFields("error", err).Debug("unable to determine ignore rule expiration")
+		return true
+	}
+	return !expiration.IsZero() && !now.Before(expiration)
+}
+
 // HasConditions returns true if the ignore rule has conditions
 // that can cause a match to be ignored
 func (r IgnoreRule) HasConditions() bool {
@@ -2,9 +2,11 @@ package match
 
 import (
 	"testing"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	"github.com/anchore/grype/grype/pkg"
 	"github.com/anchore/grype/grype/vulnerability"
@@ -925,6 +927,33 @@ func TestShouldIgnore(t *testing.T) {
 			},
 			expected: true,
 		},
+		{
+			name:  "rule applies before it expires",
+			match: exampleMatch,
+			rule: IgnoreRule{
+				Vulnerability: exampleMatch.Vulnerability.ID,
+				Expires:       "2999-01-01",
+			},
+			expected: true,
+		},
+		{
+			name:  "expired rule doesn't apply",
+			match: exampleMatch,
+			rule: IgnoreRule{
+				Vulnerability: exampleMatch.Vulnerability.ID,
+				Expires:       "2020-01-01",
+			},
+			expected: false,
+		},
+		{
+			name:  "rule with invalid expiration doesn't apply",
+			match: exampleMatch,
+			rule: IgnoreRule{
+				Vulnerability: exampleMatch.Vulnerability.ID,
+				Expires:       "next tuesday",
+			},
+			expected: false,
+		},
 		{
 			name:  "rule doesn't apply despite some fields matching",
 			match: exampleMatch,
@@ -946,3 +975,62 @@ func TestShouldIgnore(t *testing.T) {
 		})
 	}
 }
+
+func TestIgnoreRule_Expiration(t *testing.T) {
+	now := time.Date(2024, 6, 15, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name        string
+		expires     string
+		want        time.Time
+		wantExpired bool
+		wantErr     require.ErrorAssertionFunc
+	}{
+		{
+			name: "no expiration",
+		},
+		{
+			name:    "date applies through the end of the day",
+			expires: "2024-06-15",
+			want:    time.Date(2024, 6, 16, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:        "past date",
+			expires:     "2024-06-14",
+			want:        time.Date(2024, 6, 15, 0, 0, 0, 0, time.UTC),
+			wantExpired: true,
+		},
+		{
+			name:        "timestamp",
+			expires:     "2024-06-15T11:00:00Z",
+			want:        time.Date(2024, 6, 15, 11, 0, 0, 0, time.UTC),
+			wantExpired: true,
+		},
+		{
+			name:        "invalid",
+			expires:     "soon",
+			wantExpired: true,
+			wantErr:     require.Error,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.wantErr == nil {
+				tt.wantErr = require.NoError
+			}
+			r := IgnoreRule{Expires: tt.expires}
+
+			got, err := r.Expiration()
+			tt.wantErr(t, err)
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.wantExpired, r.IsExpired(now))
+
+			// the expiration is the same once parsed up front
+			tt.wantErr(t, r.ParseExpiration())
+			got, err = r.Expiration()
+			tt.wantErr(t, err)
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.wantExpired, r.IsExpired(now))
+		})
+	}
+}
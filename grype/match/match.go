@@ -26,6 +26,11 @@ func (m Match) String() string {
 	return fmt.Sprintf("Match(pkg=%s vuln=%q types=%q)", m.Package, m.Vulnerability.String(), m.Details.Types())
 }
 
+// Confidence is the highest confidence (0-1) of all the ways the match was made, or 0 when unknown.
+func (m Match) Confidence() float64 {
+	return m.Details.Confidence()
+}
+
 func (m Match) Fingerprint() Fingerprint {
 	return Fingerprint{
 		coreFingerprint: coreFingerprint{
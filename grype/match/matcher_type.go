@@ -1,22 +1,24 @@
 package match
 
 const (
-	UnknownMatcherType MatcherType = "UnknownMatcherType"
-	StockMatcher       MatcherType = "stock-matcher"
-	ApkMatcher         MatcherType = "apk-matcher"
-	RubyGemMatcher     MatcherType = "ruby-gem-matcher"
-	DpkgMatcher        MatcherType = "dpkg-matcher"
-	RpmMatcher         MatcherType = "rpm-matcher"
-	JavaMatcher        MatcherType = "java-matcher"
-	PythonMatcher      MatcherType = "python-matcher"
-	DotnetMatcher      MatcherType = "dotnet-matcher"
-	JavascriptMatcher  MatcherType = "javascript-matcher"
-	MsrcMatcher        MatcherType = "msrc-matcher"
-	PortageMatcher     MatcherType = "portage-matcher"
-	GoModuleMatcher    MatcherType = "go-module-matcher"
-	OpenVexMatcher     MatcherType = "openvex-matcher"
-	RustMatcher        MatcherType = "rust-matcher"
-	BitnamiMatcher     MatcherType = "bitnami-matcher"
+	UnknownMatcherType  MatcherType = "UnknownMatcherType"
+	StockMatcher        MatcherType = "stock-matcher"
+	ApkMatcher          MatcherType = "apk-matcher"
+	RubyGemMatcher      MatcherType = "ruby-gem-matcher"
+	DpkgMatcher         MatcherType = "dpkg-matcher"
+	RpmMatcher          MatcherType = "rpm-matcher"
+	JavaMatcher         MatcherType = "java-matcher"
+	PythonMatcher       MatcherType = "python-matcher"
+	DotnetMatcher       MatcherType = "dotnet-matcher"
+	JavascriptMatcher   MatcherType = "javascript-matcher"
+	MsrcMatcher         MatcherType = "msrc-matcher"
+	PortageMatcher      MatcherType = "portage-matcher"
+	GoModuleMatcher     MatcherType = "go-module-matcher"
+	OpenVexMatcher      MatcherType = "openvex-matcher"
+	CsafVexMatcher      MatcherType = "csaf-vex-matcher"
+	CycloneDXVexMatcher MatcherType = "cyclonedx-vex-matcher"
+	RustMatcher         MatcherType = "rust-matcher"
+	BitnamiMatcher      MatcherType = "bitnami-matcher"
 )
 
 var AllMatcherTypes = []MatcherType{
@@ -32,6 +34,8 @@ var AllMatcherTypes = []MatcherType{
 	PortageMatcher,
 	GoModuleMatcher,
 	OpenVexMatcher,
+	CsafVexMatcher,
+	CycloneDXVexMatcher,
 	RustMatcher,
 	BitnamiMatcher,
 }
@@ -28,6 +28,7 @@ func ConvertToIndirectMatches(matches []Match, p pkg.Package) {
 			// only override the match details to "indirect" if the match details are explicitly indicate a "direct" match
 			if matches[idx].Details[dIdx].Type == ExactDirectMatch {
 				matches[idx].Details[dIdx].Type = ExactIndirectMatch
+				matches[idx].Details[dIdx].Confidence = ExactIndirectMatchConfidence
 			}
 		}
 		// we always override the package to the direct package
@@ -355,7 +355,7 @@ func TestNvdOnlyMatches(t *testing.T) {
 			Details: []match.Detail{
 				{
 					Type:       match.CPEMatch,
-					Confidence: 0.9,
+					Confidence: 0.57,
 					SearchedBy: match.CPEParameters{
 						CPEs:      []string{"cpe:2.3:a:*:lib\\/vncserver:0.9.9:*:*:*:*:*:*:*"},
 						Namespace: "nvd:cpe",
@@ -426,7 +426,7 @@ func TestNvdOnlyMatches_FixInNvd(t *testing.T) {
 			Details: []match.Detail{
 				{
 					Type:       match.CPEMatch,
-					Confidence: 0.9,
+					Confidence: 0.57,
 					SearchedBy: match.CPEParameters{
 						CPEs:      []string{"cpe:2.3:a:*:libvncserver:0.9.9:*:*:*:*:*:*:*"},
 						Namespace: "nvd:cpe",
@@ -498,7 +498,7 @@ func TestNvdMatchesProperVersionFiltering(t *testing.T) {
 			Details: []match.Detail{
 				{
 					Type:       match.CPEMatch,
-					Confidence: 0.9,
+					Confidence: 0.57,
 					SearchedBy: match.CPEParameters{
 						CPEs:      []string{"cpe:2.3:a:*:libvncserver:0.9.11:*:*:*:*:*:*:*"},
 						Namespace: "nvd:cpe",
@@ -706,7 +706,7 @@ func TestDistroMatchBySourceIndirection(t *testing.T) {
 			Details: []match.Detail{
 				{
 					Type:       match.ExactIndirectMatch,
-					Confidence: 1.0,
+					Confidence: 0.9,
 					SearchedBy: match.DistroParameters{
 						Distro: match.DistroIdentification{
 							Type:    d.Type.String(),
@@ -774,7 +774,7 @@ func TestSecDBMatchesStillCountedWithCpeErrors(t *testing.T) {
 			Details: []match.Detail{
 				{
 					Type:       match.ExactIndirectMatch,
-					Confidence: 1.0,
+					Confidence: 0.9,
 					SearchedBy: match.DistroParameters{
 						Distro: match.DistroIdentification{
 							Type:    d.Type.String(),
@@ -843,7 +843,7 @@ func TestNVDMatchBySourceIndirection(t *testing.T) {
 			Details: []match.Detail{
 				{
 					Type:       match.CPEMatch,
-					Confidence: 0.9,
+					Confidence: 0.71,
 					SearchedBy: match.CPEParameters{
 						CPEs:      []string{"cpe:2.3:a:musl:musl:1.3.2-r0:*:*:*:*:*:*:*"},
 						Namespace: "nvd:cpe",
@@ -3,6 +3,7 @@ package internal
 import (
 	"errors"
 	"fmt"
+	"math"
 	"sort"
 	"strings"
 
@@ -132,7 +133,7 @@ func addNewMatch(matchesByFingerprint map[match.Fingerprint]match.Match, vuln vu
 func CPEMatchDetails(matcherType match.MatcherType, vuln vulnerability.Vulnerability, searchedByCPE cpe.CPE, p pkg.Package, searchVersion *version.Version) match.Detail {
 	return match.Detail{
 		Type:       match.CPEMatch,
-		Confidence: 0.9, // TODO: this is hard coded for now
+		Confidence: cpeMatchConfidence(searchedByCPE, vuln.CPEs),
 		Matcher:    matcherType,
 		SearchedBy: match.CPEParameters{
 			Namespace: vuln.Namespace,
@@ -153,6 +154,60 @@ func CPEMatchDetails(matcherType match.MatcherType, vuln vulnerability.Vulnerabi
 	}
 }
 
+// cpeMatchAttributes are the attributes that a CPE match is scored by. All other attributes are rarely given, so are
+// rarely a sign of a more (or less) specific match.
+var cpeMatchAttributes = []func(cpe.Attributes) string{
+	func(a cpe.Attributes) string { return a.Vendor },
+	func(a cpe.Attributes) string { return a.Product },
+	func(a cpe.Attributes) string { return a.TargetSW },
+}
+
+// cpeMatchConfidence scores a match on the searched CPE by how specifically it matches the CPEs of the vulnerability:
+// each of the vendor, product, and target software attributes counts fully when given by both CPEs (and equal), and
+// half when either CPE allows any value. The score is relative to the highest confidence for the source of the
+// searched CPE, since CPEs generated from package metadata are more often wrong than declared CPEs.
+func cpeMatchConfidence(searched cpe.CPE, vulnCPEs []cpe.CPE) float64 {
+	highest := match.DeclaredCPEMatchConfidence
+	if searched.Source == cpe.GeneratedSource {
+		highest = match.GeneratedCPEMatchConfidence
+	}
+
+	var best float64
+	for _, c := range vulnCPEs {
+		var score float64
+		for _, attribute := range cpeMatchAttributes {
+			s, v := attribute(searched.Attributes), attribute(c.Attributes)
+			switch {
+			case isAnyCPEValue(s) || isAnyCPEValue(v):
+				score += 0.5
+			case strings.EqualFold(s, v):
+				score++
+			default:
+				// the CPE does not match this vulnerability CPE
+				score = -1
+			}
+			if score < 0 {
+				break
+			}
+		}
+		if score > best {
+			best = score
+		}
+	}
+
+	if best == 0 {
+		// the vulnerability does not describe the matching CPE
+		return highest / 2
+	}
+
+	// round to avoid noise from floating point arithmetic in reports
+	return math.Round(highest*best/float64(len(cpeMatchAttributes))*100) / 100
+}
+
+func isAnyCPEValue(v string) bool {
+	return v == "" || v == wfn.Any || v == wfn.NA
+}
+
 func addMatchDetails(existingDetails []match.Detail, newDetails match.Detail) []match.Detail {
 	newFound, ok := newDetails.Found.(match.CPEResult)
 	if !ok {
@@ -184,6 +239,8 @@ func addMatchDetails(existingDetails []match.Detail, newDetails match.Detail) []
 		}
 
 		existingDetails[idx].SearchedBy = searchedBy
+		// the merged detail is as certain as the most certain of the CPEs searched by
+		existingDetails[idx].Confidence = max(existingDetails[idx].Confidence, newDetails.Confidence)
 		return existingDetails
 	}
 
@@ -144,7 +144,7 @@ func TestFindMatchesByPackageCPE(t *testing.T) {
 					Details: []match.Detail{
 						{
 							Type:       match.CPEMatch,
-							Confidence: 0.9,
+							Confidence: 0.85,
 							SearchedBy: match.CPEParameters{
 								Namespace: "nvd:cpe",
 								CPEs:      []string{"cpe:2.3:*:activerecord:activerecord:3.7.5:rando4:*:re:*:rails:*:*"},
@@ -195,7 +195,7 @@ func TestFindMatchesByPackageCPE(t *testing.T) {
 					Details: []match.Detail{
 						{
 							Type:       match.CPEMatch,
-							Confidence: 0.9,
+							Confidence: 0.85,
 							SearchedBy: match.CPEParameters{
 								Namespace: "nvd:cpe",
 								CPEs:      []string{"cpe:2.3:*:activerecord:activerecord:3.7.5:rando4:*:re:*:rails:*:*"},
@@ -247,7 +247,7 @@ func TestFindMatchesByPackageCPE(t *testing.T) {
 					Details: []match.Detail{
 						{
 							Type:       match.CPEMatch,
-							Confidence: 0.9,
+							Confidence: 0.85,
 							SearchedBy: match.CPEParameters{
 								CPEs: []string{
 									"cpe:2.3:*:activerecord:activerecord:*:rando4:*:re:*:rails:*:*", //important!
@@ -286,7 +286,7 @@ func TestFindMatchesByPackageCPE(t *testing.T) {
 					Details: []match.Detail{
 						{
 							Type:       match.CPEMatch,
-							Confidence: 0.9,
+							Confidence: 0.85,
 							SearchedBy: match.CPEParameters{
 								CPEs:      []string{"cpe:2.3:*:activerecord:activerecord:*:rando1:*:ra:*:ruby:*:*"}, //important!
 								Namespace: "nvd:cpe",
@@ -322,7 +322,7 @@ func TestFindMatchesByPackageCPE(t *testing.T) {
 					Details: []match.Detail{
 						{
 							Type:       match.CPEMatch,
-							Confidence: 0.9,
+							Confidence: 0.71,
 							SearchedBy: match.CPEParameters{
 								CPEs: []string{
 									"cpe:2.3:*:activerecord:activerecord:*:rando1:*:ra:*:ruby:*:*",  //important!
@@ -391,7 +391,7 @@ func TestFindMatchesByPackageCPE(t *testing.T) {
 					Details: []match.Detail{
 						{
 							Type:       match.CPEMatch,
-							Confidence: 0.9,
+							Confidence: 0.85,
 							SearchedBy: match.CPEParameters{
 								CPEs: []string{
 									"cpe:2.3:*:activerecord:activerecord:3.7.3:rando4:*:re:*:rails:*:*",
@@ -430,7 +430,7 @@ func TestFindMatchesByPackageCPE(t *testing.T) {
 					Details: []match.Detail{
 						{
 							Type:       match.CPEMatch,
-							Confidence: 0.9,
+							Confidence: 0.85,
 							SearchedBy: match.CPEParameters{
 								CPEs:      []string{"cpe:2.3:*:activerecord:activerecord:3.7.3:rando1:*:ra:*:ruby:*:*"},
 								Namespace: "nvd:cpe",
@@ -479,7 +479,7 @@ func TestFindMatchesByPackageCPE(t *testing.T) {
 					Details: []match.Detail{
 						{
 							Type:       match.CPEMatch,
-							Confidence: 0.9,
+							Confidence: 0.57,
 							SearchedBy: match.CPEParameters{
 								CPEs:      []string{"cpe:2.3:*:*:activerecord:4.0.1:*:*:*:*:*:*:*"},
 								Namespace: "nvd:cpe",
@@ -539,7 +539,7 @@ func TestFindMatchesByPackageCPE(t *testing.T) {
 					Details: []match.Detail{
 						{
 							Type:       match.CPEMatch,
-							Confidence: 0.9,
+							Confidence: 0.71,
 							SearchedBy: match.CPEParameters{
 								CPEs:      []string{"cpe:2.3:*:awesome:awesome:98SE1:rando1:*:ra:*:dunno:*:*"},
 								Namespace: "nvd:cpe",
@@ -589,7 +589,7 @@ func TestFindMatchesByPackageCPE(t *testing.T) {
 					Details: []match.Detail{
 						{
 							Type:       match.CPEMatch,
-							Confidence: 0.9,
+							Confidence: 0.71,
 							SearchedBy: match.CPEParameters{
 								CPEs:      []string{"cpe:2.3:*:multiple:multiple:1.0:*:*:*:*:*:*:*"},
 								Namespace: "nvd:cpe",
@@ -653,7 +653,7 @@ func TestFindMatchesByPackageCPE(t *testing.T) {
 					Details: []match.Detail{
 						{
 							Type:       match.CPEMatch,
-							Confidence: 0.9,
+							Confidence: 0.71,
 							SearchedBy: match.CPEParameters{
 								CPEs:      []string{"cpe:2.3:*:sw:sw:0.1:*:*:*:*:*:*:*"},
 								Namespace: "nvd:cpe",
@@ -709,7 +709,7 @@ func TestFindMatchesByPackageCPE(t *testing.T) {
 					Details: []match.Detail{
 						{
 							Type:       match.CPEMatch,
-							Confidence: 0.9,
+							Confidence: 0.85,
 							SearchedBy: match.CPEParameters{
 								CPEs:      []string{"cpe:2.3:*:funfun:funfun:5.2.1:*:*:*:*:python:*:*"},
 								Namespace: "nvd:cpe",
@@ -760,7 +760,7 @@ func TestFindMatchesByPackageCPE(t *testing.T) {
 					Details: []match.Detail{
 						{
 							Type:       match.CPEMatch,
-							Confidence: 0.9,
+							Confidence: 0.71,
 							SearchedBy: match.CPEParameters{
 								CPEs:      []string{"cpe:2.3:a:handlebarsjs:handlebars:0.1:*:*:*:*:*:*:*"},
 								Namespace: "nvd:cpe",
@@ -810,7 +810,7 @@ func TestFindMatchesByPackageCPE(t *testing.T) {
 					Details: []match.Detail{
 						{
 							Type:       match.CPEMatch,
-							Confidence: 0.9,
+							Confidence: 0.71,
 							SearchedBy: match.CPEParameters{
 								CPEs:      []string{"cpe:2.3:a:handlebarsjs:handlebars:0.1:*:*:*:*:*:*:*"},
 								Namespace: "nvd:cpe",
@@ -860,7 +860,7 @@ func TestFindMatchesByPackageCPE(t *testing.T) {
 					Details: []match.Detail{
 						{
 							Type:       match.CPEMatch,
-							Confidence: 0.9,
+							Confidence: 0.71,
 							SearchedBy: match.CPEParameters{
 								CPEs:      []string{"cpe:2.3:a:handlebarsjs:handlebars:0.1:*:*:*:*:*:*:*"},
 								Namespace: "nvd:cpe",
@@ -910,7 +910,7 @@ func TestFindMatchesByPackageCPE(t *testing.T) {
 					Details: []match.Detail{
 						{
 							Type:       match.CPEMatch,
-							Confidence: 0.9,
+							Confidence: 0.71,
 							SearchedBy: match.CPEParameters{
 								CPEs:      []string{"cpe:2.3:a:handlebarsjs:handlebars:0.1:*:*:*:*:*:*:*"},
 								Namespace: "nvd:cpe",
@@ -973,7 +973,7 @@ func TestFindMatchesByPackageCPE(t *testing.T) {
 					Details: []match.Detail{
 						{
 							Type:       match.CPEMatch,
-							Confidence: 0.9,
+							Confidence: 0.71,
 							SearchedBy: match.CPEParameters{
 								CPEs:      []string{"cpe:2.3:a:handlebarsjs:handlebars:0.1:*:*:*:*:*:*:*"},
 								Namespace: "nvd:cpe",
@@ -1018,6 +1018,55 @@ func TestFindMatchesByPackageCPE(t *testing.T) {
 	}
 }
 
+func TestCPEMatchConfidence(t *testing.T) {
+	tests := []struct {
+		name     string
+		searched cpe.CPE
+		vulnCPEs []cpe.CPE
+		expected float64
+	}{
+		{
+			name:     "declared CPE with all attributes matching",
+			searched: cpe.Must("cpe:2.3:a:acme:widget:1.0.0:*:*:*:*:node.js:*:*", cpe.DeclaredSource),
+			vulnCPEs: []cpe.CPE{cpe.Must("cpe:2.3:a:acme:widget:*:*:*:*:*:node.js:*:*", "")},
+			expected: match.DeclaredCPEMatchConfidence,
+		},
+		{
+			name:     "generated CPE with all attributes matching",
+			searched: cpe.Must("cpe:2.3:a:acme:widget:1.0.0:*:*:*:*:node.js:*:*", cpe.GeneratedSource),
+			vulnCPEs: []cpe.CPE{cpe.Must("cpe:2.3:a:acme:widget:*:*:*:*:*:node.js:*:*", "")},
+			expected: match.GeneratedCPEMatchConfidence,
+		},
+		{
+			name:     "generated CPE matching any target software",
+			searched: cpe.Must("cpe:2.3:a:acme:widget:1.0.0:*:*:*:*:*:*:*", cpe.GeneratedSource),
+			vulnCPEs: []cpe.CPE{cpe.Must("cpe:2.3:a:acme:widget:*:*:*:*:*:node.js:*:*", "")},
+			expected: 0.58,
+		},
+		{
+			name:     "most specific vulnerability CPE is scored",
+			searched: cpe.Must("cpe:2.3:a:acme:widget:1.0.0:*:*:*:*:node.js:*:*", cpe.DeclaredSource),
+			vulnCPEs: []cpe.CPE{
+				cpe.Must("cpe:2.3:a:*:widget:*:*:*:*:*:*:*:*", ""),
+				cpe.Must("cpe:2.3:a:acme:widget:*:*:*:*:*:*:*:*", ""),
+				cpe.Must("cpe:2.3:a:other:widget:*:*:*:*:*:node.js:*:*", ""),
+			},
+			expected: 0.71,
+		},
+		{
+			name:     "no matching vulnerability CPE",
+			searched: cpe.Must("cpe:2.3:a:acme:widget:1.0.0:*:*:*:*:*:*:*", cpe.GeneratedSource),
+			vulnCPEs: []cpe.CPE{cpe.Must("cpe:2.3:a:other:widget:*:*:*:*:*:*:*:*", "")},
+			expected: match.GeneratedCPEMatchConfidence / 2,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, cpeMatchConfidence(tt.searched, tt.vulnCPEs))
+		})
+	}
+}
+
 func TestFilterCPEsByVersion(t *testing.T) {
 	tests := []struct {
 		name              string
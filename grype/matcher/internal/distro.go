@@ -51,9 +51,9 @@ func matchPackage(searchPkg pkg.Package, catalogPkg *pkg.Package) pkg.Package {
 }
 
 func distroMatchDetails(upstreamMatcher match.MatcherType, searchPkg pkg.Package, catalogPkg *pkg.Package, vuln vulnerability.Vulnerability) []match.Detail {
-	ty := match.ExactIndirectMatch
+	ty, confidence := match.ExactIndirectMatch, match.ExactIndirectMatchConfidence
 	if catalogPkg == nil {
-		ty = match.ExactDirectMatch
+		ty, confidence = match.ExactDirectMatch, match.ExactDirectMatchConfidence
 	}
 
 	return []match.Detail{
@@ -75,7 +75,7 @@ func distroMatchDetails(upstreamMatcher match.MatcherType, searchPkg pkg.Package
 				VulnerabilityID:   vuln.ID,
 				VersionConstraint: vuln.Constraint.String(),
 			},
-			Confidence: 1.0, // TODO: this is hard coded for now
+			Confidence: confidence,
 		},
 	}
 }
@@ -52,7 +52,7 @@ func MatchPackageByEcosystemPackageName(provider vulnerability.Provider, p pkg.P
 			Details: []match.Detail{
 				{
 					Type:       match.ExactDirectMatch,
-					Confidence: 1.0, // TODO: this is hard coded for now
+					Confidence: match.ExactDirectMatchConfidence,
 					Matcher:    matcherType,
 					SearchedBy: match.EcosystemParameters{
 						Language:  string(p.Language),
@@ -0,0 +1,144 @@
+package pkg
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/bmatcuk/doublestar/v2"
+
+	"github.com/anchore/grype/internal/log"
+	"github.com/anchore/syft/syft/cpe"
+	syftPkg "github.com/anchore/syft/syft/pkg"
+)
+
+// UserConfiguredCPESource is the source of CPEs that were assigned to a package by a CPEOverride
+const UserConfiguredCPESource cpe.Source = "user-configured"
+
+// CPEConfig describes how the CPEs of provided packages are to be amended before matching
+type CPEConfig struct {
+	Overrides []CPEOverride // explicit CPEs for the packages selected by each override, applied in order
+	Deny      []CPEDenial   // CPEs that are removed from every package, so are never used for matching
+}
+
+// CPEOverride assigns explicit CPEs to the packages it selects. All criteria that are set must be met for a
+// package to be selected, and at least one criteria must be set.
+type CPEOverride struct {
+	Type        string   // the package type (e.g. "java-archive")
+	NamePattern string   // a regular expression the package name must match
+	PURLPattern string   // a glob the package URL must match ("*" does not match "/", "**" does)
+	CPEs        []string // the CPEs to assign; a CPE without a version takes the version of the package
+	Replace     bool     // replace the CPEs of the package instead of adding to them
+}
+
+// CPEDenial selects CPEs by vendor and product; an empty (or "*") vendor or product matches any value
+type CPEDenial struct {
+	Vendor  string
+	Product string
+}
+
+type cpeOverride struct {
+	CPEOverride
+	name *regexp.Regexp
+	cpes []cpe.CPE
+}
+
+// Validate returns an error for overrides that select no packages, or invalid patterns and CPEs
+func (c CPEConfig) Validate() error {
+	_, err := c.compile()
+	return err
+}
+
+func (c CPEConfig) compile() ([]cpeOverride, error) {
+	var out []cpeOverride
+	for i, o := range c.Overrides {
+		if o.Type == "" && o.NamePattern == "" && o.PURLPattern == "" {
+			return nil, fmt.Errorf("CPE override %d: at least one of a type, name or PURL pattern is required", i)
+		}
+		compiled := cpeOverride{CPEOverride: o}
+		if o.NamePattern != "" {
+			re, err := regexp.Compile(o.NamePattern)
+			if err != nil {
+				return nil, fmt.Errorf("CPE override %d: invalid name pattern: %w", i, err)
+			}
+			compiled.name = re
+		}
+		if o.PURLPattern != "" {
+			// a pattern is only parsed as far as it is matched, so it is matched against itself to validate it all
+			if _, err := doublestar.Match(o.PURLPattern, o.PURLPattern); err != nil {
+				return nil, fmt.Errorf("CPE override %d: invalid PURL pattern: %w", i, err)
+			}
+		}
+		for _, raw := range o.CPEs {
+			parsed, err := cpe.New(raw, UserConfiguredCPESource)
+			if err != nil {
+				return nil, fmt.Errorf("CPE override %d: %w", i, err)
+			}
+			compiled.cpes = append(compiled.cpes, parsed)
+		}
+		out = append(out, compiled)
+	}
+	return out, nil
+}
+
+func (o cpeOverride) selects(p Package) bool {
+	if o.Type != "" && syftPkg.Type(o.Type) != p.Type {
+		return false
+	}
+	if o.name != nil && !o.name.MatchString(p.Name) {
+		return false
+	}
+	if o.PURLPattern != "" {
+		matched, err := doublestar.Match(o.PURLPattern, p.PURL)
+		if err != nil || !matched {
+			return false
+		}
+	}
+	return true
+}
+
+func (d CPEDenial) denies(c cpe.CPE) bool {
+	return matchesCPEValue(d.Vendor, c.Attributes.Vendor) && matchesCPEValue(d.Product, c.Attributes.Product)
+}
+
+func matchesCPEValue(denied, value string) bool {
+	return denied == "" || denied == "*" || strings.EqualFold(denied, value)
+}
+
+// applyCPEConfig assigns the CPEs of all overrides that select each package, then removes denied CPEs
+func applyCPEConfig(packages []Package, config CPEConfig) ([]Package, error) {
+	if len(config.Overrides) == 0 && len(config.Deny) == 0 {
+		return packages, nil
+	}
+
+	overrides, err := config.compile()
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range packages {
+		p := &packages[i]
+		// the CPEs may be shared with the syft package the package was created from, so are never modified in place
+		cpes := slices.Clone(p.CPEs)
+		for _, o := range overrides {
+			if !o.selects(*p) {
+				continue
+			}
+			log.WithFields("package", p.Name, "cpes", o.CPEs, "replace", o.Replace).Trace("applying CPE override")
+			if o.Replace {
+				cpes = nil
+			}
+			for _, c := range o.cpes {
+				if !slices.ContainsFunc(cpes, func(existing cpe.CPE) bool { return existing.Attributes == c.Attributes }) {
+					cpes = append(cpes, c)
+				}
+			}
+		}
+
+		p.CPEs = slices.DeleteFunc(cpes, func(c cpe.CPE) bool {
+			return slices.ContainsFunc(config.Deny, func(d CPEDenial) bool { return d.denies(c) })
+		})
+	}
+	return packages, nil
+}
@@ -0,0 +1,155 @@
+package pkg
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/anchore/syft/syft/cpe"
+	syftPkg "github.com/anchore/syft/syft/pkg"
+)
+
+func TestCPEConfig_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  CPEConfig
+		wantErr require.ErrorAssertionFunc
+	}{
+		{
+			name: "valid",
+			config: CPEConfig{
+				Overrides: []CPEOverride{
+					{Type: "java-archive", NamePattern: "^acme-.*$", CPEs: []string{"cpe:2.3:a:apache:log4j:*:*:*:*:*:*:*:*"}},
+					{PURLPattern: "pkg:generic/**", CPEs: []string{"cpe:/a:zlib:zlib"}},
+				},
+				Deny: []CPEDenial{{Vendor: "apache"}},
+			},
+		},
+		{
+			name:    "no selector",
+			config:  CPEConfig{Overrides: []CPEOverride{{CPEs: []string{"cpe:2.3:a:zlib:zlib:*:*:*:*:*:*:*:*"}}}},
+			wantErr: require.Error,
+		},
+		{
+			name:    "bad name pattern",
+			config:  CPEConfig{Overrides: []CPEOverride{{NamePattern: "acme-(", CPEs: []string{"cpe:2.3:a:zlib:zlib:*:*:*:*:*:*:*:*"}}}},
+			wantErr: require.Error,
+		},
+		{
+			name:    "bad PURL pattern",
+			config:  CPEConfig{Overrides: []CPEOverride{{PURLPattern: "pkg:generic/[", CPEs: []string{"cpe:2.3:a:zlib:zlib:*:*:*:*:*:*:*:*"}}}},
+			wantErr: require.Error,
+		},
+		{
+			name:    "bad CPE",
+			config:  CPEConfig{Overrides: []CPEOverride{{Type: "binary", CPEs: []string{"zlib"}}}},
+			wantErr: require.Error,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.wantErr == nil {
+				tt.wantErr = require.NoError
+			}
+			tt.wantErr(t, tt.config.Validate())
+		})
+	}
+}
+
+func Test_applyCPEConfig(t *testing.T) {
+	generated := cpe.Must("cpe:2.3:a:acme:acme-logging:1.2.3:*:*:*:*:*:*:*", cpe.GeneratedSource)
+	declared := cpe.Must("cpe:2.3:a:apache:tomcat:9.0.1:*:*:*:*:*:*:*", cpe.DeclaredSource)
+	log4j := cpe.Must("cpe:2.3:a:apache:log4j:*:*:*:*:*:*:*:*", UserConfiguredCPESource)
+	zlib := cpe.Must("cpe:2.3:a:zlib:zlib:*:*:*:*:*:*:*:*", UserConfiguredCPESource)
+
+	newPackages := func() []Package {
+		return []Package{
+			{Name: "acme-logging", Type: syftPkg.JavaPkg, PURL: "pkg:maven/com.acme/acme-logging@1.2.3", CPEs: []cpe.CPE{generated}},
+			{Name: "tomcat-embed", Type: syftPkg.JavaPkg, PURL: "pkg:maven/org.apache.tomcat/tomcat-embed@9.0.1", CPEs: []cpe.CPE{declared}},
+			{Name: "zlib", Type: syftPkg.BinaryPkg, PURL: "pkg:generic/vendored/zlib@1.2.11"},
+		}
+	}
+
+	tests := []struct {
+		name   string
+		config CPEConfig
+		want   map[string][]cpe.CPE
+	}{
+		{
+			name: "no configuration",
+			want: map[string][]cpe.CPE{
+				"acme-logging": {generated},
+				"tomcat-embed": {declared},
+				"zlib":         nil,
+			},
+		},
+		{
+			name: "replace by type and name",
+			config: CPEConfig{Overrides: []CPEOverride{
+				{Type: "java-archive", NamePattern: "^acme-", CPEs: []string{"cpe:2.3:a:apache:log4j:*:*:*:*:*:*:*:*"}, Replace: true},
+			}},
+			want: map[string][]cpe.CPE{
+				"acme-logging": {log4j},
+				"tomcat-embed": {declared},
+				"zlib":         nil,
+			},
+		},
+		{
+			name: "augment by PURL, without duplicates",
+			config: CPEConfig{Overrides: []CPEOverride{
+				{PURLPattern: "pkg:generic/**/zlib@*", CPEs: []string{"cpe:2.3:a:zlib:zlib:*:*:*:*:*:*:*:*"}},
+				{PURLPattern: "pkg:maven/**", CPEs: []string{"cpe:2.3:a:zlib:zlib:*:*:*:*:*:*:*:*"}},
+				{Type: "binary", CPEs: []string{"cpe:2.3:a:zlib:zlib:*:*:*:*:*:*:*:*"}},
+			}},
+			want: map[string][]cpe.CPE{
+				"acme-logging": {generated, zlib},
+				"tomcat-embed": {declared, zlib},
+				"zlib":         {zlib},
+			},
+		},
+		{
+			name: "all selectors must match",
+			config: CPEConfig{Overrides: []CPEOverride{
+				{Type: "java-archive", PURLPattern: "pkg:generic/**", CPEs: []string{"cpe:2.3:a:zlib:zlib:*:*:*:*:*:*:*:*"}},
+			}},
+			want: map[string][]cpe.CPE{
+				"acme-logging": {generated},
+				"tomcat-embed": {declared},
+				"zlib":         nil,
+			},
+		},
+		{
+			name: "deny vendor and product, including configured CPEs",
+			config: CPEConfig{
+				Overrides: []CPEOverride{
+					{Type: "java-archive", CPEs: []string{"cpe:2.3:a:apache:log4j:*:*:*:*:*:*:*:*"}},
+				},
+				Deny: []CPEDenial{{Vendor: "Apache", Product: "tomcat"}, {Vendor: "*", Product: "acme-logging"}},
+			},
+			want: map[string][]cpe.CPE{
+				"acme-logging": {log4j},
+				"tomcat-embed": {log4j},
+				"zlib":         nil,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			packages := newPackages()
+			original := packages[0].CPEs
+
+			got, err := applyCPEConfig(packages, tt.config)
+			require.NoError(t, err)
+
+			actual := make(map[string][]cpe.CPE)
+			for _, p := range got {
+				actual[p.Name] = p.CPEs
+			}
+			assert.Equal(t, tt.want, actual)
+
+			// the CPEs the package was provided with are not modified
+			assert.Equal(t, []cpe.CPE{generated}, original)
+		})
+	}
+}
@@ -3,6 +3,7 @@ package pkg
 import (
 	"errors"
 	"fmt"
+	"io"
 
 	"github.com/bmatcuk/doublestar/v2"
 
@@ -20,6 +21,34 @@ func Provide(userInput string, config ProviderConfig) ([]Package, Context, *sbom
 	if err != nil {
 		return nil, Context{}, nil, err
 	}
+	if packages, err = applyCPEConfig(packages, config.CPEs); err != nil {
+		return nil, Context{}, nil, err
+	}
+	setContextDistro(packages, &ctx)
+	return packages, ctx, s, nil
+}
+
+// ProvideFromReader provides a set of packages and context metadata from an SBOM document (in any format syft can
+// decode, including a newline separated list of package URLs) read from the given reader.
+func ProvideFromReader(reader io.ReadSeeker, config ProviderConfig) ([]Package, Context, *sbom.SBOM, error) {
+	s, fmtID, err := readSBOM(reader)
+	if err != nil {
+		if errors.Is(err, errDoesNotProvide) {
+			return nil, Context{}, nil, fmt.Errorf("unable to determine the SBOM format")
+		}
+		return nil, Context{}, nil, err
+	}
+
+	packages, ctx := fromSBOM(s, fmtID, "", config)
+	if len(config.Exclusions) > 0 {
+		packages, err = filterPackageExclusions(packages, config.Exclusions)
+		if err != nil {
+			return nil, Context{}, nil, err
+		}
+	}
+	if packages, err = applyCPEConfig(packages, config.CPEs); err != nil {
+		return nil, Context{}, nil, err
+	}
 	setContextDistro(packages, &ctx)
 	return packages, ctx, s, nil
 }
@@ -21,4 +21,5 @@ type SyftProviderConfig struct {
 
 type SynthesisConfig struct {
 	GenerateMissingCPEs bool
+	CPEs                CPEConfig // user-provided CPE overrides and denials, applied after any CPEs are generated
 }
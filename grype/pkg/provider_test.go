@@ -1,9 +1,12 @@
 package pkg
 
 import (
+	"os"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	"github.com/anchore/stereoscope/pkg/imagetest"
 	"github.com/anchore/syft/syft"
@@ -135,6 +138,32 @@ func TestSyftLocationExcludes(t *testing.T) {
 	}
 }
 
+func TestProvideFromReader(t *testing.T) {
+	t.Run("purl list", func(t *testing.T) {
+		f, err := os.Open("test-fixtures/purl/homogeneous-os.txt")
+		require.NoError(t, err)
+		defer f.Close()
+
+		packages, ctx, s, err := ProvideFromReader(f, ProviderConfig{})
+		require.NoError(t, err)
+		require.NotNil(t, s)
+
+		var names []string
+		for _, p := range packages {
+			names = append(names, p.Name)
+		}
+		assert.ElementsMatch(t, []string{"openssl", "curl"}, names)
+		require.NotNil(t, ctx.Distro)
+		assert.Equal(t, "alpine", string(ctx.Distro.Type))
+		assert.Equal(t, "3.20.3", ctx.Distro.Version)
+	})
+
+	t.Run("not an sbom", func(t *testing.T) {
+		_, _, _, err := ProvideFromReader(strings.NewReader("not an sbom"), ProviderConfig{})
+		require.Error(t, err)
+	})
+}
+
 func Test_filterPackageExclusions(t *testing.T) {
 	tests := []struct {
 		name       string
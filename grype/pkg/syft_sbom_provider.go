@@ -29,6 +29,11 @@ func syftSBOMProvider(userInput string, config ProviderConfig) ([]Package, Conte
 		return nil, Context{}, nil, err
 	}
 
+	packages, ctx := fromSBOM(s, fmtID, path, config)
+	return packages, ctx, s, nil
+}
+
+func fromSBOM(s *sbom.SBOM, fmtID sbom.FormatID, path string, config ProviderConfig) ([]Package, Context) {
 	src := s.Source
 	if src.Metadata == nil && path != "" {
 		src.Metadata = SBOMFileMetadata{
@@ -48,7 +53,7 @@ func syftSBOMProvider(userInput string, config ProviderConfig) ([]Package, Conte
 	return FromCollection(catalog, config.SynthesisConfig, enhancers...), Context{
 		Source: &src,
 		Distro: d,
-	}, s, nil
+	}
 }
 
 func getSBOM(userInput string) (*sbom.SBOM, sbom.FormatID, string, error) {
@@ -0,0 +1,265 @@
+package policy
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"time"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/anchore/grype/grype/match"
+	"github.com/anchore/grype/grype/presenter/models"
+	"github.com/anchore/grype/grype/vulnerability"
+)
+
+// Action is what should happen when a policy rule matches a finding
+type Action string
+
+const (
+	WarnAction Action = "warn"
+	FailAction Action = "fail"
+)
+
+const (
+	PassStatus = "pass"
+	WarnStatus = "warn"
+	FailStatus = "fail"
+)
+
+// Policy is a set of rules used to decide if the findings of a scan should pass, warn, or fail
+type Policy struct {
+	Rules []Rule `yaml:"rules"`
+}
+
+// Rule matches findings that meet all of the configured conditions. Conditions that are not set are not considered.
+type Rule struct {
+	Name   string `yaml:"name"`
+	Action Action `yaml:"action"`
+
+	// Severity matches vulnerabilities with a severity at or above the given severity
+	Severity string `yaml:"severity"`
+	// CVSS matches vulnerabilities with a CVSS base score at or above the given score
+	CVSS float64 `yaml:"cvss"`
+	// EPSSPercentile matches vulnerabilities with an EPSS percentile at or above the given percentile (0-1)
+	EPSSPercentile float64 `yaml:"epss-percentile"`
+	// KEV matches vulnerabilities that are in the CISA Known Exploited Vulnerabilities catalog
+	KEV bool `yaml:"kev"`
+	// FixStates matches vulnerabilities with any of the given fix states
+	FixStates []string `yaml:"fix-states"`
+	// FixAgeDays matches vulnerabilities where a fix has been available for at least the given number of days
+	FixAgeDays int `yaml:"fix-age-days"`
+	// PackageTypes matches packages of any of the given types
+	PackageTypes []string `yaml:"package-types"`
+	// MatchTypes matches findings made by any of the given match types
+	MatchTypes []string `yaml:"match-types"`
+	// ExcludeMatchTypes does not match findings that were only made by the given match types
+	ExcludeMatchTypes []string `yaml:"exclude-match-types"`
+}
+
+// Read loads and validates a policy file
+func Read(path string) (*Policy, error) {
+	contents, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read policy %q: %w", path, err)
+	}
+
+	decoder := yaml.NewDecoder(bytes.NewReader(contents))
+	decoder.KnownFields(true)
+
+	var p Policy
+	if err := decoder.Decode(&p); err != nil {
+		return nil, fmt.Errorf("unable to parse policy %q: %w", path, err)
+	}
+
+	if err := p.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid policy %q: %w", path, err)
+	}
+
+	return &p, nil
+}
+
+// Validate returns an error describing all misconfigured rules
+func (p Policy) Validate() error {
+	var errs error
+	for i, r := range p.Rules {
+		if err := r.validate(); err != nil {
+			errs = errors.Join(errs, fmt.Errorf("rule %d (%s): %w", i+1, r.Name, err))
+		}
+	}
+	return errs
+}
+
+func (r Rule) validate() error {
+	var errs []error
+
+	if r.Name == "" {
+		errs = append(errs, fmt.Errorf("a name is required"))
+	}
+
+	switch r.Action {
+	case WarnAction, FailAction:
+	default:
+		errs = append(errs, fmt.Errorf("action must be one of %q or %q, got %q", WarnAction, FailAction, r.Action))
+	}
+
+	if r.Severity != "" && vulnerability.ParseSeverity(r.Severity) == vulnerability.UnknownSeverity {
+		errs = append(errs, fmt.Errorf("unknown severity %q", r.Severity))
+	}
+
+	if r.CVSS < 0 || r.CVSS > 10 {
+		errs = append(errs, fmt.Errorf("cvss must be between 0 and 10, got %v", r.CVSS))
+	}
+
+	if r.EPSSPercentile < 0 || r.EPSSPercentile > 1 {
+		errs = append(errs, fmt.Errorf("epss-percentile must be between 0 and 1, got %v", r.EPSSPercentile))
+	}
+
+	if r.FixAgeDays < 0 {
+		errs = append(errs, fmt.Errorf("fix-age-days must not be negative, got %d", r.FixAgeDays))
+	}
+
+	for _, s := range r.FixStates {
+		if !slices.Contains(vulnerability.AllFixStates(), vulnerability.FixState(s)) {
+			errs = append(errs, fmt.Errorf("unknown fix state %q", s))
+		}
+	}
+
+	for _, t := range append(slices.Clone(r.MatchTypes), r.ExcludeMatchTypes...) {
+		switch match.Type(t) {
+		case match.ExactDirectMatch, match.ExactIndirectMatch, match.CPEMatch:
+		default:
+			errs = append(errs, fmt.Errorf("unknown match type %q", t))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// Evaluate applies the policy to the matches in the given document (ignored matches are not considered)
+func (p Policy) Evaluate(doc models.Document, now time.Time) models.PolicyEvaluation {
+	result := models.PolicyEvaluation{
+		Status:     PassStatus,
+		Violations: []models.PolicyViolation{},
+	}
+
+	for _, m := range doc.Matches {
+		for _, r := range p.Rules {
+			if !r.Matches(m, now) {
+				continue
+			}
+
+			result.Violations = append(result.Violations, models.PolicyViolation{
+				Rule:          r.Name,
+				Action:        string(r.Action),
+				Vulnerability: m.Vulnerability.ID,
+				Package:       m.Artifact.Name,
+				Version:       m.Artifact.Version,
+				Type:          string(m.Artifact.Type),
+			})
+
+			switch {
+			case r.Action == FailAction:
+				result.Status = FailStatus
+			case result.Status == PassStatus:
+				result.Status = WarnStatus
+			}
+		}
+	}
+
+	return result
+}
+
+// Matches indicates if the given finding meets all of the conditions of the rule
+func (r Rule) Matches(m models.Match, now time.Time) bool {
+	if r.Severity != "" && vulnerability.ParseSeverity(m.Vulnerability.Severity) < vulnerability.ParseSeverity(r.Severity) {
+		return false
+	}
+
+	if r.CVSS > 0 && maxCVSS(m) < r.CVSS {
+		return false
+	}
+
+	if r.EPSSPercentile > 0 && maxEPSSPercentile(m) < r.EPSSPercentile {
+		return false
+	}
+
+	if r.KEV && !isKEV(m) {
+		return false
+	}
+
+	if len(r.FixStates) > 0 && !slices.Contains(r.FixStates, m.Vulnerability.Fix.State) {
+		return false
+	}
+
+	if r.FixAgeDays > 0 && !fixAvailableFor(m, now, time.Duration(r.FixAgeDays)*24*time.Hour) {
+		return false
+	}
+
+	if len(r.PackageTypes) > 0 && !slices.Contains(r.PackageTypes, string(m.Artifact.Type)) {
+		return false
+	}
+
+	if len(r.MatchTypes) > 0 && !slices.ContainsFunc(m.MatchDetails, func(d models.MatchDetails) bool {
+		return slices.Contains(r.MatchTypes, d.Type)
+	}) {
+		return false
+	}
+
+	if len(r.ExcludeMatchTypes) > 0 && !slices.ContainsFunc(m.MatchDetails, func(d models.MatchDetails) bool {
+		return !slices.Contains(r.ExcludeMatchTypes, d.Type)
+	}) {
+		return false
+	}
+
+	return true
+}
+
+// metadata returns the vulnerability and all related vulnerabilities, since scores such as EPSS and KEV
+// are typically only available on the related CVE record
+func metadata(m models.Match) []models.VulnerabilityMetadata {
+	return append([]models.VulnerabilityMetadata{m.Vulnerability.VulnerabilityMetadata}, m.RelatedVulnerabilities...)
+}
+
+func maxCVSS(m models.Match) float64 {
+	var score float64
+	for _, md := range metadata(m) {
+		for _, c := range md.Cvss {
+			score = max(score, c.Metrics.BaseScore)
+		}
+	}
+	return score
+}
+
+func maxEPSSPercentile(m models.Match) float64 {
+	var percentile float64
+	for _, md := range metadata(m) {
+		for _, e := range md.EPSS {
+			percentile = max(percentile, e.Percentile)
+		}
+	}
+	return percentile
+}
+
+func isKEV(m models.Match) bool {
+	for _, md := range metadata(m) {
+		if len(md.KnownExploited) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+func fixAvailableFor(m models.Match, now time.Time, age time.Duration) bool {
+	if m.Vulnerability.Fix.State != string(vulnerability.FixStateFixed) || m.Vulnerability.Fix.Date == "" {
+		// without a known fix date the age of the fix cannot be determined
+		return false
+	}
+	date, err := time.Parse(time.DateOnly, m.Vulnerability.Fix.Date)
+	if err != nil {
+		return false
+	}
+	return !date.Add(age).After(now)
+}
@@ -0,0 +1,321 @@
+package policy
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/anchore/grype/grype/match"
+	"github.com/anchore/grype/grype/presenter/models"
+	"github.com/anchore/grype/grype/vulnerability"
+	syftPkg "github.com/anchore/syft/syft/pkg"
+)
+
+var now = time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)
+
+func newMatch(id string, modify ...func(m *models.Match)) models.Match {
+	m := models.Match{
+		Vulnerability: models.Vulnerability{
+			VulnerabilityMetadata: models.VulnerabilityMetadata{
+				ID:       id,
+				Severity: "low",
+			},
+			Fix: models.Fix{
+				State: string(vulnerability.FixStateNotFixed),
+			},
+		},
+		MatchDetails: []models.MatchDetails{
+			{Type: string(match.ExactDirectMatch)},
+		},
+		Artifact: models.Package{
+			Name:    "pkg",
+			Version: "1.0.0",
+			Type:    syftPkg.DebPkg,
+		},
+	}
+	for _, fn := range modify {
+		fn(&m)
+	}
+	return m
+}
+
+func TestRead(t *testing.T) {
+	tests := []struct {
+		name    string
+		path    string
+		want    *Policy
+		wantErr require.ErrorAssertionFunc
+	}{
+		{
+			name: "valid policy",
+			path: "test-fixtures/policy.yaml",
+			want: &Policy{
+				Rules: []Rule{
+					{
+						Name:   "no-exploited-vulnerabilities",
+						Action: FailAction,
+						KEV:    true,
+					},
+					{
+						Name:              "stale-fixes",
+						Action:            FailAction,
+						Severity:          "high",
+						FixAgeDays:        30,
+						ExcludeMatchTypes: []string{"cpe-match"},
+					},
+					{
+						Name:           "likely-exploitable",
+						Action:         WarnAction,
+						EPSSPercentile: 0.9,
+						PackageTypes:   []string{"npm"},
+					},
+				},
+			},
+		},
+		{
+			name:    "invalid values",
+			path:    "test-fixtures/invalid.yaml",
+			wantErr: require.Error,
+		},
+		{
+			name:    "unknown field",
+			path:    "test-fixtures/unknown-field.yaml",
+			wantErr: require.Error,
+		},
+		{
+			name:    "missing file",
+			path:    "test-fixtures/does-not-exist.yaml",
+			wantErr: require.Error,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.wantErr == nil {
+				tt.wantErr = require.NoError
+			}
+			got, err := Read(tt.path)
+			tt.wantErr(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestPolicy_Validate_reportsAllProblems(t *testing.T) {
+	_, err := Read("test-fixtures/invalid.yaml")
+	require.Error(t, err)
+
+	for _, expected := range []string{`"block"`, `"extreme"`, "epss-percentile", `"patched"`, `"fuzzy-match"`} {
+		assert.Contains(t, err.Error(), expected)
+	}
+}
+
+func TestRule_Matches(t *testing.T) {
+	tests := []struct {
+		name  string
+		rule  Rule
+		match models.Match
+		want  bool
+	}{
+		{
+			name:  "no conditions matches everything",
+			rule:  Rule{},
+			match: newMatch("CVE-1"),
+			want:  true,
+		},
+		{
+			name: "severity at threshold",
+			rule: Rule{Severity: "high"},
+			match: newMatch("CVE-1", func(m *models.Match) {
+				m.Vulnerability.Severity = "high"
+			}),
+			want: true,
+		},
+		{
+			name: "severity below threshold",
+			rule: Rule{Severity: "high"},
+			match: newMatch("CVE-1", func(m *models.Match) {
+				m.Vulnerability.Severity = "medium"
+			}),
+			want: false,
+		},
+		{
+			name: "cvss score from related vulnerability",
+			rule: Rule{CVSS: 9},
+			match: newMatch("GHSA-1", func(m *models.Match) {
+				m.RelatedVulnerabilities = []models.VulnerabilityMetadata{
+					{ID: "CVE-1", Cvss: []models.Cvss{{Metrics: models.CvssMetrics{BaseScore: 9.8}}}},
+				}
+			}),
+			want: true,
+		},
+		{
+			name: "cvss score below threshold",
+			rule: Rule{CVSS: 9},
+			match: newMatch("CVE-1", func(m *models.Match) {
+				m.Vulnerability.Cvss = []models.Cvss{{Metrics: models.CvssMetrics{BaseScore: 7.5}}}
+			}),
+			want: false,
+		},
+		{
+			name: "epss percentile at threshold",
+			rule: Rule{EPSSPercentile: 0.9},
+			match: newMatch("CVE-1", func(m *models.Match) {
+				m.Vulnerability.EPSS = []models.EPSS{{CVE: "CVE-1", Percentile: 0.95}}
+			}),
+			want: true,
+		},
+		{
+			name:  "epss percentile missing",
+			rule:  Rule{EPSSPercentile: 0.9},
+			match: newMatch("CVE-1"),
+			want:  false,
+		},
+		{
+			name: "known exploited",
+			rule: Rule{KEV: true},
+			match: newMatch("CVE-1", func(m *models.Match) {
+				m.Vulnerability.KnownExploited = []models.KnownExploited{{CVE: "CVE-1"}}
+			}),
+			want: true,
+		},
+		{
+			name:  "not known exploited",
+			rule:  Rule{KEV: true},
+			match: newMatch("CVE-1"),
+			want:  false,
+		},
+		{
+			name:  "fix state",
+			rule:  Rule{FixStates: []string{"fixed", "wont-fix"}},
+			match: newMatch("CVE-1"),
+			want:  false,
+		},
+		{
+			name: "fix available long enough",
+			rule: Rule{FixAgeDays: 30},
+			match: newMatch("CVE-1", func(m *models.Match) {
+				m.Vulnerability.Fix = models.Fix{State: "fixed", Versions: []string{"1.0.1"}, Date: "2025-05-01"}
+			}),
+			want: true,
+		},
+		{
+			name: "fix too recent",
+			rule: Rule{FixAgeDays: 30},
+			match: newMatch("CVE-1", func(m *models.Match) {
+				m.Vulnerability.Fix = models.Fix{State: "fixed", Versions: []string{"1.0.1"}, Date: "2025-05-15"}
+			}),
+			want: false,
+		},
+		{
+			name: "fix date unknown",
+			rule: Rule{FixAgeDays: 30},
+			match: newMatch("CVE-1", func(m *models.Match) {
+				m.Vulnerability.Fix = models.Fix{State: "fixed", Versions: []string{"1.0.1"}}
+			}),
+			want: false,
+		},
+		{
+			name:  "package type",
+			rule:  Rule{PackageTypes: []string{"npm"}},
+			match: newMatch("CVE-1"),
+			want:  false,
+		},
+		{
+			name: "match type",
+			rule: Rule{MatchTypes: []string{"cpe-match"}},
+			match: newMatch("CVE-1", func(m *models.Match) {
+				m.MatchDetails = append(m.MatchDetails, models.MatchDetails{Type: string(match.CPEMatch)})
+			}),
+			want: true,
+		},
+		{
+			name: "excluded match type only",
+			rule: Rule{ExcludeMatchTypes: []string{"cpe-match"}},
+			match: newMatch("CVE-1", func(m *models.Match) {
+				m.MatchDetails = []models.MatchDetails{{Type: string(match.CPEMatch)}}
+			}),
+			want: false,
+		},
+		{
+			name: "excluded match type with other match types",
+			rule: Rule{ExcludeMatchTypes: []string{"cpe-match"}},
+			match: newMatch("CVE-1", func(m *models.Match) {
+				m.MatchDetails = append(m.MatchDetails, models.MatchDetails{Type: string(match.CPEMatch)})
+			}),
+			want: true,
+		},
+		{
+			name: "all conditions must match",
+			rule: Rule{Severity: "high", KEV: true},
+			match: newMatch("CVE-1", func(m *models.Match) {
+				m.Vulnerability.Severity = "critical"
+			}),
+			want: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.rule.Matches(tt.match, now))
+		})
+	}
+}
+
+func TestPolicy_Evaluate(t *testing.T) {
+	p := Policy{
+		Rules: []Rule{
+			{Name: "kev", Action: FailAction, KEV: true},
+			{Name: "critical", Action: WarnAction, Severity: "critical"},
+		},
+	}
+
+	critical := newMatch("CVE-1", func(m *models.Match) {
+		m.Vulnerability.Severity = "critical"
+	})
+	exploited := newMatch("CVE-2", func(m *models.Match) {
+		m.Vulnerability.KnownExploited = []models.KnownExploited{{CVE: "CVE-2"}}
+	})
+
+	tests := []struct {
+		name    string
+		matches []models.Match
+		want    models.PolicyEvaluation
+	}{
+		{
+			name:    "pass",
+			matches: []models.Match{newMatch("CVE-3")},
+			want: models.PolicyEvaluation{
+				Status:     PassStatus,
+				Violations: []models.PolicyViolation{},
+			},
+		},
+		{
+			name:    "warn",
+			matches: []models.Match{critical},
+			want: models.PolicyEvaluation{
+				Status: WarnStatus,
+				Violations: []models.PolicyViolation{
+					{Rule: "critical", Action: "warn", Vulnerability: "CVE-1", Package: "pkg", Version: "1.0.0", Type: "deb"},
+				},
+			},
+		},
+		{
+			name:    "fail takes precedence over warn",
+			matches: []models.Match{exploited, critical},
+			want: models.PolicyEvaluation{
+				Status: FailStatus,
+				Violations: []models.PolicyViolation{
+					{Rule: "kev", Action: "fail", Vulnerability: "CVE-2", Package: "pkg", Version: "1.0.0", Type: "deb"},
+					{Rule: "critical", Action: "warn", Vulnerability: "CVE-1", Package: "pkg", Version: "1.0.0", Type: "deb"},
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := p.Evaluate(models.Document{Matches: tt.matches}, now)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,113 @@
+package diff
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/anchore/grype/grype/presenter/models"
+	"github.com/anchore/grype/grype/vulnerability"
+)
+
+const (
+	// SeverityChange indicates that the severity of a finding differs between the two reports
+	SeverityChange = "severity"
+
+	// FixChange indicates that the fix state or fix versions of a finding differ between the two reports
+	FixChange = "fix"
+)
+
+// Report describes how the findings of a grype report changed relative to an earlier report.
+type Report struct {
+	Added     []models.Match `json:"added"`
+	Removed   []models.Match `json:"removed"`
+	Changed   []Change       `json:"changed"`
+	Unchanged []models.Match `json:"unchanged"`
+}
+
+// Change is a finding that is present in both reports, but with different severity or fix information.
+type Change struct {
+	Before  models.Match `json:"before"`
+	After   models.Match `json:"after"`
+	Changes []string     `json:"changes"`
+}
+
+// Compare classifies the matches of the after report as added, changed or unchanged relative to the
+// before report, and the matches that are only in the before report as removed. Findings are identified
+// by vulnerability ID and package name, version, type and locations, so that the same finding is
+// recognized even when the vulnerability data behind it has been updated.
+func Compare(before, after models.Document) Report {
+	r := Report{
+		Added:     []models.Match{},
+		Removed:   []models.Match{},
+		Changed:   []Change{},
+		Unchanged: []models.Match{},
+	}
+
+	previous := make(map[string]models.Match)
+	for _, m := range before.Matches {
+		k := m.FindingKey()
+		if _, ok := previous[k]; !ok {
+			previous[k] = m
+		}
+	}
+
+	seen := make(map[string]struct{})
+	for _, m := range after.Matches {
+		k := m.FindingKey()
+		if _, ok := seen[k]; ok {
+			continue
+		}
+		seen[k] = struct{}{}
+
+		old, ok := previous[k]
+		if !ok {
+			r.Added = append(r.Added, m)
+			continue
+		}
+
+		if changes := changesBetween(old, m); len(changes) > 0 {
+			r.Changed = append(r.Changed, Change{Before: old, After: m, Changes: changes})
+			continue
+		}
+		r.Unchanged = append(r.Unchanged, m)
+	}
+
+	for _, m := range before.Matches {
+		k := m.FindingKey()
+		if _, ok := seen[k]; ok {
+			continue
+		}
+		seen[k] = struct{}{}
+		r.Removed = append(r.Removed, m)
+	}
+
+	return r
+}
+
+// AddedAtOrAbove returns the findings that were introduced by the later report with a severity that is equal
+// to or greater than the given severity.
+func (r Report) AddedAtOrAbove(severity vulnerability.Severity) []models.Match {
+	var out []models.Match
+	for _, m := range r.Added {
+		if vulnerability.ParseSeverity(m.Vulnerability.Severity) >= severity {
+			out = append(out, m)
+		}
+	}
+	return out
+}
+
+// HasDifferences indicates if any findings were added, removed or changed.
+func (r Report) HasDifferences() bool {
+	return len(r.Added) > 0 || len(r.Removed) > 0 || len(r.Changed) > 0
+}
+
+func changesBetween(before, after models.Match) []string {
+	var changes []string
+	if !strings.EqualFold(before.Vulnerability.Severity, after.Vulnerability.Severity) {
+		changes = append(changes, SeverityChange)
+	}
+	if before.Vulnerability.Fix.State != after.Vulnerability.Fix.State || !slices.Equal(before.Vulnerability.Fix.Versions, after.Vulnerability.Fix.Versions) {
+		changes = append(changes, FixChange)
+	}
+	return changes
+}
@@ -0,0 +1,124 @@
+package diff
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/anchore/grype/grype/presenter/models"
+	"github.com/anchore/grype/grype/vulnerability"
+	"github.com/anchore/syft/syft/file"
+)
+
+func newMatch(id, name, version, severity string, fix models.Fix) models.Match {
+	return models.Match{
+		Vulnerability: models.Vulnerability{
+			VulnerabilityMetadata: models.VulnerabilityMetadata{
+				ID:       id,
+				Severity: severity,
+			},
+			Fix: fix,
+		},
+		Artifact: models.Package{
+			Name:      name,
+			Version:   version,
+			Type:      "deb",
+			Locations: []file.Location{file.NewLocation("/var/lib/dpkg/status")},
+		},
+	}
+}
+
+var (
+	notFixed = models.Fix{State: vulnerability.FixStateNotFixed.String()}
+	fixed    = models.Fix{State: vulnerability.FixStateFixed.String(), Versions: []string{"1.2.4"}}
+)
+
+func testDocuments() (models.Document, models.Document) {
+	before := models.Document{
+		Matches: []models.Match{
+			newMatch("CVE-2023-0001", "libfoo", "1.2.3", "High", notFixed),
+			newMatch("CVE-2023-0002", "libfoo", "1.2.3", "Medium", notFixed),
+			newMatch("CVE-2023-0003", "libbar", "0.1.0", "Low", notFixed),
+			newMatch("CVE-2023-0004", "libbar", "0.1.0", "Low", notFixed),
+		},
+	}
+
+	after := models.Document{
+		Matches: []models.Match{
+			newMatch("CVE-2023-0001", "libfoo", "1.2.3", "High", notFixed),
+			newMatch("CVE-2023-0002", "libfoo", "1.2.3", "Critical", notFixed),
+			newMatch("CVE-2023-0003", "libbar", "0.1.0", "Low", fixed),
+			newMatch("CVE-2023-0005", "libbaz", "2.0.0", "High", notFixed),
+			newMatch("CVE-2023-0006", "libbaz", "2.0.0", "Medium", notFixed),
+		},
+	}
+
+	return before, after
+}
+
+func TestCompare(t *testing.T) {
+	before, after := testDocuments()
+
+	r := Compare(before, after)
+
+	ids := func(matches []models.Match) []string {
+		var out []string
+		for _, m := range matches {
+			out = append(out, m.Vulnerability.ID)
+		}
+		return out
+	}
+
+	assert.Equal(t, []string{"CVE-2023-0005", "CVE-2023-0006"}, ids(r.Added))
+	assert.Equal(t, []string{"CVE-2023-0004"}, ids(r.Removed))
+	assert.Equal(t, []string{"CVE-2023-0001"}, ids(r.Unchanged))
+
+	if assert.Len(t, r.Changed, 2) {
+		assert.Equal(t, "CVE-2023-0002", r.Changed[0].After.Vulnerability.ID)
+		assert.Equal(t, []string{SeverityChange}, r.Changed[0].Changes)
+		assert.Equal(t, "CVE-2023-0003", r.Changed[1].After.Vulnerability.ID)
+		assert.Equal(t, []string{FixChange}, r.Changed[1].Changes)
+	}
+
+	assert.True(t, r.HasDifferences())
+}
+
+func TestCompare_packageVersionChange(t *testing.T) {
+	before := models.Document{Matches: []models.Match{newMatch("CVE-2023-0001", "libfoo", "1.2.3", "High", notFixed)}}
+	after := models.Document{Matches: []models.Match{newMatch("CVE-2023-0001", "libfoo", "1.2.4", "High", notFixed)}}
+
+	r := Compare(before, after)
+
+	assert.Len(t, r.Added, 1)
+	assert.Len(t, r.Removed, 1)
+	assert.Empty(t, r.Changed)
+	assert.Empty(t, r.Unchanged)
+}
+
+func TestCompare_identical(t *testing.T) {
+	before, _ := testDocuments()
+
+	r := Compare(before, before)
+
+	assert.False(t, r.HasDifferences())
+	assert.Len(t, r.Unchanged, len(before.Matches))
+}
+
+func TestReport_AddedAtOrAbove(t *testing.T) {
+	before, after := testDocuments()
+	r := Compare(before, after)
+
+	tests := []struct {
+		severity vulnerability.Severity
+		want     int
+	}{
+		{severity: vulnerability.CriticalSeverity, want: 0},
+		{severity: vulnerability.HighSeverity, want: 1},
+		{severity: vulnerability.MediumSeverity, want: 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.severity.String(), func(t *testing.T) {
+			assert.Len(t, r.AddedAtOrAbove(tt.severity), tt.want)
+		})
+	}
+}
@@ -0,0 +1,203 @@
+package diff
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/olekukonko/tablewriter"
+	"github.com/olekukonko/tablewriter/renderer"
+	"github.com/olekukonko/tablewriter/tw"
+
+	"github.com/anchore/grype/grype/presenter/models"
+	"github.com/anchore/grype/grype/vulnerability"
+)
+
+const (
+	TableFormat    = "table"
+	JSONFormat     = "json"
+	MarkdownFormat = "markdown"
+)
+
+// AvailableFormats is a list of the formats a diff report can be presented in.
+var AvailableFormats = []string{
+	TableFormat,
+	JSONFormat,
+	MarkdownFormat,
+}
+
+// Presenter writes a diff report in one of the available formats
+type Presenter struct {
+	format string
+	report Report
+}
+
+// NewPresenter is a *Presenter constructor
+func NewPresenter(format string, report Report) *Presenter {
+	return &Presenter{
+		format: format,
+		report: report,
+	}
+}
+
+// Present writes the diff report to the given writer
+func (p *Presenter) Present(output io.Writer) error {
+	switch p.format {
+	case JSONFormat:
+		return p.presentJSON(output)
+	case MarkdownFormat:
+		return p.presentMarkdown(output)
+	case TableFormat, "":
+		return p.presentTable(output)
+	}
+	return fmt.Errorf("unsupported diff output format: %q", p.format)
+}
+
+func (p *Presenter) presentJSON(output io.Writer) error {
+	enc := json.NewEncoder(output)
+	enc.SetEscapeHTML(false)
+	enc.SetIndent("", " ")
+	return enc.Encode(&p.report)
+}
+
+func (p *Presenter) presentTable(output io.Writer) error {
+	if !p.report.HasDifferences() {
+		_, err := fmt.Fprintf(output, "No differences found (%d unchanged)\n", len(p.report.Unchanged))
+		return err
+	}
+
+	table := tablewriter.NewTable(output,
+		tablewriter.WithHeader([]string{"Change", "Name", "Installed", "Type", "Vulnerability", "Severity", "Fixed In"}),
+		tablewriter.WithHeaderAutoWrap(tw.WrapNone),
+		tablewriter.WithRowAutoWrap(tw.WrapNone),
+		tablewriter.WithRenderer(renderer.NewBlueprint()),
+		tablewriter.WithBehavior(tw.Behavior{TrimSpace: tw.On}),
+		tablewriter.WithPadding(tw.Padding{Right: "  "}),
+		tablewriter.WithRendition(tw.Rendition{
+			Symbols: tw.NewSymbols(tw.StyleNone),
+			Settings: tw.Settings{
+				Lines: tw.Lines{
+					ShowTop:        tw.Off,
+					ShowBottom:     tw.Off,
+					ShowHeaderLine: tw.Off,
+					ShowFooterLine: tw.Off,
+				},
+			},
+		}),
+	)
+
+	if err := table.Bulk(p.rows()); err != nil {
+		return fmt.Errorf("failed to add table rows: %w", err)
+	}
+
+	if err := table.Render(); err != nil {
+		return err
+	}
+
+	_, err := fmt.Fprintf(output, "\n%s\n", p.summary())
+	return err
+}
+
+func (p *Presenter) presentMarkdown(output io.Writer) error {
+	var sb strings.Builder
+
+	sb.WriteString("## Vulnerability changes\n\n")
+	sb.WriteString(p.summary())
+	sb.WriteString("\n")
+
+	sections := []struct {
+		title string
+		rows  [][]string
+	}{
+		{title: "Added", rows: matchRows("", p.report.Added)},
+		{title: "Removed", rows: matchRows("", p.report.Removed)},
+		{title: "Changed", rows: changeRows(p.report.Changed)},
+	}
+
+	for _, s := range sections {
+		if len(s.rows) == 0 {
+			continue
+		}
+		fmt.Fprintf(&sb, "\n### %s (%d)\n\n", s.title, len(s.rows))
+		sb.WriteString("| Name | Installed | Type | Vulnerability | Severity | Fixed In |\n")
+		sb.WriteString("|------|-----------|------|---------------|----------|----------|\n")
+		for _, r := range s.rows {
+			// the first column is the change kind, which is conveyed by the section
+			cells := make([]string, 0, len(r)-1)
+			for _, c := range r[1:] {
+				cells = append(cells, strings.ReplaceAll(c, "|", `\|`))
+			}
+			fmt.Fprintf(&sb, "| %s |\n", strings.Join(cells, " | "))
+		}
+	}
+
+	_, err := io.WriteString(output, sb.String())
+	return err
+}
+
+func (p *Presenter) rows() [][]string {
+	var rs [][]string
+	rs = append(rs, matchRows("added", p.report.Added)...)
+	rs = append(rs, matchRows("removed", p.report.Removed)...)
+	rs = append(rs, changeRows(p.report.Changed)...)
+	return rs
+}
+
+func (p *Presenter) summary() string {
+	return fmt.Sprintf("%d added, %d removed, %d changed, %d unchanged",
+		len(p.report.Added), len(p.report.Removed), len(p.report.Changed), len(p.report.Unchanged))
+}
+
+func matchRows(kind string, matches []models.Match) [][]string {
+	var rs [][]string
+	for _, m := range matches {
+		rs = append(rs, []string{
+			kind,
+			m.Artifact.Name,
+			m.Artifact.Version,
+			string(m.Artifact.Type),
+			m.Vulnerability.ID,
+			m.Vulnerability.Severity,
+			formatFix(m.Vulnerability.Fix),
+		})
+	}
+	return rs
+}
+
+func changeRows(changes []Change) [][]string {
+	var rs [][]string
+	for _, c := range changes {
+		severity := c.After.Vulnerability.Severity
+		if severity != c.Before.Vulnerability.Severity {
+			severity = fmt.Sprintf("%s -> %s", c.Before.Vulnerability.Severity, severity)
+		}
+
+		fix := formatFix(c.After.Vulnerability.Fix)
+		if before := formatFix(c.Before.Vulnerability.Fix); before != fix {
+			fix = fmt.Sprintf("%s -> %s", before, fix)
+		}
+
+		rs = append(rs, []string{
+			"changed",
+			c.After.Artifact.Name,
+			c.After.Artifact.Version,
+			string(c.After.Artifact.Type),
+			c.After.Vulnerability.ID,
+			severity,
+			fix,
+		})
+	}
+	return rs
+}
+
+func formatFix(f models.Fix) string {
+	if len(f.Versions) > 0 {
+		return strings.Join(f.Versions, ", ")
+	}
+	state := f.State
+	if state == "" {
+		state = vulnerability.FixStateUnknown.String()
+	}
+	return fmt.Sprintf("(%s)", state)
+}
@@ -0,0 +1,50 @@
+package diff
+
+import (
+	"bytes"
+	"flag"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/stretchr/testify/require"
+
+	"github.com/anchore/go-testutils"
+	"github.com/anchore/grype/grype/presenter/models"
+)
+
+var update = flag.Bool("update", false, "update the *.golden files for diff presenters")
+
+func TestPresenter(t *testing.T) {
+	before, after := testDocuments()
+	r := Compare(before, after)
+
+	for _, format := range AvailableFormats {
+		t.Run(format, func(t *testing.T) {
+			var buffer bytes.Buffer
+			require.NoError(t, NewPresenter(format, r).Present(&buffer))
+
+			actual := buffer.Bytes()
+			if *update {
+				testutils.UpdateGoldenFileContents(t, actual)
+			}
+
+			expected := testutils.GetGoldenFileContents(t)
+			if d := cmp.Diff(string(expected), string(actual)); d != "" {
+				t.Fatalf("diff: %s", d)
+			}
+		})
+	}
+}
+
+func TestPresenter_noDifferences(t *testing.T) {
+	before, _ := testDocuments()
+
+	var buffer bytes.Buffer
+	require.NoError(t, NewPresenter(TableFormat, Compare(before, before)).Present(&buffer))
+	require.Equal(t, "No differences found (4 unchanged)\n", buffer.String())
+}
+
+func TestPresenter_unsupportedFormat(t *testing.T) {
+	var buffer bytes.Buffer
+	require.Error(t, NewPresenter("sarif", Compare(models.Document{}, models.Document{})).Present(&buffer))
+}
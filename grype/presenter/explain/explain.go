@@ -2,6 +2,7 @@ package explain
 
 import (
 	_ "embed"
+	"encoding/json"
 	"fmt"
 	"io"
 	"sort"
@@ -23,10 +24,10 @@ type VulnerabilityExplainer interface {
 }
 
 type ViewModel struct {
-	PrimaryVulnerability   models.VulnerabilityMetadata
-	RelatedVulnerabilities []models.VulnerabilityMetadata
-	MatchedPackages        []*explainedPackage // I think this needs a map of artifacts to explained evidence
-	URLs                   []string
+	PrimaryVulnerability   models.VulnerabilityMetadata   `json:"primaryVulnerability"`
+	RelatedVulnerabilities []models.VulnerabilityMetadata `json:"relatedVulnerabilities"`
+	MatchedPackages        []*explainedPackage            `json:"matchedPackages"` // I think this needs a map of artifacts to explained evidence
+	URLs                   []string                       `json:"urls"`
 }
 
 type viewModelBuilder struct {
@@ -38,34 +39,55 @@ type viewModelBuilder struct {
 type Findings map[string]ViewModel
 
 type explainedPackage struct {
-	PURL                string
-	Name                string
-	Version             string
-	MatchedOnID         string
-	MatchedOnNamespace  string
-	IndirectExplanation string
-	DirectExplanation   string
-	CPEExplanation      string
-	Locations           []explainedEvidence
-	displayPriority     int // shows how early it should be displayed; direct matches first
+	PURL                string              `json:"purl"`
+	Name                string              `json:"name"`
+	Version             string              `json:"version"`
+	Type                string              `json:"type"`
+	MatchedOnID         string              `json:"matchedOnId"`
+	MatchedOnNamespace  string              `json:"matchedOnNamespace"`
+	IndirectExplanation string              `json:"indirectExplanation,omitempty"`
+	DirectExplanation   string              `json:"directExplanation,omitempty"`
+	CPEExplanation      string              `json:"cpeExplanation,omitempty"`
+	Locations           []explainedEvidence `json:"locations"`
+	Matches             []explainedMatch    `json:"matches"` // the evidence of every match on the package, for machine consumption
+	displayPriority     int                 // shows how early it should be displayed; direct matches first
 }
 
 type explainedEvidence struct {
-	Location     string
-	ArtifactID   string
-	ViaVulnID    string
-	ViaNamespace string
+	Location     string `json:"location"`
+	ArtifactID   string `json:"artifactId"`
+	ViaVulnID    string `json:"viaVulnerabilityId"`
+	ViaNamespace string `json:"viaNamespace"`
+}
+
+type explainedMatch struct {
+	VulnerabilityID string                `json:"vulnerabilityId"`
+	Namespace       string                `json:"namespace"`
+	Fix             models.Fix            `json:"fix"`
+	MatchDetails    []models.MatchDetails `json:"matchDetails"`
+}
+
+// Config is the configuration for a VulnerabilityExplainer
+type Config struct {
+	Filter Filter // selects the matches to explain
+	JSON   bool   // write the findings as JSON instead of as human-readable text
 }
 
 type vulnerabilityExplainer struct {
 	w   io.Writer
 	doc *models.Document
+	cfg Config
 }
 
 func NewVulnerabilityExplainer(w io.Writer, doc *models.Document) VulnerabilityExplainer {
+	return NewVulnerabilityExplainerWithConfig(w, doc, Config{})
+}
+
+func NewVulnerabilityExplainerWithConfig(w io.Writer, doc *models.Document, cfg Config) VulnerabilityExplainer {
 	return &vulnerabilityExplainer{
 		w:   w,
 		doc: doc,
+		cfg: cfg,
 	}
 }
 
@@ -74,10 +96,41 @@ var funcs = template.FuncMap{
 }
 
 func (e *vulnerabilityExplainer) ExplainByID(ids []string) error {
-	findings, err := Doc(e.doc, ids)
+	return e.explain(e.cfg.Filter, ids)
+}
+
+// ExplainBySeverity explains every selected finding with a severity at or above the given severity
+func (e *vulnerabilityExplainer) ExplainBySeverity(severity string) error {
+	filter := e.cfg.Filter
+	filter.Severity = severity
+	if err := filter.Validate(); err != nil {
+		return err
+	}
+	return e.explain(filter, nil)
+}
+
+func (e *vulnerabilityExplainer) ExplainAll() error {
+	return e.explain(e.cfg.Filter, nil)
+}
+
+// explain writes the findings for the given IDs, or for every selected match when no IDs are given
+func (e *vulnerabilityExplainer) explain(filter Filter, ids []string) error {
+	doc := *e.doc
+	doc.Matches = filter.apply(e.doc.Matches)
+
+	findings, err := Doc(&doc, ids)
 	if err != nil {
 		return err
 	}
+
+	if len(ids) == 0 {
+		ids = matchedIDs(doc.Matches)
+	}
+
+	if e.cfg.JSON {
+		return e.writeJSON(findings, ids)
+	}
+
 	t := template.Must(template.New("explanation").Funcs(funcs).Parse(explainTemplate))
 	for _, id := range ids {
 		finding, ok := findings[id]
@@ -91,18 +144,32 @@ func (e *vulnerabilityExplainer) ExplainByID(ids []string) error {
 	return nil
 }
 
-func (e *vulnerabilityExplainer) ExplainBySeverity(_ string) error {
-	return fmt.Errorf("not implemented")
+func (e *vulnerabilityExplainer) writeJSON(findings Findings, ids []string) error {
+	selected := make(Findings)
+	for _, id := range ids {
+		if finding, ok := findings[id]; ok {
+			selected[id] = finding
+		}
+	}
+	enc := json.NewEncoder(e.w)
+	enc.SetEscapeHTML(false)
+	enc.SetIndent("", " ")
+	return enc.Encode(selected)
 }
 
-func (e *vulnerabilityExplainer) ExplainAll() error {
-	findings, err := Doc(e.doc, nil)
-	if err != nil {
-		return err
+// matchedIDs returns the sorted, unique vulnerability IDs of the given matches
+func matchedIDs(matches []models.Match) []string {
+	seen := make(map[string]struct{})
+	var ids []string
+	for _, m := range matches {
+		if _, ok := seen[m.Vulnerability.ID]; ok {
+			continue
+		}
+		seen[m.Vulnerability.ID] = struct{}{}
+		ids = append(ids, m.Vulnerability.ID)
 	}
-	t := template.Must(template.New("explanation").Funcs(funcs).Parse(explainTemplate))
-
-	return t.Execute(e.w, findings)
+	sort.Strings(ids)
+	return ids
 }
 
 func Doc(doc *models.Document, requestedIDs []string) (Findings, error) {
@@ -253,6 +320,12 @@ func groupAndSortEvidence(matches []models.Match) []*explainedPackage {
 		for _, l := range m.Artifact.Locations {
 			newLocations = append(newLocations, explainLocation(m, l))
 		}
+		newMatch := explainedMatch{
+			VulnerabilityID: m.Vulnerability.ID,
+			Namespace:       m.Vulnerability.Namespace,
+			Fix:             m.Vulnerability.Fix,
+			MatchDetails:    m.MatchDetails,
+		}
 		var directExplanation string
 		var indirectExplanation string
 		var cpeExplanation string
@@ -279,17 +352,20 @@ func groupAndSortEvidence(matches []models.Match) []*explainedPackage {
 				PURL:                m.Artifact.PURL,
 				Name:                m.Artifact.Name,
 				Version:             m.Artifact.Version,
+				Type:                string(m.Artifact.Type),
 				MatchedOnID:         m.Vulnerability.ID,
 				MatchedOnNamespace:  m.Vulnerability.Namespace,
 				DirectExplanation:   directExplanation,
 				IndirectExplanation: indirectExplanation,
 				CPEExplanation:      cpeExplanation,
 				Locations:           newLocations,
+				Matches:             []explainedMatch{newMatch},
 				displayPriority:     matchTypePriority,
 			}
 			idsToMatchDetails[key] = e
 		} else {
 			e.Locations = append(e.Locations, newLocations...)
+			e.Matches = append(e.Matches, newMatch)
 			if e.CPEExplanation == "" {
 				e.CPEExplanation = cpeExplanation
 			}
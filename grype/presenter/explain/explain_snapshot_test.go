@@ -69,3 +69,54 @@ func TestExplainSnapshot(t *testing.T) {
 		})
 	}
 }
+
+func TestExplainSnapshot_Selected(t *testing.T) {
+	testCases := []struct {
+		name    string
+		fixture string
+		cfg     explain.Config
+		explain func(e explain.VulnerabilityExplainer) error
+	}{
+		{
+			name:    "all",
+			fixture: "test-fixtures/ghsa-test.json",
+			explain: func(e explain.VulnerabilityExplainer) error { return e.ExplainAll() },
+		},
+		{
+			name:    "by severity",
+			fixture: "test-fixtures/chainguard-ruby-test.json",
+			cfg:     explain.Config{Filter: explain.Filter{Packages: []string{"pkg:gem/uri"}}},
+			explain: func(e explain.VulnerabilityExplainer) error { return e.ExplainBySeverity("high") },
+		},
+		{
+			name:    "by fix state",
+			fixture: "test-fixtures/chainguard-ruby-test.json",
+			cfg:     explain.Config{Filter: explain.Filter{FixStates: []string{"fixed"}, Matchers: []string{"apk-matcher"}}},
+			explain: func(e explain.VulnerabilityExplainer) error { return e.ExplainAll() },
+		},
+		{
+			name:    "json",
+			fixture: "test-fixtures/ghsa-test.json",
+			cfg:     explain.Config{JSON: true},
+			explain: func(e explain.VulnerabilityExplainer) error { return e.ExplainByID([]string{"CVE-2014-3577"}) },
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			r, err := os.Open(tc.fixture)
+			require.NoError(t, err)
+
+			doc := models.Document{}
+			require.NoError(t, json.NewDecoder(r).Decode(&doc))
+
+			w := bytes.NewBufferString("")
+			require.NoError(t, tc.explain(explain.NewVulnerabilityExplainerWithConfig(w, &doc, tc.cfg)))
+			snaps.MatchSnapshot(t, w.String())
+		})
+	}
+}
+
+func TestExplainBySeverity_UnknownSeverity(t *testing.T) {
+	explainer := explain.NewVulnerabilityExplainer(bytes.NewBufferString(""), &models.Document{})
+	require.Error(t, explainer.ExplainBySeverity("severe"))
+}
@@ -0,0 +1,104 @@
+package explain
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/anchore/grype/grype/match"
+	"github.com/anchore/grype/grype/presenter/models"
+	"github.com/anchore/grype/grype/vulnerability"
+)
+
+// Filter selects the matches to explain; every criteria that is set must be met by a match for it to be selected,
+// so an empty filter selects all matches.
+type Filter struct {
+	Severity  string   // select matches with a severity at or above this severity
+	Packages  []string // select matches on packages with any of these names or package URLs (a package URL without a version matches any version)
+	FixStates []string // select matches with any of these fix states
+	KEV       bool     // select only matches for vulnerabilities (or related vulnerabilities) in the CISA KEV catalog
+	Matchers  []string // select matches found by any of these matchers
+}
+
+// Validate returns an error for criteria that could never be met
+func (f Filter) Validate() error {
+	if f.Severity != "" && vulnerability.ParseSeverity(f.Severity) == vulnerability.UnknownSeverity {
+		return fmt.Errorf("unknown severity %q", f.Severity)
+	}
+	for _, s := range f.FixStates {
+		if !slices.Contains(vulnerability.AllFixStates(), vulnerability.FixState(strings.ToLower(s))) {
+			return fmt.Errorf("unknown fix state %q (options: %s)", s, joinValues(vulnerability.AllFixStates()))
+		}
+	}
+	// the stock matcher is the fallback for packages no other matcher is for, so is not amongst all matcher types
+	matcherTypes := append([]match.MatcherType{match.StockMatcher}, match.AllMatcherTypes...)
+	for _, m := range f.Matchers {
+		if !slices.Contains(matcherTypes, match.MatcherType(strings.ToLower(m))) {
+			return fmt.Errorf("unknown matcher %q (options: %s)", m, joinValues(matcherTypes))
+		}
+	}
+	return nil
+}
+
+func (f Filter) apply(matches []models.Match) []models.Match {
+	var out []models.Match
+	for _, m := range matches {
+		if f.selects(m) {
+			out = append(out, m)
+		}
+	}
+	return out
+}
+
+func (f Filter) selects(m models.Match) bool {
+	if f.Severity != "" && vulnerability.ParseSeverity(m.Vulnerability.Severity) < vulnerability.ParseSeverity(f.Severity) {
+		return false
+	}
+	if len(f.Packages) > 0 && !slices.ContainsFunc(f.Packages, func(p string) bool { return selectsPackage(p, m.Artifact) }) {
+		return false
+	}
+	if len(f.FixStates) > 0 && !slices.ContainsFunc(f.FixStates, func(s string) bool { return strings.EqualFold(s, m.Vulnerability.Fix.State) }) {
+		return false
+	}
+	if f.KEV && !isKnownExploited(m) {
+		return false
+	}
+	if len(f.Matchers) > 0 && !slices.ContainsFunc(m.MatchDetails, func(d models.MatchDetails) bool {
+		return slices.ContainsFunc(f.Matchers, func(mt string) bool { return strings.EqualFold(mt, d.Matcher) })
+	}) {
+		return false
+	}
+	return true
+}
+
+func selectsPackage(selector string, p models.Package) bool {
+	if !strings.HasPrefix(selector, "pkg:") {
+		return selector == p.Name
+	}
+	if selector == p.PURL {
+		return true
+	}
+	// a package URL without a version (or qualifiers) selects every version of the package
+	rest, ok := strings.CutPrefix(p.PURL, selector)
+	return ok && (strings.HasPrefix(rest, "@") || strings.HasPrefix(rest, "?"))
+}
+
+func isKnownExploited(m models.Match) bool {
+	if len(m.Vulnerability.KnownExploited) > 0 {
+		return true
+	}
+	for _, r := range m.RelatedVulnerabilities {
+		if len(r.KnownExploited) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+func joinValues[T fmt.Stringer](values []T) string {
+	var out []string
+	for _, v := range values {
+		out = append(out, v.String())
+	}
+	return strings.Join(out, ", ")
+}
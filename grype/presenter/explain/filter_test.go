@@ -0,0 +1,136 @@
+package explain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/anchore/grype/grype/presenter/models"
+)
+
+func TestFilter_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		filter  Filter
+		wantErr require.ErrorAssertionFunc
+	}{
+		{
+			name:   "empty",
+			filter: Filter{},
+		},
+		{
+			name: "valid",
+			filter: Filter{
+				Severity:  "High",
+				FixStates: []string{"fixed", "wont-fix"},
+				Matchers:  []string{"dpkg-matcher", "stock-matcher"},
+			},
+		},
+		{
+			name:    "unknown severity",
+			filter:  Filter{Severity: "severe"},
+			wantErr: require.Error,
+		},
+		{
+			name:    "unknown fix state",
+			filter:  Filter{FixStates: []string{"fixed", "patched"}},
+			wantErr: require.Error,
+		},
+		{
+			name:    "unknown matcher",
+			filter:  Filter{Matchers: []string{"dpkg"}},
+			wantErr: require.Error,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.wantErr == nil {
+				tt.wantErr = require.NoError
+			}
+			tt.wantErr(t, tt.filter.Validate())
+		})
+	}
+}
+
+func TestFilter_apply(t *testing.T) {
+	newMatch := func(id, severity, fixState, name, purl, matcher string) models.Match {
+		return models.Match{
+			Vulnerability: models.Vulnerability{
+				VulnerabilityMetadata: models.VulnerabilityMetadata{ID: id, Severity: severity},
+				Fix:                   models.Fix{State: fixState},
+			},
+			MatchDetails: []models.MatchDetails{{Matcher: matcher}},
+			Artifact:     models.Package{Name: name, PURL: purl},
+		}
+	}
+
+	openssl := newMatch("CVE-2023-0001", "Critical", "fixed", "openssl", "pkg:deb/debian/openssl@3.0.11-1?distro=debian-12", "dpkg-matcher")
+	opensslKEV := newMatch("CVE-2023-0002", "High", "not-fixed", "openssl", "pkg:deb/debian/openssl@3.0.11-1?distro=debian-12", "dpkg-matcher")
+	opensslKEV.Vulnerability.KnownExploited = []models.KnownExploited{{CVE: "CVE-2023-0002"}}
+	lodash := newMatch("GHSA-0000-0000-0003", "Medium", "wont-fix", "lodash", "pkg:npm/lodash@4.17.20", "javascript-matcher")
+	lodashRelatedKEV := newMatch("GHSA-0000-0000-0004", "Low", "unknown", "lodash", "pkg:npm/lodash@4.17.20", "javascript-matcher")
+	lodashRelatedKEV.RelatedVulnerabilities = []models.VulnerabilityMetadata{
+		{ID: "CVE-2023-0004", KnownExploited: []models.KnownExploited{{CVE: "CVE-2023-0004"}}},
+	}
+	lodashes := newMatch("CVE-2023-0005", "", "unknown", "lodashes", "pkg:npm/lodashes@1.0.0", "stock-matcher")
+
+	all := []models.Match{openssl, opensslKEV, lodash, lodashRelatedKEV, lodashes}
+
+	tests := []struct {
+		name   string
+		filter Filter
+		want   []models.Match
+	}{
+		{
+			name:   "empty filter selects everything",
+			filter: Filter{},
+			want:   all,
+		},
+		{
+			name:   "at or above severity",
+			filter: Filter{Severity: "high"},
+			want:   []models.Match{openssl, opensslKEV},
+		},
+		{
+			name:   "by package name",
+			filter: Filter{Packages: []string{"lodash"}},
+			want:   []models.Match{lodash, lodashRelatedKEV},
+		},
+		{
+			name:   "by package URL without a version",
+			filter: Filter{Packages: []string{"pkg:npm/lodash"}},
+			want:   []models.Match{lodash, lodashRelatedKEV},
+		},
+		{
+			name:   "by package URL",
+			filter: Filter{Packages: []string{"pkg:npm/lodashes@1.0.0", "pkg:deb/debian/openssl@3.0.11-1?distro=debian-12"}},
+			want:   []models.Match{openssl, opensslKEV, lodashes},
+		},
+		{
+			name:   "by fix state",
+			filter: Filter{FixStates: []string{"Fixed", "wont-fix"}},
+			want:   []models.Match{openssl, lodash},
+		},
+		{
+			name:   "known exploited, directly or by a related vulnerability",
+			filter: Filter{KEV: true},
+			want:   []models.Match{opensslKEV, lodashRelatedKEV},
+		},
+		{
+			name:   "by matcher",
+			filter: Filter{Matchers: []string{"javascript-matcher", "stock-matcher"}},
+			want:   []models.Match{lodash, lodashRelatedKEV, lodashes},
+		},
+		{
+			name:   "every criteria must be met",
+			filter: Filter{Packages: []string{"openssl", "lodash"}, KEV: true, Severity: "medium"},
+			want:   []models.Match{opensslKEV},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.filter.apply(all))
+		})
+	}
+}
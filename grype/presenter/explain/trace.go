@@ -0,0 +1,281 @@
+package explain
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"text/template"
+
+	"github.com/anchore/grype/grype"
+	v6 "github.com/anchore/grype/grype/db/v6"
+	"github.com/anchore/grype/grype/match"
+	"github.com/anchore/grype/grype/pkg"
+	"github.com/anchore/grype/grype/presenter/models"
+)
+
+//go:embed explain_trace.tmpl
+var traceTemplate string
+
+// Trace explains why a vulnerability was (or was not) matched to a single package
+type Trace struct {
+	Vulnerability string             `json:"vulnerability"`
+	Package       TracePackage       `json:"package"`
+	Matched       bool               `json:"matched"`
+	Summary       string             `json:"summary"`
+	Records       []v6.AffectedTrace `json:"records"`
+	Matchers      []TraceMatcher     `json:"matchers"`
+	Ignored       []TraceIgnored     `json:"ignored"`
+}
+
+// TracePackage is the package a vulnerability was searched for
+type TracePackage struct {
+	Name      string   `json:"name"`
+	Version   string   `json:"version"`
+	Type      string   `json:"type"`
+	Language  string   `json:"language,omitempty"`
+	PURL      string   `json:"purl,omitempty"`
+	Distro    string   `json:"distro,omitempty"`
+	Upstreams []string `json:"upstreams,omitempty"`
+	CPEs      []string `json:"cpes,omitempty"`
+}
+
+// TraceMatcher is what a single matcher found for the vulnerability
+type TraceMatcher struct {
+	Matcher           string         `json:"matcher"`
+	Matches           []TraceMatch   `json:"matches"`
+	ExplicitlyIgnored []TraceIgnored `json:"explicitlyIgnored"`
+	MatcherIgnored    []TraceIgnored `json:"matcherIgnored"`
+	Error             string         `json:"error,omitempty"`
+}
+
+// TraceMatch is a match for the vulnerability
+type TraceMatch struct {
+	Vulnerability string   `json:"vulnerability"`
+	Namespace     string   `json:"namespace"`
+	Types         []string `json:"types"`
+	Matchers      []string `json:"matchers"`
+	Confidence    float64  `json:"confidence,omitempty"`
+}
+
+// TraceIgnored is a match for the vulnerability along with the ignore rules that were applied to it
+type TraceIgnored struct {
+	TraceMatch
+	Rules []models.IgnoreRule `json:"rules"`
+}
+
+// NewTrace describes the outcome of tracing the vulnerability through the DB records and the matchers
+func NewTrace(p pkg.Package, vulnID string, records []v6.AffectedTrace, matchTrace grype.MatchTrace) Trace {
+	t := Trace{
+		Vulnerability: vulnID,
+		Package:       newTracePackage(p),
+		Matched:       matchTrace.Matched(),
+		Records:       records,
+		Matchers:      []TraceMatcher{},
+		Ignored:       newTraceIgnored(matchTrace.Ignored),
+	}
+	if t.Records == nil {
+		t.Records = []v6.AffectedTrace{}
+	}
+
+	for _, m := range matchTrace.Matchers {
+		tm := TraceMatcher{
+			Matcher:           string(m.Matcher),
+			Matches:           newTraceMatches(m.Matches),
+			ExplicitlyIgnored: newTraceIgnored(m.ExplicitlyIgnored),
+			MatcherIgnored:    newTraceIgnored(m.MatcherIgnored),
+		}
+		if m.Err != nil {
+			tm.Error = m.Err.Error()
+		}
+		t.Matchers = append(t.Matchers, tm)
+	}
+
+	t.Summary = t.summarize(matchTrace)
+	return t
+}
+
+func (t Trace) summarize(matchTrace grype.MatchTrace) string {
+	if t.Matched {
+		var matchers []string
+		for _, m := range matchTrace.Matches {
+			for _, ty := range m.Details.Matchers() {
+				matchers = append(matchers, string(ty))
+			}
+		}
+		return fmt.Sprintf("%s is matched by %s", t.Vulnerability, strings.Join(uniqueSorted(matchers), ", "))
+	}
+
+	if len(t.Ignored) > 0 {
+		return fmt.Sprintf("%s is matched, but ignored by user-provided ignore rules or VEX", t.Vulnerability)
+	}
+
+	var explicit, matcherIgnored, failed bool
+	for _, m := range t.Matchers {
+		explicit = explicit || len(m.ExplicitlyIgnored) > 0
+		matcherIgnored = matcherIgnored || len(m.MatcherIgnored) > 0
+		failed = failed || m.Error != ""
+	}
+
+	switch {
+	case explicit:
+		return fmt.Sprintf("%s is matched, but ignored by a built-in rule or a database exclusion", t.Vulnerability)
+	case matcherIgnored:
+		return fmt.Sprintf("%s is matched, but ignored by a rule from another matcher (e.g. a distro fix for the package)", t.Vulnerability)
+	case len(t.Records) == 0:
+		return fmt.Sprintf("%s has no affected package or CPE records in the database", t.Vulnerability)
+	}
+
+	for _, r := range t.Records {
+		if r.Selected {
+			if failed {
+				return fmt.Sprintf("a record for %s applies to the package, but a matcher failed", t.Vulnerability)
+			}
+			return fmt.Sprintf("a record for %s applies to the package, but no matcher searched for it (e.g. CPE matching is disabled for the package type, or the package version is unknown)", t.Vulnerability)
+		}
+	}
+	return fmt.Sprintf("none of the %d records for %s apply to the package", len(t.Records), t.Vulnerability)
+}
+
+func newTracePackage(p pkg.Package) TracePackage {
+	out := TracePackage{
+		Name:     p.Name,
+		Version:  p.Version,
+		Type:     string(p.Type),
+		Language: string(p.Language),
+		PURL:     p.PURL,
+	}
+	if p.Distro != nil {
+		out.Distro = p.Distro.String()
+	}
+	for _, u := range p.Upstreams {
+		out.Upstreams = append(out.Upstreams, u.Name)
+	}
+	for _, c := range p.CPEs {
+		out.CPEs = append(out.CPEs, c.Attributes.BindToFmtString())
+	}
+	return out
+}
+
+func newTraceMatches(matches []match.Match) []TraceMatch {
+	out := []TraceMatch{}
+	for _, m := range matches {
+		out = append(out, newTraceMatch(m))
+	}
+	return out
+}
+
+func newTraceMatch(m match.Match) TraceMatch {
+	var types []string
+	for _, ty := range m.Details.Types() {
+		types = append(types, string(ty))
+	}
+	var matchers []string
+	for _, ty := range m.Details.Matchers() {
+		matchers = append(matchers, string(ty))
+	}
+	return TraceMatch{
+		Vulnerability: m.Vulnerability.ID,
+		Namespace:     m.Vulnerability.Namespace,
+		Types:         uniqueSorted(types),
+		Matchers:      uniqueSorted(matchers),
+		Confidence:    m.Confidence(),
+	}
+}
+
+func newTraceIgnored(ignored []match.IgnoredMatch) []TraceIgnored {
+	out := []TraceIgnored{}
+	for _, i := range ignored {
+		ti := TraceIgnored{TraceMatch: newTraceMatch(i.Match)}
+		for _, r := range i.AppliedIgnoreRules {
+			ti.Rules = append(ti.Rules, models.NewIgnoreRule(r))
+		}
+		out = append(out, ti)
+	}
+	return out
+}
+
+func uniqueSorted(values []string) []string {
+	seen := make(map[string]struct{})
+	var out []string
+	for _, v := range values {
+		if _, ok := seen[v]; ok {
+			continue
+		}
+		seen[v] = struct{}{}
+		out = append(out, v)
+	}
+	sort.Strings(out)
+	return out
+}
+
+// describeRule is a short description of the criteria of an ignore rule
+func describeRule(r models.IgnoreRule) string {
+	var parts []string
+	if r.Vulnerability != "" {
+		parts = append(parts, fmt.Sprintf("vulnerability=%s", r.Vulnerability))
+	}
+	if r.Package != nil {
+		if r.Package.Name != "" {
+			parts = append(parts, fmt.Sprintf("package=%s", r.Package.Name))
+		}
+		if r.Package.Version != "" {
+			parts = append(parts, fmt.Sprintf("version=%s", r.Package.Version))
+		}
+		if r.Package.Type != "" {
+			parts = append(parts, fmt.Sprintf("type=%s", r.Package.Type))
+		}
+		if r.Package.Location != "" {
+			parts = append(parts, fmt.Sprintf("location=%s", r.Package.Location))
+		}
+		if r.Package.UpstreamName != "" {
+			parts = append(parts, fmt.Sprintf("upstream=%s", r.Package.UpstreamName))
+		}
+	}
+	if r.FixState != "" {
+		parts = append(parts, fmt.Sprintf("fix-state=%s", r.FixState))
+	}
+	if r.MatchType != "" {
+		parts = append(parts, fmt.Sprintf("match-type=%s", r.MatchType))
+	}
+	if r.VexStatus != "" {
+		parts = append(parts, fmt.Sprintf("vex-status=%s", r.VexStatus))
+	}
+	if r.Reason != "" {
+		parts = append(parts, fmt.Sprintf("reason=%q", r.Reason))
+	}
+	return strings.Join(parts, " ")
+}
+
+var traceFuncs = template.FuncMap{
+	"rule": describeRule,
+	"join": strings.Join,
+	"mark": func(passed bool) string {
+		if passed {
+			return "✔"
+		}
+		return "✘"
+	},
+}
+
+// WriteTrace writes the trace as a human-readable explanation
+func WriteTrace(w io.Writer, t Trace) error {
+	tmpl := template.Must(template.New("trace").Funcs(traceFuncs).Parse(traceTemplate))
+	if err := tmpl.Execute(w, t); err != nil {
+		return fmt.Errorf("unable to execute template: %w", err)
+	}
+	return nil
+}
+
+// WriteTraceJSON writes the traces as a JSON list
+func WriteTraceJSON(w io.Writer, traces ...Trace) error {
+	if traces == nil {
+		traces = []Trace{}
+	}
+	enc := json.NewEncoder(w)
+	enc.SetEscapeHTML(false)
+	enc.SetIndent("", " ")
+	return enc.Encode(traces)
+}
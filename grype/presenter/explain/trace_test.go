@@ -0,0 +1,168 @@
+package explain_test
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/gkampitakis/go-snaps/snaps"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/anchore/grype/grype"
+	v6 "github.com/anchore/grype/grype/db/v6"
+	"github.com/anchore/grype/grype/distro"
+	"github.com/anchore/grype/grype/match"
+	"github.com/anchore/grype/grype/pkg"
+	"github.com/anchore/grype/grype/presenter/explain"
+	"github.com/anchore/grype/grype/vulnerability"
+	syftPkg "github.com/anchore/syft/syft/pkg"
+)
+
+var (
+	tracedPackage = pkg.Package{
+		Name:    "openssl",
+		Version: "3.0.11-1~deb12u2",
+		Type:    syftPkg.DebPkg,
+		PURL:    "pkg:deb/debian/openssl@3.0.11-1~deb12u2?distro=debian-12",
+		Distro:  distro.New(distro.Debian, "12", ""),
+	}
+
+	tracedMatch = match.Match{
+		Vulnerability: vulnerability.Vulnerability{
+			Reference: vulnerability.Reference{ID: "CVE-2023-5678", Namespace: "debian:distro:debian:12"},
+		},
+		Package: tracedPackage,
+		Details: match.Details{
+			{Type: match.ExactDirectMatch, Matcher: match.DpkgMatcher, Confidence: match.ExactDirectMatchConfidence},
+		},
+	}
+
+	appliedRecord = v6.AffectedTrace{
+		Vulnerability: "CVE-2023-5678",
+		Provider:      "debian",
+		Namespace:     "debian:distro:debian:12",
+		Package:       "openssl",
+		OS:            "debian@12",
+		Selected:      true,
+		Decisions: []v6.TraceDecision{
+			{Stage: v6.DistroTraceStage, Passed: true, Detail: "package distro debian@12 resolves to record distro debian@12"},
+			{Stage: v6.NameTraceStage, Passed: true, Detail: `record package "openssl" matches searched name "openssl"`},
+			{Stage: v6.RangeTraceStage, Passed: true, Detail: `version 3.0.11-1~deb12u2 is within "< 3.0.13-1~deb12u1" (deb), fixed in 3.0.13-1~deb12u1`},
+		},
+	}
+
+	unappliedRecord = v6.AffectedTrace{
+		Vulnerability: "CVE-2023-5678",
+		Provider:      "nvd",
+		Namespace:     "nvd:cpe",
+		CPE:           "cpe:2.3:a:openssl:openssl:*:*:*:*:*:*:*:*",
+		Decisions: []v6.TraceDecision{
+			{Stage: v6.StatusTraceStage, Passed: true, Detail: "vulnerability is not withdrawn or rejected"},
+			{Stage: v6.CPETraceStage, Detail: "package has no CPEs to search by"},
+		},
+	}
+)
+
+func TestNewTrace_Summary(t *testing.T) {
+	tests := []struct {
+		name       string
+		records    []v6.AffectedTrace
+		matchTrace grype.MatchTrace
+		matched    bool
+		expected   string
+	}{
+		{
+			name:    "matched",
+			records: []v6.AffectedTrace{appliedRecord},
+			matchTrace: grype.MatchTrace{
+				Matchers: []grype.MatcherTrace{{Matcher: match.DpkgMatcher, Matches: []match.Match{tracedMatch}}},
+				Matches:  []match.Match{tracedMatch},
+			},
+			matched:  true,
+			expected: "CVE-2023-5678 is matched by dpkg-matcher",
+		},
+		{
+			name:    "ignored by the user",
+			records: []v6.AffectedTrace{appliedRecord},
+			matchTrace: grype.MatchTrace{
+				Matchers: []grype.MatcherTrace{{Matcher: match.DpkgMatcher, Matches: []match.Match{tracedMatch}}},
+				Ignored: []match.IgnoredMatch{
+					{Match: tracedMatch, AppliedIgnoreRules: []match.IgnoreRule{{Vulnerability: "CVE-2023-5678", Reason: "not exploitable"}}},
+				},
+			},
+			expected: "CVE-2023-5678 is matched, but ignored by user-provided ignore rules or VEX",
+		},
+		{
+			name:    "explicitly ignored",
+			records: []v6.AffectedTrace{appliedRecord},
+			matchTrace: grype.MatchTrace{
+				Matchers: []grype.MatcherTrace{{
+					Matcher: match.DpkgMatcher,
+					ExplicitlyIgnored: []match.IgnoredMatch{
+						{Match: tracedMatch, AppliedIgnoreRules: []match.IgnoreRule{{Vulnerability: "CVE-2023-5678", Reason: "Explicit ignore"}}},
+					},
+				}},
+			},
+			expected: "CVE-2023-5678 is matched, but ignored by a built-in rule or a database exclusion",
+		},
+		{
+			name:    "ignored by another matcher",
+			records: []v6.AffectedTrace{appliedRecord},
+			matchTrace: grype.MatchTrace{
+				Matchers: []grype.MatcherTrace{{
+					Matcher: match.DpkgMatcher,
+					MatcherIgnored: []match.IgnoredMatch{
+						{Match: tracedMatch, AppliedIgnoreRules: []match.IgnoreRule{{Vulnerability: "CVE-2023-5678", Reason: "Distro Packages Only"}}},
+					},
+				}},
+			},
+			expected: "CVE-2023-5678 is matched, but ignored by a rule from another matcher (e.g. a distro fix for the package)",
+		},
+		{
+			name:       "no records",
+			matchTrace: grype.MatchTrace{Matchers: []grype.MatcherTrace{{Matcher: match.DpkgMatcher}}},
+			expected:   "CVE-2023-5678 has no affected package or CPE records in the database",
+		},
+		{
+			name:       "record applies but the matcher failed",
+			records:    []v6.AffectedTrace{appliedRecord},
+			matchTrace: grype.MatchTrace{Matchers: []grype.MatcherTrace{{Matcher: match.DpkgMatcher, Err: errors.New("bad things")}}},
+			expected:   "a record for CVE-2023-5678 applies to the package, but a matcher failed",
+		},
+		{
+			name:       "no record applies",
+			records:    []v6.AffectedTrace{unappliedRecord},
+			matchTrace: grype.MatchTrace{Matchers: []grype.MatcherTrace{{Matcher: match.DpkgMatcher}}},
+			expected:   "none of the 1 records for CVE-2023-5678 apply to the package",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			trace := explain.NewTrace(tracedPackage, "CVE-2023-5678", tt.records, tt.matchTrace)
+			assert.Equal(t, tt.matched, trace.Matched)
+			assert.Equal(t, tt.expected, trace.Summary)
+		})
+	}
+}
+
+func TestTraceSnapshot(t *testing.T) {
+	trace := explain.NewTrace(tracedPackage, "CVE-2023-5678", []v6.AffectedTrace{appliedRecord, unappliedRecord}, grype.MatchTrace{
+		Matchers: []grype.MatcherTrace{{Matcher: match.DpkgMatcher, Matches: []match.Match{tracedMatch}}},
+		Ignored: []match.IgnoredMatch{
+			{Match: tracedMatch, AppliedIgnoreRules: []match.IgnoreRule{{Vulnerability: "CVE-2023-5678", Reason: "not exploitable"}}},
+		},
+	})
+
+	t.Run("text", func(t *testing.T) {
+		w := bytes.NewBufferString("")
+		require.NoError(t, explain.WriteTrace(w, trace))
+		snaps.MatchSnapshot(t, w.String())
+	})
+
+	t.Run("json", func(t *testing.T) {
+		w := bytes.NewBufferString("")
+		require.NoError(t, explain.WriteTraceJSON(w, trace))
+		snaps.MatchSnapshot(t, w.String())
+	})
+}
@@ -16,6 +16,9 @@ type IgnoreRule struct {
 	VexStatus        string             `json:"vex-status,omitempty"`
 	VexJustification string             `json:"vex-justification,omitempty"`
 	MatchType        string             `json:"match-type,omitempty"`
+	Expires          string             `json:"expires,omitempty"`
+	Owner            string             `json:"owner,omitempty"`
+	Ticket           string             `json:"ticket,omitempty"`
 }
 
 type IgnoreRulePackage struct {
@@ -27,7 +30,8 @@ type IgnoreRulePackage struct {
 	UpstreamName string `json:"upstream-name,omitempty"`
 }
 
-func newIgnoreRule(r match.IgnoreRule) IgnoreRule {
+// NewIgnoreRule creates the presentation model of an ignore rule
+func NewIgnoreRule(r match.IgnoreRule) IgnoreRule {
 	var ignoreRulePackage *IgnoreRulePackage
 
 	// We'll only set the package part of the rule not to `nil` if there are any values to fill out.
@@ -51,6 +55,9 @@ func newIgnoreRule(r match.IgnoreRule) IgnoreRule {
 		VexStatus:        r.VexStatus,
 		VexJustification: r.VexJustification,
 		MatchType:        string(r.MatchType),
+		Expires:          r.Expires,
+		Owner:            r.Owner,
+		Ticket:           r.Ticket,
 	}
 }
 
@@ -58,7 +65,7 @@ func mapIgnoreRules(rules []match.IgnoreRule) []IgnoreRule {
 	var result []IgnoreRule
 
 	for _, rule := range rules {
-		result = append(result, newIgnoreRule(rule))
+		result = append(result, NewIgnoreRule(rule))
 	}
 
 	return result
@@ -75,6 +75,23 @@ func TestNewIgnoreRule(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "accountability fields",
+			input: match.IgnoreRule{
+				Vulnerability: "CVE-2020-1234",
+				Reason:        "not reachable",
+				Expires:       "2024-12-31",
+				Owner:         "security-team",
+				Ticket:        "SEC-1234",
+			},
+			expected: IgnoreRule{
+				Vulnerability: "CVE-2020-1234",
+				Reason:        "not reachable",
+				Expires:       "2024-12-31",
+				Owner:         "security-team",
+				Ticket:        "SEC-1234",
+			},
+		},
 		{
 			name: "all fields",
 			input: match.IgnoreRule{
@@ -102,7 +119,7 @@ func TestNewIgnoreRule(t *testing.T) {
 
 	for _, testCase := range cases {
 		t.Run(testCase.name, func(t *testing.T) {
-			actual := newIgnoreRule(testCase.input)
+			actual := NewIgnoreRule(testCase.input)
 			if diff := cmp.Diff(testCase.expected, actual); diff != "" {
 				t.Errorf("(-expected +actual):\n%s", diff)
 			}
@@ -2,7 +2,9 @@ package models
 
 import (
 	"fmt"
+	"slices"
 	"sort"
+	"strings"
 
 	"github.com/anchore/grype/grype/match"
 	"github.com/anchore/grype/grype/pkg"
@@ -23,16 +25,52 @@ type Match struct {
 type MatchDetails struct {
 	Type       string      `json:"type"`
 	Matcher    string      `json:"matcher"`
-	SearchedBy interface{} `json:"searchedBy"` // The specific attributes that were used to search (other than package name and version) --this indicates "how" the match was made.
-	Found      interface{} `json:"found"`      // The specific attributes on the vulnerability object that were matched with --this indicates "what" was matched on / within.
+	SearchedBy interface{} `json:"searchedBy"`           // The specific attributes that were used to search (other than package name and version) --this indicates "how" the match was made.
+	Found      interface{} `json:"found"`                // The specific attributes on the vulnerability object that were matched with --this indicates "what" was matched on / within.
+	Confidence float64     `json:"confidence,omitempty"` // The certainty of the match as a ratio (0-1).
 	Fix        *FixDetails `json:"fix,omitempty"`
 }
 
+// Confidence returns the highest confidence of all match details, or 0 when unknown
+func (m Match) Confidence() float64 {
+	var confidence float64
+	for _, d := range m.MatchDetails {
+		confidence = max(confidence, d.Confidence)
+	}
+	return confidence
+}
+
 // FixDetails contains any data that is relevant to fixing the vulnerability specific to the package searched with
 type FixDetails struct {
 	SuggestedVersion string `json:"suggestedVersion"`
 }
 
+// FindingKey identifies the finding reported by the match (the vulnerability and the package it was found in), so that
+// the same finding can be recognized in other reports regardless of how it was matched
+func (m Match) FindingKey() string {
+	var locations []string
+	for _, l := range m.Artifact.Locations {
+		locations = append(locations, l.RealPath)
+	}
+	return FindingKey(m.Vulnerability.ID, m.Artifact.Name, m.Artifact.Version, string(m.Artifact.Type), locations)
+}
+
+// FindingKey identifies the finding of the given vulnerability in the package with the given name, version, type and
+// locations (which may be in any order), as returned by Match.FindingKey for a reported match
+func FindingKey(vulnerabilityID, name, version, pkgType string, locations []string) string {
+	locations = slices.Clone(locations)
+	sort.Strings(locations)
+	locations = slices.Compact(locations)
+
+	return strings.Join([]string{
+		vulnerabilityID,
+		name,
+		version,
+		pkgType,
+		strings.Join(locations, ","),
+	}, "|")
+}
+
 func newMatch(m match.Match, p pkg.Package, metadataProvider vulnerability.MetadataProvider) (*Match, error) {
 	relatedVulnerabilities := make([]VulnerabilityMetadata, 0)
 	for _, r := range m.Vulnerability.RelatedVulnerabilities {
@@ -65,6 +103,7 @@ func newMatch(m match.Match, p pkg.Package, metadataProvider vulnerability.Metad
 			Matcher:    string(d.Matcher),
 			SearchedBy: d.SearchedBy,
 			Found:      d.Found,
+			Confidence: d.Confidence,
 			Fix:        getFix(m, p, format),
 		}
 	}
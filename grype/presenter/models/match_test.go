@@ -0,0 +1,37 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/anchore/syft/syft/file"
+	syftPkg "github.com/anchore/syft/syft/pkg"
+)
+
+func TestMatch_FindingKey(t *testing.T) {
+	m := Match{
+		Vulnerability: Vulnerability{VulnerabilityMetadata: VulnerabilityMetadata{ID: "CVE-2024-0001"}},
+		Artifact: Package{
+			Name:    "lodash",
+			Version: "4.17.15",
+			Type:    syftPkg.NpmPkg,
+			Locations: file.Locations{
+				file.NewLocation("/b/package.json"),
+				file.NewLocation("/a/package.json"),
+				file.NewLocation("/b/package.json"),
+			},
+		},
+	}
+
+	assert.Equal(t, "CVE-2024-0001|lodash|4.17.15|npm|/a/package.json,/b/package.json", m.FindingKey())
+
+	// the key of a match being reported is the same as the key of the match once reported, regardless of location order
+	locations := []string{"/b/package.json", "/a/package.json"}
+	assert.Equal(t, m.FindingKey(), FindingKey("CVE-2024-0001", "lodash", "4.17.15", "npm", locations))
+	assert.Equal(t, []string{"/b/package.json", "/a/package.json"}, locations, "the given locations should not be modified")
+
+	other := m
+	other.Artifact.Version = "4.17.21"
+	assert.NotEqual(t, m.FindingKey(), other.FindingKey())
+}
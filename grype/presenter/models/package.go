@@ -17,12 +17,19 @@ type Package struct {
 	Language     syftPkg.Language  `json:"language"`
 	Licenses     []string          `json:"licenses"`
 	CPEs         []string          `json:"cpes"`
+	CPESources   []CPESource       `json:"cpeSources,omitempty"` // where each CPE (with a known source) came from
 	PURL         string            `json:"purl"`
 	Upstreams    []UpstreamPackage `json:"upstreams"`
 	MetadataType string            `json:"metadataType,omitempty"`
 	Metadata     interface{}       `json:"metadata,omitempty"`
 }
 
+// CPESource records where a CPE of a package came from (e.g. declared in the SBOM, generated, or user-configured)
+type CPESource struct {
+	CPE    string `json:"cpe"`
+	Source string `json:"source"`
+}
+
 type UpstreamPackage struct {
 	Name    string `json:"name"`
 	Version string `json:"version,omitempty"`
@@ -30,9 +37,13 @@ type UpstreamPackage struct {
 
 func newPackage(p pkg.Package) Package {
 	var cpes = make([]string, 0)
+	var cpeSources []CPESource
 	for _, c := range p.CPEs {
 		// use .String() to ensure proper escaping
 		cpes = append(cpes, c.Attributes.String())
+		if c.Source != "" {
+			cpeSources = append(cpeSources, CPESource{CPE: c.Attributes.String(), Source: string(c.Source)})
+		}
 	}
 
 	licenses := p.Licenses
@@ -57,6 +68,7 @@ func newPackage(p pkg.Package) Package {
 		Language:     p.Language,
 		Type:         p.Type,
 		CPEs:         cpes,
+		CPESources:   cpeSources,
 		PURL:         p.PURL,
 		Upstreams:    upstreams,
 		MetadataType: packagemetadata.JSONName(p.Metadata),
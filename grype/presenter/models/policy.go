@@ -0,0 +1,17 @@
+package models
+
+// PolicyEvaluation is the result of evaluating a policy file against the reported matches
+type PolicyEvaluation struct {
+	Status     string            `json:"status"`
+	Violations []PolicyViolation `json:"violations"`
+}
+
+// PolicyViolation describes a policy rule that was tripped by a single match
+type PolicyViolation struct {
+	Rule          string `json:"rule"`
+	Action        string `json:"action"`
+	Vulnerability string `json:"vulnerability"`
+	Package       string `json:"package"`
+	Version       string `json:"version"`
+	Type          string `json:"type"`
+}
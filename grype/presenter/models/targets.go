@@ -0,0 +1,45 @@
+package models
+
+import (
+	"github.com/anchore/grype/grype/vulnerability"
+)
+
+// TargetsDocument represents the JSON document presented when multiple targets are scanned in a single invocation,
+// with the document for each target keyed by the target as it was given.
+type TargetsDocument struct {
+	Targets map[string]Document `json:"targets"`
+	Summary []TargetSummary     `json:"summary"`
+}
+
+// TargetSummary describes the findings for a single target of a multi-target scan.
+type TargetSummary struct {
+	Target     string         `json:"target"`
+	Packages   int            `json:"packages"`
+	Matches    int            `json:"matches"`
+	Ignored    int            `json:"ignored"`
+	Severities map[string]int `json:"severities"`
+	Policy     string         `json:"policy,omitempty"`
+	Error      string         `json:"error,omitempty"`
+}
+
+// NewTargetSummary summarizes the findings reported for a target, counting the (non-ignored) matches by severity.
+func NewTargetSummary(target string, packages int, doc Document) TargetSummary {
+	severities := make(map[string]int)
+	for _, m := range doc.Matches {
+		severities[vulnerability.ParseSeverity(m.Vulnerability.Severity).String()]++
+	}
+
+	var policyStatus string
+	if doc.Policy != nil {
+		policyStatus = doc.Policy.Status
+	}
+
+	return TargetSummary{
+		Target:     target,
+		Packages:   packages,
+		Matches:    len(doc.Matches),
+		Ignored:    len(doc.IgnoredMatches),
+		Severities: severities,
+		Policy:     policyStatus,
+	}
+}
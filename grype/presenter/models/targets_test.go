@@ -0,0 +1,35 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewTargetSummary(t *testing.T) {
+	doc := Document{
+		Matches: []Match{
+			{Vulnerability: Vulnerability{VulnerabilityMetadata: VulnerabilityMetadata{ID: "CVE-1", Severity: "Critical"}}},
+			{Vulnerability: Vulnerability{VulnerabilityMetadata: VulnerabilityMetadata{ID: "CVE-2", Severity: "High"}}},
+			{Vulnerability: Vulnerability{VulnerabilityMetadata: VulnerabilityMetadata{ID: "CVE-3", Severity: "high"}}},
+			{Vulnerability: Vulnerability{VulnerabilityMetadata: VulnerabilityMetadata{ID: "CVE-4", Severity: ""}}},
+		},
+		IgnoredMatches: []IgnoredMatch{
+			{Match: Match{Vulnerability: Vulnerability{VulnerabilityMetadata: VulnerabilityMetadata{ID: "CVE-5", Severity: "Critical"}}}},
+		},
+		Policy: &PolicyEvaluation{Status: "fail"},
+	}
+
+	assert.Equal(t, TargetSummary{
+		Target:   "alpine:3.20",
+		Packages: 12,
+		Matches:  4,
+		Ignored:  1,
+		Severities: map[string]int{
+			"critical": 1,
+			"high":     2,
+			"unknown":  1,
+		},
+		Policy: "fail",
+	}, NewTargetSummary("alpine:3.20", 12, doc))
+}
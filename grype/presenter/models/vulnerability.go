@@ -18,6 +18,7 @@ type Vulnerability struct {
 type Fix struct {
 	Versions []string `json:"versions"`
 	State    string   `json:"state"`
+	Date     string   `json:"date,omitempty"`
 }
 
 type Advisory struct {
@@ -51,6 +52,7 @@ func NewVulnerability(vuln vulnerability.Vulnerability, metadata *vulnerability.
 		Fix: Fix{
 			Versions: sortVersions(fixedInVersions, versionFormat),
 			State:    string(vuln.Fix.State),
+			Date:     formatDate(vuln.Fix.Date),
 		},
 		Advisories: advisories,
 		Risk:       metadata.RiskScore(),
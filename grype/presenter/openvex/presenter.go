@@ -0,0 +1,267 @@
+package openvex
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	gopenvex "github.com/openvex/go-vex/pkg/vex"
+
+	"github.com/anchore/clio"
+	"github.com/anchore/grype/grype/baseline"
+	"github.com/anchore/grype/grype/match"
+	"github.com/anchore/grype/grype/presenter/models"
+	"github.com/anchore/grype/grype/vex/openvex"
+	"github.com/anchore/grype/internal/log"
+	syftSource "github.com/anchore/syft/syft/source"
+)
+
+// Presenter writes the scan results as an OpenVEX document: matches become "affected"
+// statements and matches ignored for a VEX status or a documented reason become
+// "not_affected" or "fixed" statements (or "under_investigation" when only ignored for a low confidence).
+type Presenter struct {
+	id       clio.Identification
+	document models.Document
+	name     string
+}
+
+func NewPresenter(pb models.PresenterConfig) *Presenter {
+	var name string
+	if pb.SBOM != nil {
+		name = pb.SBOM.Source.Name
+	}
+	return &Presenter{
+		id:       pb.ID,
+		document: pb.Document,
+		name:     name,
+	}
+}
+
+// Present creates an OpenVEX document from the scan results and writes it to the given writer
+func (p *Presenter) Present(output io.Writer) error {
+	doc, err := p.vexDocument()
+	if err != nil {
+		return err
+	}
+	return doc.ToJSON(output)
+}
+
+func (p *Presenter) vexDocument() (*gopenvex.VEX, error) {
+	doc := gopenvex.New()
+	doc.Author = p.id.Name
+	doc.Tooling = strings.TrimSpace(fmt.Sprintf("%s %s", p.id.Name, p.id.Version))
+
+	if ts, err := time.Parse(time.RFC3339Nano, p.document.Descriptor.Timestamp); err == nil {
+		doc.Timestamp = &ts
+	}
+
+	product := p.product()
+
+	b := newStatementBuilder(product)
+	for _, m := range p.document.Matches {
+		b.add(m, affectedStatement(m))
+	}
+
+	for _, m := range p.document.IgnoredMatches {
+		s, ok := ignoredStatement(m)
+		if !ok {
+			continue
+		}
+		b.add(m.Match, s)
+	}
+
+	doc.Statements = b.statements()
+
+	if _, err := doc.GenerateCanonicalID(); err != nil {
+		return nil, fmt.Errorf("generating openvex document ID: %w", err)
+	}
+
+	return &doc, nil
+}
+
+// product returns the scanned image as a VEX product, or nil when the scan target was not an image,
+// in which case each package is described as a product of its own.
+func (p *Presenter) product() *gopenvex.Component {
+	if p.document.Source == nil {
+		return nil
+	}
+
+	metadata, ok := p.document.Source.Target.(syftSource.ImageMetadata)
+	if !ok {
+		return nil
+	}
+
+	name := p.name
+	if name == "" {
+		name = metadata.UserInput
+	}
+
+	identifiers := openvex.ProductIdentifiersFromImage(name, metadata)
+	if len(identifiers) == 0 {
+		return nil
+	}
+
+	// prefer the digest pURL since it is the most specific identifier for the image
+	id := identifiers[0]
+	for _, i := range identifiers {
+		if strings.HasPrefix(i, "pkg:oci/") && strings.Contains(i, "@sha256") {
+			id = i
+			break
+		}
+	}
+
+	c := &gopenvex.Component{ID: id}
+	if strings.HasPrefix(id, "pkg:") {
+		c.Identifiers = map[gopenvex.IdentifierType]string{gopenvex.PURL: id}
+	}
+	return c
+}
+
+// affectedStatement describes a match that grype reported
+func affectedStatement(m models.Match) gopenvex.Statement {
+	action := "No fix is available"
+	if len(m.Vulnerability.Fix.Versions) > 0 {
+		action = fmt.Sprintf("Upgrade %s to %s", m.Artifact.Name, strings.Join(m.Vulnerability.Fix.Versions, ", "))
+	}
+
+	return gopenvex.Statement{
+		Vulnerability:   vulnerability(m),
+		Status:          gopenvex.StatusAffected,
+		ActionStatement: action,
+	}
+}
+
+// ignoredStatement describes an ignored match when one of the applied ignore rules asserts the
+// impact of the vulnerability: either a VEX status or a rule with a documented reason. Matches that
+// were ignored for other reasons (e.g. --only-fixed or a baseline) make no claim about the impact and are skipped.
+// Matches that were only ignored for a low confidence (--min-confidence) may still be affected, so are
+// described as under investigation rather than not affected.
+func ignoredStatement(m models.IgnoredMatch) (gopenvex.Statement, bool) {
+	var lowConfidence *models.IgnoreRule
+	for _, r := range m.AppliedIgnoreRules {
+		switch {
+		case r.VexStatus == string(gopenvex.StatusFixed):
+			return gopenvex.Statement{
+				Vulnerability: vulnerability(m.Match),
+				Status:        gopenvex.StatusFixed,
+			}, true
+		case r.VexStatus == string(gopenvex.StatusNotAffected):
+			return gopenvex.Statement{
+				Vulnerability:   vulnerability(m.Match),
+				Status:          gopenvex.StatusNotAffected,
+				Justification:   gopenvex.Justification(r.VexJustification),
+				ImpactStatement: impactStatement(r),
+			}, true
+		case r.VexStatus == "" && match.IsLowConfidenceReason(r.Reason):
+			lowConfidence = &r
+		case r.VexStatus == "" && r.Reason != "" && !baseline.IsBaselineRule(r):
+			return gopenvex.Statement{
+				Vulnerability:   vulnerability(m.Match),
+				Status:          gopenvex.StatusNotAffected,
+				ImpactStatement: r.Reason,
+			}, true
+		}
+	}
+	if lowConfidence != nil {
+		return gopenvex.Statement{
+			Vulnerability: vulnerability(m.Match),
+			Status:        gopenvex.StatusUnderInvestigation,
+			StatusNotes:   lowConfidence.Reason,
+		}, true
+	}
+	return gopenvex.Statement{}, false
+}
+
+func impactStatement(r models.IgnoreRule) string {
+	if r.Reason != "" {
+		return r.Reason
+	}
+	if r.VexJustification == "" {
+		// not_affected statements require either a justification or an impact statement
+		return "marked as not affected by a VEX statement"
+	}
+	return ""
+}
+
+func vulnerability(m models.Match) gopenvex.Vulnerability {
+	v := gopenvex.Vulnerability{
+		Name:        gopenvex.VulnerabilityID(m.Vulnerability.ID),
+		Description: m.Vulnerability.Description,
+	}
+	if m.Vulnerability.DataSource != "" {
+		v.ID = m.Vulnerability.DataSource
+	}
+	for _, r := range m.RelatedVulnerabilities {
+		if r.ID == m.Vulnerability.ID {
+			continue
+		}
+		v.Aliases = append(v.Aliases, gopenvex.VulnerabilityID(r.ID))
+	}
+	return v
+}
+
+// packageComponent returns the OpenVEX component that identifies the package in a match
+func packageComponent(p models.Package) (gopenvex.Component, bool) {
+	if p.PURL != "" {
+		return gopenvex.Component{
+			ID:          p.PURL,
+			Identifiers: map[gopenvex.IdentifierType]string{gopenvex.PURL: p.PURL},
+		}, true
+	}
+	if len(p.CPEs) > 0 {
+		return gopenvex.Component{
+			Identifiers: map[gopenvex.IdentifierType]string{gopenvex.CPE23: p.CPEs[0]},
+		}, true
+	}
+	return gopenvex.Component{}, false
+}
+
+// statementBuilder groups packages that share the same assertion about a vulnerability into a
+// single statement, preserving the order in which statements were first seen.
+type statementBuilder struct {
+	product *gopenvex.Component
+	order   []string
+	byKey   map[string]*gopenvex.Statement
+}
+
+func newStatementBuilder(product *gopenvex.Component) *statementBuilder {
+	return &statementBuilder{
+		product: product,
+		byKey:   make(map[string]*gopenvex.Statement),
+	}
+}
+
+func (b *statementBuilder) add(m models.Match, s gopenvex.Statement) {
+	component, ok := packageComponent(m.Artifact)
+	if !ok {
+		log.WithFields("vuln", m.Vulnerability.ID, "package", m.Artifact.Name).Debug("unable to identify package for openvex statement")
+		return
+	}
+
+	key := strings.Join([]string{string(s.Vulnerability.Name), string(s.Status), string(s.Justification), s.ImpactStatement, s.ActionStatement}, "|")
+	existing, ok := b.byKey[key]
+	if !ok {
+		existing = &s
+		b.byKey[key] = existing
+		b.order = append(b.order, key)
+	}
+
+	if b.product == nil {
+		existing.Products = append(existing.Products, gopenvex.Product{Component: component})
+		return
+	}
+
+	if len(existing.Products) == 0 {
+		existing.Products = []gopenvex.Product{{Component: *b.product}}
+	}
+	existing.Products[0].Subcomponents = append(existing.Products[0].Subcomponents, gopenvex.Subcomponent{Component: component})
+}
+
+func (b *statementBuilder) statements() []gopenvex.Statement {
+	out := make([]gopenvex.Statement, 0, len(b.order))
+	for _, k := range b.order {
+		out = append(out, *b.byKey[k])
+	}
+	return out
+}
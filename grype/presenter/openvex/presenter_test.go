@@ -0,0 +1,199 @@
+package openvex
+
+import (
+	"bytes"
+	"flag"
+	"regexp"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	gopenvex "github.com/openvex/go-vex/pkg/vex"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/anchore/clio"
+	"github.com/anchore/go-testutils"
+	"github.com/anchore/grype/grype/match"
+	"github.com/anchore/grype/grype/pkg"
+	"github.com/anchore/grype/grype/presenter/internal"
+	"github.com/anchore/grype/grype/presenter/models"
+	"github.com/anchore/syft/syft/source"
+)
+
+var update = flag.Bool("update", false, "update the *.golden files for openvex presenters")
+
+var (
+	timestampRegexp  = regexp.MustCompile(`"timestamp":\s*"[^"]+"`)
+	documentIDRegexp = regexp.MustCompile(`"@id":\s*"https://openvex.dev/docs/public/vex-[0-9a-f]+"`)
+)
+
+func TestOpenVEXImgsPresenter(t *testing.T) {
+	pb := internal.GeneratePresenterConfig(t, internal.ImageSource)
+	assertPresenterAgainstGoldenSnapshot(t, NewPresenter(pb))
+}
+
+func TestOpenVEXDirsPresenter(t *testing.T) {
+	pb := internal.GeneratePresenterConfig(t, internal.DirectorySource)
+	assertPresenterAgainstGoldenSnapshot(t, NewPresenter(pb))
+}
+
+func TestOpenVEXIgnoredMatchesPresenter(t *testing.T) {
+	pb := models.PresenterConfig{
+		ID:       clio.Identification{Name: "grype", Version: "[not provided]"},
+		Document: internal.GenerateAnalysisWithIgnoredMatches(t, internal.ImageSource),
+	}
+	assertPresenterAgainstGoldenSnapshot(t, NewPresenter(pb))
+}
+
+func TestOpenVEXPresenter_LowConfidenceIgnores(t *testing.T) {
+	doc := internal.GenerateAnalysisWithIgnoredMatches(t, internal.ImageSource)
+	require.NotEmpty(t, doc.IgnoredMatches)
+	for i, m := range doc.IgnoredMatches {
+		doc.IgnoredMatches[i].AppliedIgnoreRules = []models.IgnoreRule{
+			{Vulnerability: m.Vulnerability.ID, Reason: match.LowConfidenceReasonPrefix + ": 0.35 is below the minimum of 0.50"},
+		}
+	}
+
+	var buffer bytes.Buffer
+	require.NoError(t, NewPresenter(models.PresenterConfig{Document: doc}).Present(&buffer))
+
+	vexDoc, err := gopenvex.Parse(buffer.Bytes())
+	require.NoError(t, err)
+
+	ignored := make(map[string]bool)
+	for _, m := range doc.IgnoredMatches {
+		ignored[m.Vulnerability.ID] = true
+	}
+
+	var underInvestigation int
+	for _, s := range vexDoc.Statements {
+		// a low confidence match may still be affected, so is never claimed to be not affected
+		assert.NotEqual(t, gopenvex.StatusNotAffected, s.Status, string(s.Vulnerability.Name))
+		if s.Status == gopenvex.StatusUnderInvestigation {
+			assert.True(t, ignored[string(s.Vulnerability.Name)], string(s.Vulnerability.Name))
+			underInvestigation++
+		}
+	}
+	assert.Equal(t, len(doc.IgnoredMatches), underInvestigation)
+}
+
+func TestImageProduct(t *testing.T) {
+	ctx := pkg.Context{
+		Source: &source.Description{
+			Name: "alpine",
+			Metadata: source.ImageMetadata{
+				UserInput:   "alpine:3.17",
+				Tags:        []string{"alpine:3.17"},
+				RepoDigests: []string{"alpine@sha256:124c7d2707904eea7431fffe91522a01e5a861a624ee31d03372cc1d138a3126"},
+			},
+		},
+	}
+
+	doc, err := models.NewDocument(clio.Identification{Name: "grype"}, nil, ctx, match.NewMatches(), nil, models.NewMetadataMock(), nil, nil, models.SortByPackage)
+	require.NoError(t, err)
+
+	product := NewPresenter(models.PresenterConfig{Document: doc}).product()
+	require.NotNil(t, product)
+
+	expected := "pkg:oci/alpine@sha256%3A124c7d2707904eea7431fffe91522a01e5a861a624ee31d03372cc1d138a3126?repository_url=index.docker.io%2Flibrary"
+	assert.Equal(t, expected, product.ID)
+	assert.Equal(t, expected, product.Identifiers[gopenvex.PURL])
+}
+
+func TestIgnoredStatement(t *testing.T) {
+	m := models.Match{
+		Vulnerability: models.Vulnerability{
+			VulnerabilityMetadata: models.VulnerabilityMetadata{ID: "CVE-2023-1234"},
+		},
+	}
+
+	tests := []struct {
+		name   string
+		rules  []models.IgnoreRule
+		want   gopenvex.Status
+		reason string
+		notes  string
+		skip   bool
+	}{
+		{
+			name:  "vex fixed",
+			rules: []models.IgnoreRule{{VexStatus: string(gopenvex.StatusFixed)}},
+			want:  gopenvex.StatusFixed,
+		},
+		{
+			name:   "vex not affected without justification",
+			rules:  []models.IgnoreRule{{VexStatus: string(gopenvex.StatusNotAffected)}},
+			want:   gopenvex.StatusNotAffected,
+			reason: "marked as not affected by a VEX statement",
+		},
+		{
+			name:   "rule with a reason",
+			rules:  []models.IgnoreRule{{Vulnerability: "CVE-2023-1234", Reason: "not reachable in our deployment"}},
+			want:   gopenvex.StatusNotAffected,
+			reason: "not reachable in our deployment",
+		},
+		{
+			name:  "baseline rule",
+			rules: []models.IgnoreRule{{Vulnerability: "CVE-2023-1234", Reason: "baseline"}},
+			skip:  true,
+		},
+		{
+			name:  "fix state rule",
+			rules: []models.IgnoreRule{{FixState: "not-fixed"}},
+			skip:  true,
+		},
+		{
+			name:  "low confidence rule",
+			rules: []models.IgnoreRule{{Vulnerability: "CVE-2023-1234", Reason: "low-confidence: 0.35 is below the minimum of 0.50"}},
+			want:  gopenvex.StatusUnderInvestigation,
+			notes: "low-confidence: 0.35 is below the minimum of 0.50",
+		},
+		{
+			name: "low confidence rule with a documented reason",
+			rules: []models.IgnoreRule{
+				{Vulnerability: "CVE-2023-1234", Reason: "low-confidence: 0.35 is below the minimum of 0.50"},
+				{Vulnerability: "CVE-2023-1234", Reason: "not reachable in our deployment"},
+			},
+			want:   gopenvex.StatusNotAffected,
+			reason: "not reachable in our deployment",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := ignoredStatement(models.IgnoredMatch{Match: m, AppliedIgnoreRules: tt.rules})
+			if tt.skip {
+				assert.False(t, ok)
+				return
+			}
+			require.True(t, ok)
+			assert.Equal(t, tt.want, got.Status)
+			assert.Equal(t, tt.reason, got.ImpactStatement)
+			assert.Equal(t, tt.notes, got.StatusNotes)
+			assert.Equal(t, gopenvex.VulnerabilityID("CVE-2023-1234"), got.Vulnerability.Name)
+		})
+	}
+}
+
+func assertPresenterAgainstGoldenSnapshot(t *testing.T, pres *Presenter) {
+	t.Helper()
+
+	var buffer bytes.Buffer
+	require.NoError(t, pres.Present(&buffer))
+
+	actual := redact(buffer.Bytes())
+
+	if *update {
+		testutils.UpdateGoldenFileContents(t, actual)
+	}
+
+	expected := testutils.GetGoldenFileContents(t)
+
+	if d := cmp.Diff(string(expected), string(actual)); d != "" {
+		t.Fatalf("diff: %s", d)
+	}
+}
+
+func redact(content []byte) []byte {
+	content = timestampRegexp.ReplaceAll(content, []byte(`"timestamp": ""`))
+	return documentIDRegexp.ReplaceAll(content, []byte(`"@id": ""`))
+}
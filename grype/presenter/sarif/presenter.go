@@ -372,7 +372,7 @@ func shortDescription(m models.Match) string {
 func (p Presenter) sarifResults() []*sarif.Result {
 	out := make([]*sarif.Result, 0) // make sure we have at least an empty array
 	for _, m := range p.document.Matches {
-		out = append(out, &sarif.Result{
+		result := &sarif.Result{
 			RuleID:  sp(p.ruleID(m)),
 			Level:   sp(levelValue(m)),
 			Message: p.resultMessage(m),
@@ -382,7 +382,11 @@ func (p Presenter) sarifResults() []*sarif.Result {
 			// when using the CodeQL upload action. See: https://docs.github.com/en/code-security/code-scanning/integrating-with-code-scanning/sarif-support-for-code-scanning#providing-data-to-track-code-scanning-alerts-across-runs
 			PartialFingerprints: p.partialFingerprints(m),
 			Locations:           p.locations(m),
-		})
+		}
+		if confidence := m.Confidence(); confidence > 0 {
+			result.Add("confidence", confidence)
+		}
+		out = append(out, result)
 	}
 	return out
 }
@@ -11,14 +11,18 @@ import (
 	"github.com/olekukonko/tablewriter/tw"
 	"github.com/scylladb/go-set/strset"
 
+	"github.com/anchore/grype/grype/baseline"
 	"github.com/anchore/grype/grype/db/v5/namespace/distro"
+	"github.com/anchore/grype/grype/match"
 	"github.com/anchore/grype/grype/presenter/models"
 	"github.com/anchore/grype/grype/vulnerability"
 )
 
 const (
-	appendSuppressed    = "suppressed"
-	appendSuppressedVEX = "suppressed by VEX"
+	appendSuppressed         = "suppressed"
+	appendSuppressedVEX      = "suppressed by VEX"
+	appendSuppressedBaseline = "suppressed by baseline"
+	appendSuppressedLowConf  = "suppressed by low confidence"
 )
 
 // Presenter is a generic struct for holding fields needed for reporting
@@ -200,6 +204,14 @@ func (p *Presenter) getRows(doc models.Document, showSuppressed bool) rows {
 						msg = appendSuppressedVEX
 					}
 				}
+				if baseline.IsBaselineRule(m.AppliedIgnoreRules...) {
+					msg = appendSuppressedBaseline
+				}
+				for i := range m.AppliedIgnoreRules {
+					if match.IsLowConfidenceReason(m.AppliedIgnoreRules[i].Reason) {
+						msg = appendSuppressedLowConf
+					}
+				}
 			}
 			rs = append(rs, p.newRow(m.Match, msg, multipleDistros))
 		}
@@ -220,6 +232,11 @@ func (p *Presenter) newRow(m models.Match, extraAnnotation string, showDistro bo
 		}
 	}
 
+	// only less certain matches (e.g. CPE matches) are annotated, to draw attention to possible false positives
+	if confidence := m.Confidence(); confidence > 0 && confidence < match.ExactIndirectMatchConfidence {
+		annotations = append(annotations, p.auxiliaryStyle.Render(fmt.Sprintf("%.0f%% confidence", confidence*100)))
+	}
+
 	if extraAnnotation != "" {
 		annotations = append(annotations, p.auxiliaryStyle.Render(extraAnnotation))
 	}
@@ -0,0 +1,202 @@
+package rescan
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/scylladb/go-set/strset"
+
+	"github.com/anchore/grype/grype/db/v6/differ"
+	"github.com/anchore/grype/grype/pkg"
+	"github.com/anchore/grype/grype/presenter/models"
+	"github.com/anchore/grype/internal/log"
+)
+
+// ReadDocument loads a previous grype JSON report
+func ReadDocument(path string) (*models.Document, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("unable to open grype report %q: %w", path, err)
+	}
+	defer log.CloseAndLogError(f, path)
+
+	var doc models.Document
+	if err := json.NewDecoder(f).Decode(&doc); err != nil {
+		return nil, fmt.Errorf("unable to parse grype report %q: %w", path, err)
+	}
+	return &doc, nil
+}
+
+// DBBuilt returns the build time of the vulnerability database recorded in the descriptor of a grype report
+func DBBuilt(doc models.Document) (time.Time, error) {
+	if doc.Descriptor.DB == nil {
+		return time.Time{}, fmt.Errorf("report does not describe the vulnerability database it was produced with")
+	}
+
+	by, err := json.Marshal(doc.Descriptor.DB)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("unable to read database description from report: %w", err)
+	}
+
+	var info struct {
+		Status *struct {
+			Built time.Time `json:"built"`
+		} `json:"status"`
+	}
+	if err := json.Unmarshal(by, &info); err != nil {
+		return time.Time{}, fmt.Errorf("unable to read database description from report: %w", err)
+	}
+
+	if info.Status == nil || info.Status.Built.IsZero() {
+		return time.Time{}, fmt.Errorf("report does not describe the build time of the vulnerability database")
+	}
+	return info.Status.Built, nil
+}
+
+// Changes are the package names, CPE products, and vulnerability IDs touched by the differences between two databases
+type Changes struct {
+	names           *strset.Set
+	products        *strset.Set
+	vulnerabilities *strset.Set
+}
+
+// NewChanges collects the records touched by the given database differences. Affected package and CPE changes
+// identify packages that may gain or lose matches, while vulnerability, KEV, and EPSS changes identify existing
+// matches whose details are out of date.
+func NewChanges(diff differ.Diff) Changes {
+	c := Changes{
+		names:           strset.New(),
+		products:        strset.New(),
+		vulnerabilities: strset.New(),
+	}
+
+	for _, p := range diff.AffectedPackages {
+		c.names.Add(packageNames(p.Package)...)
+	}
+	for _, a := range diff.AffectedCPEs {
+		c.products.Add(normalize(a.Product))
+	}
+	for _, v := range diff.Vulnerabilities {
+		c.vulnerabilities.Add(strings.ToLower(v.ID))
+	}
+	for _, k := range diff.KnownExploited {
+		c.vulnerabilities.Add(strings.ToLower(k.CVE))
+	}
+	for _, e := range diff.EPSS {
+		c.vulnerabilities.Add(strings.ToLower(e.CVE))
+	}
+
+	return c
+}
+
+// IsEmpty indicates that no packages or matches are touched by the changes
+func (c Changes) IsEmpty() bool {
+	return c.names.IsEmpty() && c.products.IsEmpty() && c.vulnerabilities.IsEmpty()
+}
+
+// Select returns the packages that need to be re-evaluated: packages with a name (or upstream name) or CPE product
+// touched by an affected record change, and packages previously matched to a changed vulnerability.
+func (c Changes) Select(packages []pkg.Package, previous models.Document) []pkg.Package {
+	stale := strset.New()
+	for _, m := range allMatches(previous) {
+		if c.touchesMatch(m) {
+			stale.Add(m.Artifact.ID)
+		}
+	}
+
+	var selected []pkg.Package
+	for _, p := range packages {
+		if stale.Has(string(p.ID)) || c.touchesPackage(p) {
+			selected = append(selected, p)
+		}
+	}
+	return selected
+}
+
+func (c Changes) touchesPackage(p pkg.Package) bool {
+	if c.names.Has(normalize(p.Name)) {
+		return true
+	}
+	for _, u := range p.Upstreams {
+		if c.names.Has(normalize(u.Name)) {
+			return true
+		}
+	}
+	for _, cp := range p.CPEs {
+		if c.products.Has(normalize(cp.Attributes.Product)) {
+			return true
+		}
+	}
+	return false
+}
+
+func (c Changes) touchesMatch(m models.Match) bool {
+	if c.vulnerabilities.Has(strings.ToLower(m.Vulnerability.ID)) {
+		return true
+	}
+	for _, r := range m.RelatedVulnerabilities {
+		if c.vulnerabilities.Has(strings.ToLower(r.ID)) {
+			return true
+		}
+	}
+	return false
+}
+
+// Merge combines the matches of a previous report with the matches of the re-evaluated packages. Previous matches for
+// re-evaluated packages are replaced, while all other previous matches are kept as-is. The source, distro, and
+// descriptor of the updated report are used.
+func Merge(previous, updated models.Document, reevaluated []pkg.Package, strategy models.SortStrategy) models.Document {
+	replaced := strset.New()
+	for _, p := range reevaluated {
+		replaced.Add(string(p.ID))
+	}
+
+	matches := make([]models.Match, 0, len(previous.Matches)+len(updated.Matches))
+	for _, m := range previous.Matches {
+		if !replaced.Has(m.Artifact.ID) {
+			matches = append(matches, m)
+		}
+	}
+	matches = append(matches, updated.Matches...)
+	models.SortMatches(matches, strategy)
+
+	var ignored []models.IgnoredMatch
+	for _, m := range previous.IgnoredMatches {
+		if !replaced.Has(m.Artifact.ID) {
+			ignored = append(ignored, m)
+		}
+	}
+	ignored = append(ignored, updated.IgnoredMatches...)
+
+	merged := updated
+	merged.Matches = matches
+	merged.IgnoredMatches = ignored
+	return merged
+}
+
+func allMatches(doc models.Document) []models.Match {
+	matches := append([]models.Match{}, doc.Matches...)
+	for _, m := range doc.IgnoredMatches {
+		matches = append(matches, m.Match)
+	}
+	return matches
+}
+
+// packageNames returns the names a package in the database may be known by within an SBOM: the name itself and,
+// for qualified names (e.g. Java "group:artifact" or Go module paths), the final component of the name
+func packageNames(name string) []string {
+	names := []string{normalize(name)}
+	if i := strings.LastIndexAny(name, ":/"); i >= 0 && i < len(name)-1 {
+		names = append(names, normalize(name[i+1:]))
+	}
+	return names
+}
+
+// normalize lowercases the name and treats "_" and "." separators as "-" (consistent with python package name
+// normalization), so that a change is never missed due to naming differences
+func normalize(name string) string {
+	return strings.NewReplacer("_", "-", ".", "-").Replace(strings.ToLower(name))
+}
@@ -0,0 +1,144 @@
+package rescan
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/anchore/grype/grype/db/v6/differ"
+	"github.com/anchore/grype/grype/pkg"
+	"github.com/anchore/grype/grype/presenter/models"
+	"github.com/anchore/syft/syft/cpe"
+)
+
+func TestDBBuilt(t *testing.T) {
+	built := time.Date(2025, 3, 14, 1, 31, 6, 0, time.UTC)
+
+	report := []byte(`{
+  "matches": [],
+  "descriptor": {
+    "name": "grype",
+    "version": "0.90.0",
+    "db": {
+      "status": {"schemaVersion": "v6.0.2", "built": "2025-03-14T01:31:06Z"},
+      "providers": {}
+    },
+    "timestamp": "2025-03-15T00:00:00Z"
+  }
+}`)
+	path := filepath.Join(t.TempDir(), "report.json")
+	require.NoError(t, os.WriteFile(path, report, 0600))
+
+	doc, err := ReadDocument(path)
+	require.NoError(t, err)
+
+	got, err := DBBuilt(*doc)
+	require.NoError(t, err)
+	assert.True(t, built.Equal(got))
+
+	_, err = DBBuilt(models.Document{})
+	require.ErrorContains(t, err, "does not describe the vulnerability database")
+
+	var noBuilt models.Document
+	require.NoError(t, json.Unmarshal([]byte(`{"descriptor": {"db": {"status": {"schemaVersion": "v6.0.2"}}}}`), &noBuilt))
+	_, err = DBBuilt(noBuilt)
+	require.ErrorContains(t, err, "build time")
+}
+
+func TestChanges_Select(t *testing.T) {
+	lodash := pkg.Package{ID: "lodash", Name: "lodash"}
+	commonsText := pkg.Package{ID: "commons-text", Name: "commons-text"}
+	musl := pkg.Package{ID: "musl-utils", Name: "musl-utils", Upstreams: []pkg.UpstreamPackage{{Name: "musl"}}}
+	pyYAML := pkg.Package{ID: "pyyaml", Name: "PyYAML"}
+	widget := pkg.Package{ID: "widget", Name: "acme-widget", CPEs: []cpe.CPE{cpe.Must("cpe:2.3:a:acme:widget:1.0:*:*:*:*:*:*:*", "")}}
+	previouslyMatched := pkg.Package{ID: "openssl", Name: "openssl"}
+	untouched := pkg.Package{ID: "zlib", Name: "zlib"}
+
+	diff := differ.Diff{
+		AffectedPackages: []differ.AffectedPackageDiff{
+			{Vulnerability: "GHSA-1", Ecosystem: "npm", Package: "lodash", Change: differ.Modified},
+			{Vulnerability: "GHSA-2", Ecosystem: "maven", Package: "org.apache.commons:commons-text", Change: differ.Added},
+			{Vulnerability: "CVE-2024-0001", Package: "musl", OS: "alpine@3.20", Change: differ.Modified},
+			{Vulnerability: "GHSA-3", Ecosystem: "pypi", Package: "pyyaml", Change: differ.Removed},
+		},
+		AffectedCPEs: []differ.AffectedCPEDiff{
+			{Vulnerability: "CVE-2024-0002", Vendor: "acme", Product: "widget", Change: differ.Added},
+		},
+		EPSS: []differ.EPSSDiff{
+			{CVE: "CVE-2024-0003", Change: differ.Modified},
+		},
+	}
+
+	previous := models.Document{
+		Matches: []models.Match{
+			{
+				Vulnerability:          models.Vulnerability{VulnerabilityMetadata: models.VulnerabilityMetadata{ID: "GHSA-openssl"}},
+				RelatedVulnerabilities: []models.VulnerabilityMetadata{{ID: "CVE-2024-0003"}},
+				Artifact:               models.Package{ID: "openssl"},
+			},
+			{
+				Vulnerability: models.Vulnerability{VulnerabilityMetadata: models.VulnerabilityMetadata{ID: "CVE-2024-9999"}},
+				Artifact:      models.Package{ID: "zlib"},
+			},
+		},
+	}
+
+	changes := NewChanges(diff)
+	require.False(t, changes.IsEmpty())
+
+	got := changes.Select([]pkg.Package{lodash, commonsText, musl, pyYAML, widget, previouslyMatched, untouched}, previous)
+
+	var ids []pkg.ID
+	for _, p := range got {
+		ids = append(ids, p.ID)
+	}
+	assert.Equal(t, []pkg.ID{"lodash", "commons-text", "musl-utils", "pyyaml", "widget", "openssl"}, ids)
+
+	assert.True(t, NewChanges(differ.Diff{}).IsEmpty())
+	assert.Empty(t, NewChanges(differ.Diff{}).Select([]pkg.Package{lodash}, previous))
+}
+
+func TestMerge(t *testing.T) {
+	newMatch := func(vuln, artifact string) models.Match {
+		return models.Match{
+			Vulnerability: models.Vulnerability{VulnerabilityMetadata: models.VulnerabilityMetadata{ID: vuln}},
+			Artifact:      models.Package{ID: artifact, Name: artifact},
+		}
+	}
+
+	previous := models.Document{
+		Matches: []models.Match{
+			newMatch("CVE-2024-0001", "lodash"),
+			newMatch("CVE-2024-0002", "zlib"),
+		},
+		IgnoredMatches: []models.IgnoredMatch{
+			{Match: newMatch("CVE-2024-0003", "lodash")},
+			{Match: newMatch("CVE-2024-0004", "zlib")},
+		},
+	}
+
+	updated := models.Document{
+		Matches: []models.Match{
+			newMatch("CVE-2024-0005", "lodash"),
+		},
+	}
+	updated.Descriptor.Name = "grype"
+
+	got := Merge(previous, updated, []pkg.Package{{ID: "lodash"}}, models.SortByPackage)
+
+	var matches []string
+	for _, m := range got.Matches {
+		matches = append(matches, m.Vulnerability.ID+"/"+m.Artifact.ID)
+	}
+	assert.ElementsMatch(t, []string{"CVE-2024-0005/lodash", "CVE-2024-0002/zlib"}, matches)
+
+	require.Len(t, got.IgnoredMatches, 1)
+	assert.Equal(t, "CVE-2024-0004", got.IgnoredMatches[0].Vulnerability.ID)
+
+	assert.Equal(t, "grype", got.Descriptor.Name)
+}
@@ -0,0 +1,321 @@
+package csaf
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"slices"
+
+	openvex "github.com/openvex/go-vex/pkg/vex"
+)
+
+// the CSAF 2.0 product status categories (see https://docs.oasis-open.org/csaf/csaf/v2.0/os/csaf-v2.0-os.html#3239-vulnerabilities-property---product-status)
+const (
+	productStatusFirstAffected      = "first_affected"
+	productStatusFirstFixed         = "first_fixed"
+	productStatusFixed              = "fixed"
+	productStatusKnownAffected      = "known_affected"
+	productStatusKnownNotAffected   = "known_not_affected"
+	productStatusLastAffected       = "last_affected"
+	productStatusUnderInvestigation = "under_investigation"
+)
+
+// advisory is the subset of a CSAF 2.0 document needed to derive VEX statements
+type advisory struct {
+	Document        documentMeta            `json:"document"`
+	ProductTree     productTree             `json:"product_tree"`
+	Vulnerabilities []advisoryVulnerability `json:"vulnerabilities"`
+}
+
+type documentMeta struct {
+	Category    string   `json:"category"`
+	CSAFVersion string   `json:"csaf_version"`
+	Title       string   `json:"title"`
+	Tracking    tracking `json:"tracking"`
+}
+
+type tracking struct {
+	ID string `json:"id"`
+}
+
+type productTree struct {
+	Branches          []branch          `json:"branches"`
+	FullProductNames  []fullProductName `json:"full_product_names"`
+	Relationships     []relationship    `json:"relationships"`
+	ProductGroups     []productGroup    `json:"product_groups"`
+	productIdentities map[string]productIdentity
+}
+
+type branch struct {
+	Category string           `json:"category"`
+	Name     string           `json:"name"`
+	Branches []branch         `json:"branches"`
+	Product  *fullProductName `json:"product"`
+}
+
+type fullProductName struct {
+	Name                        string                      `json:"name"`
+	ProductID                   string                      `json:"product_id"`
+	ProductIdentificationHelper productIdentificationHelper `json:"product_identification_helper"`
+}
+
+type productIdentificationHelper struct {
+	CPE  string `json:"cpe"`
+	PURL string `json:"purl"`
+}
+
+type relationship struct {
+	Category                  string          `json:"category"`
+	FullProductName           fullProductName `json:"full_product_name"`
+	ProductReference          string          `json:"product_reference"`
+	RelatesToProductReference string          `json:"relates_to_product_reference"`
+}
+
+type productGroup struct {
+	GroupID    string   `json:"group_id"`
+	ProductIDs []string `json:"product_ids"`
+}
+
+type advisoryVulnerability struct {
+	CVE           string              `json:"cve"`
+	IDs           []vulnerabilityID   `json:"ids"`
+	ProductStatus map[string][]string `json:"product_status"`
+	Flags         []flag              `json:"flags"`
+	Threats       []threat            `json:"threats"`
+}
+
+type vulnerabilityID struct {
+	SystemName string `json:"system_name"`
+	Text       string `json:"text"`
+}
+
+type flag struct {
+	Label      string   `json:"label"`
+	GroupIDs   []string `json:"group_ids"`
+	ProductIDs []string `json:"product_ids"`
+}
+
+type threat struct {
+	Category   string   `json:"category"`
+	Details    string   `json:"details"`
+	GroupIDs   []string `json:"group_ids"`
+	ProductIDs []string `json:"product_ids"`
+}
+
+// productIdentity describes how a CSAF product_id can be recognized in scan results. Products that
+// were created through a relationship (e.g. "openssl is a default component of RHEL 9") keep the
+// identifiers of the component separately from the identifiers of the product they belong to.
+type productIdentity struct {
+	ID string
+
+	// Identifiers are the PURLs and CPEs of the product (or of the component, for relationships)
+	Identifiers []string
+
+	// Platform are the PURLs and CPEs of the product the component relates to (only for relationships)
+	Platform []string
+}
+
+// Statement is a single product status assertion found within a CSAF advisory.
+type Statement struct {
+	Advisory        string
+	Vulnerability   string
+	Aliases         []string
+	ProductID       string
+	Status          openvex.Status
+	Justification   openvex.Justification
+	ImpactStatement string
+}
+
+// readAdvisory reads and indexes a single CSAF document from disk
+func readAdvisory(path string) (*advisory, error) {
+	by, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading csaf document %q: %w", path, err)
+	}
+
+	var adv advisory
+	if err := json.Unmarshal(by, &adv); err != nil {
+		return nil, fmt.Errorf("decoding csaf document %q: %w", path, err)
+	}
+
+	if adv.Document.CSAFVersion == "" {
+		return nil, fmt.Errorf("document %q is not a csaf document (missing csaf_version)", path)
+	}
+
+	adv.ProductTree.index()
+
+	return &adv, nil
+}
+
+// index builds a lookup of product_id to the identifiers that describe the product
+func (t *productTree) index() {
+	t.productIdentities = make(map[string]productIdentity)
+
+	add := func(p fullProductName) {
+		if p.ProductID == "" {
+			return
+		}
+		t.productIdentities[p.ProductID] = productIdentity{
+			ID:          p.ProductID,
+			Identifiers: p.ProductIdentificationHelper.identifiers(),
+		}
+	}
+
+	var walk func(branches []branch)
+	walk = func(branches []branch) {
+		for _, b := range branches {
+			if b.Product != nil {
+				add(*b.Product)
+			}
+			walk(b.Branches)
+		}
+	}
+
+	walk(t.Branches)
+
+	for _, p := range t.FullProductNames {
+		add(p)
+	}
+
+	// relationships must be resolved after all base products are known
+	for _, r := range t.Relationships {
+		id := r.FullProductName.ProductID
+		if id == "" {
+			continue
+		}
+
+		component := t.productIdentities[r.ProductReference]
+		platform := t.productIdentities[r.RelatesToProductReference]
+
+		identifiers := r.FullProductName.ProductIdentificationHelper.identifiers()
+		identifiers = append(identifiers, component.Identifiers...)
+
+		t.productIdentities[id] = productIdentity{
+			ID:          id,
+			Identifiers: identifiers,
+			Platform:    platform.Identifiers,
+		}
+	}
+}
+
+func (h productIdentificationHelper) identifiers() []string {
+	var ids []string
+	if h.PURL != "" {
+		ids = append(ids, h.PURL)
+	}
+	if h.CPE != "" {
+		ids = append(ids, h.CPE)
+	}
+	return ids
+}
+
+// productIDsForGroups expands product group references to the product IDs they contain
+func (t productTree) productIDsForGroups(groupIDs []string) []string {
+	var ids []string
+	for _, g := range t.ProductGroups {
+		for _, gid := range groupIDs {
+			if g.GroupID == gid {
+				ids = append(ids, g.ProductIDs...)
+			}
+		}
+	}
+	return ids
+}
+
+// statements flattens the product status of every vulnerability in the advisory into individual statements
+func (a *advisory) statements() []Statement {
+	var out []Statement
+	for _, v := range a.Vulnerabilities {
+		justifications := a.justifications(v)
+		impacts := a.impactStatements(v)
+
+		var aliases []string
+		for _, id := range v.IDs {
+			if id.Text != "" {
+				aliases = append(aliases, id.Text)
+			}
+		}
+
+		name := v.CVE
+		if name == "" && len(aliases) > 0 {
+			name = aliases[0]
+			aliases = aliases[1:]
+		}
+		if name == "" {
+			continue
+		}
+
+		for _, category := range sortedStatusCategories {
+			status := statusFromCategory(category)
+			for _, productID := range v.ProductStatus[category] {
+				s := Statement{
+					Advisory:        a.Document.Tracking.ID,
+					Vulnerability:   name,
+					Aliases:         aliases,
+					ProductID:       productID,
+					Status:          status,
+					ImpactStatement: impacts[productID],
+				}
+				if status == openvex.StatusNotAffected {
+					s.Justification = justifications[productID]
+				}
+				out = append(out, s)
+			}
+		}
+	}
+	return out
+}
+
+// justifications maps product IDs to the not_affected justification flag that applies to them. CSAF
+// flag labels are the same values as OpenVEX justifications.
+func (a *advisory) justifications(v advisoryVulnerability) map[string]openvex.Justification {
+	out := make(map[string]openvex.Justification)
+	for _, f := range v.Flags {
+		ids := slices.Concat(f.ProductIDs, a.ProductTree.productIDsForGroups(f.GroupIDs))
+		for _, id := range ids {
+			out[id] = openvex.Justification(f.Label)
+		}
+	}
+	return out
+}
+
+// impactStatements maps product IDs to the details of the "impact" threats that apply to them
+func (a *advisory) impactStatements(v advisoryVulnerability) map[string]string {
+	out := make(map[string]string)
+	for _, t := range v.Threats {
+		if t.Category != "impact" {
+			continue
+		}
+		ids := slices.Concat(t.ProductIDs, a.ProductTree.productIDsForGroups(t.GroupIDs))
+		for _, id := range ids {
+			out[id] = t.Details
+		}
+	}
+	return out
+}
+
+// sortedStatusCategories is the order in which product status categories are considered, which determines
+// which statement wins when the same product is listed more than once
+var sortedStatusCategories = []string{
+	productStatusFixed,
+	productStatusFirstFixed,
+	productStatusKnownNotAffected,
+	productStatusKnownAffected,
+	productStatusFirstAffected,
+	productStatusLastAffected,
+	productStatusUnderInvestigation,
+}
+
+func statusFromCategory(category string) openvex.Status {
+	switch category {
+	case productStatusFixed, productStatusFirstFixed:
+		return openvex.StatusFixed
+	case productStatusKnownNotAffected:
+		return openvex.StatusNotAffected
+	case productStatusKnownAffected, productStatusFirstAffected, productStatusLastAffected:
+		return openvex.StatusAffected
+	case productStatusUnderInvestigation:
+		return openvex.StatusUnderInvestigation
+	}
+	return ""
+}
@@ -0,0 +1,241 @@
+package csaf
+
+import (
+	"errors"
+	"slices"
+	"strings"
+
+	gopenvex "github.com/openvex/go-vex/pkg/vex"
+
+	"github.com/anchore/grype/grype/match"
+	"github.com/anchore/grype/grype/pkg"
+	"github.com/anchore/grype/grype/vex/internal"
+	"github.com/anchore/grype/grype/vex/openvex"
+	"github.com/anchore/syft/syft/cpe"
+	"github.com/anchore/syft/syft/source"
+)
+
+type Processor struct{}
+
+func New() *Processor {
+	return &Processor{}
+}
+
+// Match captures the criteria that caused a vulnerability to match
+type Match struct {
+	Statement Statement
+}
+
+// SearchedBy captures the parameters used to search through the VEX data
+type SearchedBy struct {
+	Vulnerability string
+	Product       string
+	Identifiers   []string
+}
+
+// documents holds the statements from all loaded CSAF advisories. Product IDs are only unique within
+// a single advisory, so each statement keeps the identity of its product alongside it.
+type documents struct {
+	statements []productStatement
+}
+
+type productStatement struct {
+	Statement
+	product productIdentity
+}
+
+// ReadVexDocuments reads all CSAF advisories and flattens them into product statements
+func (p *Processor) ReadVexDocuments(docs []string) (interface{}, error) {
+	out := &documents{}
+	for _, path := range docs {
+		adv, err := readAdvisory(path)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, s := range adv.statements() {
+			product, ok := adv.ProductTree.productIdentities[s.ProductID]
+			if !ok {
+				// a product status entry that is not described in the product tree can never be matched
+				continue
+			}
+			out.statements = append(out.statements, productStatement{
+				Statement: s,
+				product:   product,
+			})
+		}
+	}
+	return out, nil
+}
+
+// productIdentifiersFromContext reads the package context and returns software
+// identifiers identifying the scanned image (the same as for OpenVEX). Unlike OpenVEX,
+// CSAF products are commonly packages themselves, so a non-image source is not an error.
+func productIdentifiersFromContext(pkgContext *pkg.Context) []string {
+	if pkgContext == nil || pkgContext.Source == nil {
+		return nil
+	}
+
+	v, ok := pkgContext.Source.Metadata.(source.ImageMetadata)
+	if !ok {
+		return nil
+	}
+
+	return openvex.ProductIdentifiersFromImage(pkgContext.Source.Name, v)
+}
+
+// packageIdentifiersFromMatch returns the PURL and CPEs of the package where grype did the match.
+func packageIdentifiersFromMatch(m *match.Match) []string {
+	var ret []string
+	if m.Package.PURL != "" {
+		ret = append(ret, m.Package.PURL)
+	}
+	for _, c := range m.Package.CPEs {
+		ret = append(ret, c.Attributes.BindToFmtString())
+	}
+	return ret
+}
+
+// findStatement returns the first statement about the given vulnerability that applies to the package
+// in the match or to the scanned product as a whole.
+func (d *documents) findStatement(m *match.Match, products []string) (*productStatement, *SearchedBy) {
+	identifiers := packageIdentifiersFromMatch(m)
+	for i := range d.statements {
+		s := &d.statements[i]
+		if !s.MatchesVulnerability(m.Vulnerability.ID) {
+			continue
+		}
+
+		if id := firstMatchingIdentifier(s.product.Identifiers, identifiers); id != "" {
+			return s, &SearchedBy{
+				Vulnerability: m.Vulnerability.ID,
+				Product:       s.ProductID,
+				Identifiers:   identifiers,
+			}
+		}
+
+		// statements about a component of another product are only matched on the component; a product
+		// without relationships may describe the scanned image itself, in which case it applies to all packages
+		if len(s.product.Platform) > 0 {
+			continue
+		}
+
+		if id := firstMatchingIdentifier(s.product.Identifiers, products); id != "" {
+			return s, &SearchedBy{
+				Vulnerability: m.Vulnerability.ID,
+				Product:       id,
+			}
+		}
+	}
+	return nil, nil
+}
+
+func (s productStatement) VexStatus() gopenvex.Status {
+	return s.Status
+}
+
+func (s productStatement) VexJustification() gopenvex.Justification {
+	return s.Justification
+}
+
+func (s productStatement) MatchesVulnerability(id string) bool {
+	if s.Vulnerability == id {
+		return true
+	}
+	return slices.Contains(s.Aliases, id)
+}
+
+// firstMatchingIdentifier returns the first identifier from the CSAF product that describes any of the
+// given identifiers. PURLs match from more generic (CSAF) to more specific (scan result) and CPEs
+// match on part, vendor, product and (when specified) version.
+func firstMatchingIdentifier(productIdentifiers []string, identifiers []string) string {
+	for _, pid := range productIdentifiers {
+		for _, id := range identifiers {
+			switch {
+			case pid == id:
+				return pid
+			case strings.HasPrefix(pid, "pkg:") && strings.HasPrefix(id, "pkg:"):
+				if gopenvex.PurlMatches(pid, id) {
+					return pid
+				}
+			case strings.HasPrefix(pid, "cpe:") && strings.HasPrefix(id, "cpe:"):
+				if cpeMatches(pid, id) {
+					return pid
+				}
+			}
+		}
+	}
+	return ""
+}
+
+func cpeMatches(productCPE, packageCPE string) bool {
+	a, err := cpe.NewAttributes(productCPE)
+	if err != nil {
+		return false
+	}
+	b, err := cpe.NewAttributes(packageCPE)
+	if err != nil {
+		return false
+	}
+
+	if a.Vendor == cpe.Any || a.Product == cpe.Any {
+		return false
+	}
+
+	fieldMatches := func(x, y string) bool {
+		return x == cpe.Any || x == y
+	}
+
+	return fieldMatches(a.Part, b.Part) &&
+		a.Vendor == b.Vendor &&
+		a.Product == b.Product &&
+		fieldMatches(a.Version, b.Version) &&
+		fieldMatches(a.Update, b.Update)
+}
+
+// statementFinder adapts findStatement for the rule matching shared by all VEX formats
+func (d *documents) statementFinder(products []string) internal.StatementFinder {
+	return func(m *match.Match) (internal.Statement, *match.Detail) {
+		statement, searchedBy := d.findStatement(m, products)
+		if statement == nil {
+			return nil, nil
+		}
+		return statement, &match.Detail{
+			Type:       match.ExactDirectMatch,
+			SearchedBy: searchedBy,
+			Found: Match{
+				Statement: statement.Statement,
+			},
+			Matcher: match.CsafVexMatcher,
+		}
+	}
+}
+
+// FilterMatches takes a set of scanning results and moves any results marked in
+// the CSAF data as fixed or known_not_affected to the ignored list.
+func (p *Processor) FilterMatches(
+	docRaw interface{}, ignoreRules []match.IgnoreRule, pkgContext *pkg.Context, matches *match.Matches, ignoredMatches []match.IgnoredMatch,
+) (*match.Matches, []match.IgnoredMatch, error) {
+	doc, ok := docRaw.(*documents)
+	if !ok {
+		return nil, nil, errors.New("unable to cast vex document as csaf")
+	}
+
+	remainingMatches, ignoredMatches := internal.FilterMatches(ignoreRules, matches, ignoredMatches, doc.statementFinder(productIdentifiersFromContext(pkgContext)))
+	return remainingMatches, ignoredMatches, nil
+}
+
+// AugmentMatches adds results to the match.Matches array when matching data
+// about a known_affected or under_investigation CSAF product is found on loaded
+// advisories. Matches are moved from the ignore list.
+func (p *Processor) AugmentMatches(
+	docRaw interface{}, ignoreRules []match.IgnoreRule, pkgContext *pkg.Context, remainingMatches *match.Matches, ignoredMatches []match.IgnoredMatch,
+) (*match.Matches, []match.IgnoredMatch, error) {
+	doc, ok := docRaw.(*documents)
+	if !ok {
+		return nil, nil, errors.New("unable to cast vex document as csaf")
+	}
+
+	remainingMatches, additionalIgnoredMatches := internal.AugmentMatches(ignoreRules, remainingMatches, ignoredMatches, doc.statementFinder(productIdentifiersFromContext(pkgContext)))
+	return remainingMatches, additionalIgnoredMatches, nil
+}
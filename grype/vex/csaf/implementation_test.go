@@ -0,0 +1,178 @@
+package csaf
+
+import (
+	"testing"
+
+	openvex "github.com/openvex/go-vex/pkg/vex"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/anchore/grype/grype/match"
+	"github.com/anchore/grype/grype/pkg"
+	"github.com/anchore/grype/grype/vulnerability"
+	"github.com/anchore/syft/syft/cpe"
+	"github.com/anchore/syft/syft/source"
+)
+
+func TestReadVexDocuments(t *testing.T) {
+	raw, err := New().ReadVexDocuments([]string{"../testdata/vex-docs/csaf-demo1.json"})
+	require.NoError(t, err)
+
+	doc, ok := raw.(*documents)
+	require.True(t, ok)
+
+	var got []Statement
+	for _, s := range doc.statements {
+		got = append(got, s.Statement)
+	}
+
+	expected := []Statement{
+		{
+			Advisory:      "DEMO-2023-0001",
+			Vulnerability: "CVE-2023-1255",
+			ProductID:     "alpine-image:libcrypto3-3.0.8-r3",
+			Status:        openvex.StatusFixed,
+		},
+		{
+			Advisory:      "DEMO-2023-0001",
+			Vulnerability: "CVE-2023-2975",
+			ProductID:     "alpine-image:libcrypto3-3.0.8-r3",
+			Status:        openvex.StatusAffected,
+		},
+		{
+			Advisory:        "DEMO-2023-0001",
+			Vulnerability:   "CVE-2023-3817",
+			ProductID:       "alpine-image:libcrypto3-3.0.8-r3",
+			Status:          openvex.StatusNotAffected,
+			Justification:   openvex.VulnerableCodeNotPresent,
+			ImpactStatement: "affected functions were removed before packaging",
+		},
+	}
+	assert.Equal(t, expected, got)
+
+	product := doc.statements[0].product
+	assert.Equal(t, []string{"pkg:apk/alpine/libcrypto3@3.0.8-r3"}, product.Identifiers)
+	assert.Equal(t, []string{"pkg:oci/alpine@sha256%3A124c7d2707904eea7431fffe91522a01e5a861a624ee31d03372cc1d138a3126"}, product.Platform)
+}
+
+func TestFirstMatchingIdentifier(t *testing.T) {
+	tests := []struct {
+		name        string
+		product     []string
+		identifiers []string
+		want        string
+	}{
+		{
+			name:        "exact",
+			product:     []string{"alpine:3.17"},
+			identifiers: []string{"alpine:3.17"},
+			want:        "alpine:3.17",
+		},
+		{
+			name:        "purl without qualifiers matches more specific purl",
+			product:     []string{"pkg:apk/alpine/libcrypto3@3.0.8-r3"},
+			identifiers: []string{"pkg:apk/alpine/libcrypto3@3.0.8-r3?arch=x86_64&distro=alpine-3.17.3"},
+			want:        "pkg:apk/alpine/libcrypto3@3.0.8-r3",
+		},
+		{
+			name:        "purl with different version",
+			product:     []string{"pkg:apk/alpine/libcrypto3@3.0.8-r4"},
+			identifiers: []string{"pkg:apk/alpine/libcrypto3@3.0.8-r3?arch=x86_64"},
+		},
+		{
+			name:        "cpe 2.2 uri matches formatted string",
+			product:     []string{"cpe:/a:openssl:openssl:3.0.8"},
+			identifiers: []string{"cpe:2.3:a:openssl:openssl:3.0.8:*:*:*:*:*:*:*"},
+			want:        "cpe:/a:openssl:openssl:3.0.8",
+		},
+		{
+			name:        "cpe without version matches any version",
+			product:     []string{"cpe:2.3:a:openssl:openssl:*:*:*:*:*:*:*:*"},
+			identifiers: []string{"cpe:2.3:a:openssl:openssl:3.0.8:*:*:*:*:*:*:*"},
+			want:        "cpe:2.3:a:openssl:openssl:*:*:*:*:*:*:*:*",
+		},
+		{
+			name:        "cpe with different product",
+			product:     []string{"cpe:2.3:a:openssl:libcrypto:*:*:*:*:*:*:*:*"},
+			identifiers: []string{"cpe:2.3:a:openssl:openssl:3.0.8:*:*:*:*:*:*:*"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, firstMatchingIdentifier(tt.product, tt.identifiers))
+		})
+	}
+}
+
+func TestProductIdentifiersFromContext(t *testing.T) {
+	const digest = "sha256:124c7d2707904eea7431fffe91522a01e5a861a624ee31d03372cc1d138a3126"
+	ociPURL := "pkg:oci/alpine@sha256%3A124c7d2707904eea7431fffe91522a01e5a861a624ee31d03372cc1d138a3126"
+
+	ids := productIdentifiersFromContext(&pkg.Context{
+		Source: &source.Description{
+			Name: "alpine",
+			Metadata: source.ImageMetadata{
+				Tags:        []string{"alpine:3.17.3"},
+				RepoDigests: []string{"alpine@" + digest},
+			},
+		},
+	})
+	assert.Contains(t, ids, "alpine:3.17.3")
+	assert.Contains(t, ids, "alpine@"+digest)
+	assert.Contains(t, ids, ociPURL+"?repository_url=index.docker.io%2Flibrary")
+
+	// products identified by the OCI PURL of the image describe the scanned image
+	assert.Equal(t, ociPURL, firstMatchingIdentifier([]string{ociPURL}, ids))
+
+	assert.Empty(t, productIdentifiersFromContext(&pkg.Context{
+		Source: &source.Description{Metadata: source.DirectoryMetadata{Path: "."}},
+	}))
+}
+
+func TestAugmentMatches(t *testing.T) {
+	p := New()
+	raw, err := p.ReadVexDocuments([]string{"../testdata/vex-docs/csaf-demo1.json"})
+	require.NoError(t, err)
+
+	pkgContext := &pkg.Context{
+		Source: &source.Description{
+			Name:     "alpine",
+			Metadata: source.ImageMetadata{},
+		},
+	}
+
+	ignored := match.IgnoredMatch{
+		Match: match.Match{
+			Vulnerability: vulnerability.Vulnerability{
+				Reference: vulnerability.Reference{ID: "CVE-2023-2975"},
+			},
+			Package: pkg.Package{
+				ID:      "cc8f90662d91481d",
+				Name:    "libcrypto3",
+				Version: "3.0.8-r3",
+				Type:    "apk",
+				PURL:    "pkg:apk/alpine/libcrypto3@3.0.8-r3?arch=x86_64&distro=alpine-3.17.3",
+				CPEs:    []cpe.CPE{cpe.Must("cpe:2.3:a:openssl:libcrypto3:3.0.8-r3:*:*:*:*:*:*:*", cpe.GeneratedSource)},
+			},
+		},
+		AppliedIgnoreRules: []match.IgnoreRule{{FixState: "unknown"}},
+	}
+
+	remaining := match.NewMatches()
+	rules := []match.IgnoreRule{{Namespace: "vex", VexStatus: string(openvex.StatusAffected)}}
+
+	got, stillIgnored, err := p.AugmentMatches(raw, rules, pkgContext, &remaining, []match.IgnoredMatch{ignored})
+	require.NoError(t, err)
+	assert.Empty(t, stillIgnored)
+
+	sorted := got.Sorted()
+	require.Len(t, sorted, 1)
+	require.Len(t, sorted[0].Details, 1)
+
+	detail := sorted[0].Details[0]
+	assert.Equal(t, match.CsafVexMatcher, detail.Matcher)
+	found, ok := detail.Found.(Match)
+	require.True(t, ok)
+	assert.Equal(t, openvex.StatusAffected, found.Statement.Status)
+	assert.Equal(t, "DEMO-2023-0001", found.Statement.Advisory)
+}
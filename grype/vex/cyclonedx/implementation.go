@@ -0,0 +1,354 @@
+package cyclonedx
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/CycloneDX/cyclonedx-go"
+	openvex "github.com/openvex/go-vex/pkg/vex"
+
+	"github.com/anchore/grype/grype/match"
+	"github.com/anchore/grype/grype/pkg"
+	"github.com/anchore/grype/grype/vex/internal"
+	"github.com/anchore/packageurl-go"
+)
+
+type Processor struct{}
+
+func New() *Processor {
+	return &Processor{}
+}
+
+// Match captures the criteria that caused a vulnerability to match
+type Match struct {
+	Statement Statement
+}
+
+// SearchedBy captures the parameters used to search through the VEX data
+type SearchedBy struct {
+	Vulnerability string
+	Package       string
+	Ref           string
+}
+
+// Statement is the analysis of a single vulnerability found within a CycloneDX document.
+type Statement struct {
+	Vulnerability string
+	Aliases       []string
+	Affects       []string
+	State         cyclonedx.ImpactAnalysisState
+	Status        openvex.Status
+	Justification openvex.Justification
+	Detail        string
+}
+
+// documents holds the statements from all loaded CycloneDX documents. The affected
+// refs are resolved against the components of the document that declared them.
+type documents struct {
+	statements []refStatement
+}
+
+type refStatement struct {
+	Statement
+	refs []reference
+}
+
+// reference is a bom-ref from an affects entry along with the identifiers of the
+// component it points to, when that component is described in the same document.
+type reference struct {
+	ref         string
+	identifiers []string
+}
+
+// ReadVexDocuments reads all CycloneDX documents (JSON or XML) and extracts the vulnerability analysis from them
+func (p *Processor) ReadVexDocuments(docs []string) (interface{}, error) {
+	out := &documents{}
+	for _, path := range docs {
+		bom, err := readBOM(path)
+		if err != nil {
+			return nil, err
+		}
+		out.statements = append(out.statements, statementsFromBOM(bom)...)
+	}
+	return out, nil
+}
+
+func readBOM(path string) (*cyclonedx.BOM, error) {
+	by, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading cyclonedx document %q: %w", path, err)
+	}
+
+	format := cyclonedx.BOMFileFormatJSON
+	if trimmed := bytes.TrimSpace(by); len(trimmed) > 0 && trimmed[0] == '<' {
+		format = cyclonedx.BOMFileFormatXML
+	}
+
+	var bom cyclonedx.BOM
+	if err := cyclonedx.NewBOMDecoder(bytes.NewReader(by), format).Decode(&bom); err != nil {
+		return nil, fmt.Errorf("decoding cyclonedx document %q: %w", path, err)
+	}
+	return &bom, nil
+}
+
+// statementsFromBOM returns a statement for every vulnerability in the BOM that carries an analysis
+func statementsFromBOM(bom *cyclonedx.BOM) []refStatement {
+	if bom.Vulnerabilities == nil {
+		return nil
+	}
+
+	components := componentIdentifiers(bom)
+
+	var out []refStatement
+	for _, v := range *bom.Vulnerabilities {
+		if v.Analysis == nil || v.Affects == nil || v.ID == "" {
+			continue
+		}
+
+		status := statusFromState(v.Analysis.State)
+		if status == "" {
+			continue
+		}
+
+		s := refStatement{
+			Statement: Statement{
+				Vulnerability: v.ID,
+				State:         v.Analysis.State,
+				Status:        status,
+				Detail:        v.Analysis.Detail,
+			},
+		}
+
+		if status == openvex.StatusNotAffected {
+			s.Justification = justificationFromAnalysis(v.Analysis.Justification)
+		}
+
+		if v.References != nil {
+			for _, r := range *v.References {
+				if r.ID != "" {
+					s.Aliases = append(s.Aliases, r.ID)
+				}
+			}
+		}
+
+		for _, a := range *v.Affects {
+			if a.Ref == "" {
+				continue
+			}
+			ref := localRef(a.Ref)
+			s.Affects = append(s.Affects, a.Ref)
+			s.refs = append(s.refs, reference{
+				ref:         ref,
+				identifiers: components[ref],
+			})
+		}
+
+		out = append(out, s)
+	}
+	return out
+}
+
+// componentIdentifiers indexes all components in the BOM (including nested components) by bom-ref
+func componentIdentifiers(bom *cyclonedx.BOM) map[string][]string {
+	out := make(map[string][]string)
+
+	var walk func(components []cyclonedx.Component)
+	walk = func(components []cyclonedx.Component) {
+		for _, c := range components {
+			if c.BOMRef != "" {
+				var ids []string
+				if c.PackageURL != "" {
+					ids = append(ids, c.PackageURL)
+				}
+				if c.CPE != "" {
+					ids = append(ids, c.CPE)
+				}
+				out[c.BOMRef] = ids
+			}
+			if c.Components != nil {
+				walk(*c.Components)
+			}
+		}
+	}
+
+	if bom.Metadata != nil && bom.Metadata.Component != nil {
+		walk([]cyclonedx.Component{*bom.Metadata.Component})
+	}
+	if bom.Components != nil {
+		walk(*bom.Components)
+	}
+	return out
+}
+
+// localRef returns the bom-ref portion of a reference. References to components in other
+// BOMs are expressed as BOM-Links (urn:cdx:serial/version#bom-ref), for instance when a
+// standalone VEX document describes the SBOM that was scanned.
+func localRef(ref string) string {
+	if !strings.HasPrefix(ref, "urn:cdx:") {
+		return ref
+	}
+	_, fragment, found := strings.Cut(ref, "#")
+	if !found {
+		return ref
+	}
+	if unescaped, err := url.PathUnescape(fragment); err == nil {
+		return unescaped
+	}
+	return fragment
+}
+
+func statusFromState(state cyclonedx.ImpactAnalysisState) openvex.Status {
+	switch state {
+	case cyclonedx.IASNotAffected, cyclonedx.IASFalsePositive:
+		return openvex.StatusNotAffected
+	case cyclonedx.IASResolved, cyclonedx.IASResolvedWithPedigree:
+		return openvex.StatusFixed
+	case cyclonedx.IASExploitable:
+		return openvex.StatusAffected
+	case cyclonedx.IASInTriage:
+		return openvex.StatusUnderInvestigation
+	}
+	return ""
+}
+
+// justificationFromAnalysis maps CycloneDX impact analysis justifications onto the OpenVEX
+// justifications used by ignore rules (vex-justification).
+func justificationFromAnalysis(j cyclonedx.ImpactAnalysisJustification) openvex.Justification {
+	switch j {
+	case cyclonedx.IAJCodeNotPresent:
+		return openvex.VulnerableCodeNotPresent
+	case cyclonedx.IAJCodeNotReachable:
+		return openvex.VulnerableCodeNotInExecutePath
+	case cyclonedx.IAJRequiresConfiguration, cyclonedx.IAJRequiresEnvironment:
+		return openvex.VulnerableCodeCannotBeControlledByAdversary
+	case cyclonedx.IAJRequiresDependency:
+		return openvex.ComponentNotPresent
+	case cyclonedx.IAJProtectedByCompiler, cyclonedx.IAJProtectedAtRuntime, cyclonedx.IAJProtectedAtPerimeter, cyclonedx.IAJProtectedByMitigatingControl:
+		return openvex.InlineMitigationsAlreadyExist
+	}
+	return ""
+}
+
+// findStatement returns the first statement about the given vulnerability with an affects
+// ref that resolves to the package in the match.
+func (d *documents) findStatement(m *match.Match) (*refStatement, *SearchedBy) {
+	for i := range d.statements {
+		s := &d.statements[i]
+		if !s.MatchesVulnerability(m.Vulnerability.ID) {
+			continue
+		}
+		for _, r := range s.refs {
+			if r.resolvesTo(m.Package) {
+				return s, &SearchedBy{
+					Vulnerability: m.Vulnerability.ID,
+					Package:       string(m.Package.ID),
+					Ref:           r.ref,
+				}
+			}
+		}
+	}
+	return nil, nil
+}
+
+func (s refStatement) VexStatus() openvex.Status {
+	return s.Status
+}
+
+func (s refStatement) VexJustification() openvex.Justification {
+	return s.Justification
+}
+
+func (s refStatement) MatchesVulnerability(id string) bool {
+	if s.Vulnerability == id {
+		return true
+	}
+	return slices.Contains(s.Aliases, id)
+}
+
+// resolvesTo determines if the bom-ref points at the given package. The ref may be the
+// ID of the package (when the scanned SBOM is the CycloneDX document that defines the
+// bom-ref), a PURL carrying the package-id qualifier (as written by grype and syft), a
+// plain PURL, or the bom-ref of a component described in the VEX document itself.
+func (r reference) resolvesTo(p pkg.Package) bool {
+	if p.ID != "" && r.ref == string(p.ID) {
+		return true
+	}
+
+	if strings.HasPrefix(r.ref, "pkg:") {
+		if purl, err := packageurl.FromString(r.ref); err == nil {
+			if id, ok := purl.Qualifiers.Map()["package-id"]; ok {
+				return id == string(p.ID)
+			}
+		}
+		if p.PURL != "" && openvex.PurlMatches(r.ref, p.PURL) {
+			return true
+		}
+	}
+
+	for _, id := range r.identifiers {
+		switch {
+		case strings.HasPrefix(id, "pkg:"):
+			if p.PURL != "" && openvex.PurlMatches(id, p.PURL) {
+				return true
+			}
+		default:
+			for _, c := range p.CPEs {
+				if c.Attributes.BindToFmtString() == id {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
+
+// statementFinder adapts findStatement for the rule matching shared by all VEX formats
+func (d *documents) statementFinder() internal.StatementFinder {
+	return func(m *match.Match) (internal.Statement, *match.Detail) {
+		statement, searchedBy := d.findStatement(m)
+		if statement == nil {
+			return nil, nil
+		}
+		return statement, &match.Detail{
+			Type:       match.ExactDirectMatch,
+			SearchedBy: searchedBy,
+			Found: Match{
+				Statement: statement.Statement,
+			},
+			Matcher: match.CycloneDXVexMatcher,
+		}
+	}
+}
+
+// FilterMatches takes a set of scanning results and moves any results marked in
+// the CycloneDX analysis as not_affected or resolved to the ignored list.
+func (p *Processor) FilterMatches(
+	docRaw interface{}, ignoreRules []match.IgnoreRule, _ *pkg.Context, matches *match.Matches, ignoredMatches []match.IgnoredMatch,
+) (*match.Matches, []match.IgnoredMatch, error) {
+	doc, ok := docRaw.(*documents)
+	if !ok {
+		return nil, nil, errors.New("unable to cast vex document as cyclonedx")
+	}
+
+	remainingMatches, ignoredMatches := internal.FilterMatches(ignoreRules, matches, ignoredMatches, doc.statementFinder())
+	return remainingMatches, ignoredMatches, nil
+}
+
+// AugmentMatches adds results to the match.Matches array when an exploitable or
+// in_triage analysis is found for an ignored match. Matches are moved from the ignore list.
+func (p *Processor) AugmentMatches(
+	docRaw interface{}, ignoreRules []match.IgnoreRule, _ *pkg.Context, remainingMatches *match.Matches, ignoredMatches []match.IgnoredMatch,
+) (*match.Matches, []match.IgnoredMatch, error) {
+	doc, ok := docRaw.(*documents)
+	if !ok {
+		return nil, nil, errors.New("unable to cast vex document as cyclonedx")
+	}
+
+	remainingMatches, additionalIgnoredMatches := internal.AugmentMatches(ignoreRules, remainingMatches, ignoredMatches, doc.statementFinder())
+	return remainingMatches, additionalIgnoredMatches, nil
+}
@@ -0,0 +1,122 @@
+package cyclonedx
+
+import (
+	"testing"
+
+	"github.com/CycloneDX/cyclonedx-go"
+	openvex "github.com/openvex/go-vex/pkg/vex"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/anchore/grype/grype/match"
+	"github.com/anchore/grype/grype/pkg"
+	"github.com/anchore/grype/grype/vulnerability"
+)
+
+func TestReadVexDocuments(t *testing.T) {
+	raw, err := New().ReadVexDocuments([]string{"../testdata/vex-docs/cyclonedx-demo1.json"})
+	require.NoError(t, err)
+
+	doc, ok := raw.(*documents)
+	require.True(t, ok)
+	require.Len(t, doc.statements, 3)
+
+	notAffected := doc.statements[2]
+	assert.Equal(t, "CVE-2023-3817", notAffected.Vulnerability)
+	assert.Equal(t, cyclonedx.IASNotAffected, notAffected.State)
+	assert.Equal(t, openvex.StatusNotAffected, notAffected.Status)
+	assert.Equal(t, openvex.VulnerableCodeNotPresent, notAffected.Justification)
+	assert.Equal(t, []reference{{ref: "libcrypto3-component", identifiers: []string{"pkg:apk/alpine/libcrypto3@3.0.8-r3"}}}, notAffected.refs)
+
+	inTriage := doc.statements[1]
+	assert.Equal(t, openvex.StatusUnderInvestigation, inTriage.Status)
+	assert.Equal(t, []reference{{ref: "cc8f90662d91481d"}}, inTriage.refs)
+}
+
+func TestReferenceResolvesTo(t *testing.T) {
+	p := pkg.Package{
+		ID:   "cc8f90662d91481d",
+		PURL: "pkg:apk/alpine/libcrypto3@3.0.8-r3?arch=x86_64&distro=alpine-3.17.3",
+	}
+
+	tests := []struct {
+		name string
+		ref  reference
+		want bool
+	}{
+		{
+			name: "package ID",
+			ref:  reference{ref: "cc8f90662d91481d"},
+			want: true,
+		},
+		{
+			name: "purl with matching package-id qualifier",
+			ref:  reference{ref: "pkg:apk/alpine/libcrypto3@3.0.8-r3?package-id=cc8f90662d91481d"},
+			want: true,
+		},
+		{
+			name: "purl with another package-id qualifier",
+			ref:  reference{ref: "pkg:apk/alpine/libcrypto3@3.0.8-r3?package-id=0000000000000000"},
+		},
+		{
+			name: "plain purl",
+			ref:  reference{ref: "pkg:apk/alpine/libcrypto3@3.0.8-r3"},
+			want: true,
+		},
+		{
+			name: "component defined in the document",
+			ref:  reference{ref: "some-component", identifiers: []string{"pkg:apk/alpine/libcrypto3"}},
+			want: true,
+		},
+		{
+			name: "unknown ref",
+			ref:  reference{ref: "some-component"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.ref.resolvesTo(p))
+		})
+	}
+}
+
+func TestLocalRef(t *testing.T) {
+	assert.Equal(t, "pkg-1", localRef("pkg-1"))
+	assert.Equal(t, "pkg:npm/%40scope/name@1.0.0", localRef("urn:cdx:3e671687-395b-41f5-a30f-a58921a69b79/1#pkg:npm/%2540scope/name@1.0.0"))
+}
+
+func TestAugmentMatches(t *testing.T) {
+	p := New()
+	raw, err := p.ReadVexDocuments([]string{"../testdata/vex-docs/cyclonedx-demo1.json"})
+	require.NoError(t, err)
+
+	ignored := match.IgnoredMatch{
+		Match: match.Match{
+			Vulnerability: vulnerability.Vulnerability{
+				Reference: vulnerability.Reference{ID: "CVE-2023-2975"},
+			},
+			Package: pkg.Package{
+				ID:      "cc8f90662d91481d",
+				Name:    "libcrypto3",
+				Version: "3.0.8-r3",
+				Type:    "apk",
+			},
+		},
+		AppliedIgnoreRules: []match.IgnoreRule{{FixState: "unknown"}},
+	}
+
+	remaining := match.NewMatches()
+	rules := []match.IgnoreRule{{Namespace: "vex", VexStatus: string(openvex.StatusUnderInvestigation)}}
+
+	got, stillIgnored, err := p.AugmentMatches(raw, rules, nil, &remaining, []match.IgnoredMatch{ignored})
+	require.NoError(t, err)
+	assert.Empty(t, stillIgnored)
+
+	sorted := got.Sorted()
+	require.Len(t, sorted, 1)
+	require.Len(t, sorted[0].Details, 1)
+
+	detail := sorted[0].Details[0]
+	assert.Equal(t, match.CycloneDXVexMatcher, detail.Matcher)
+	assert.Equal(t, &SearchedBy{Vulnerability: "CVE-2023-2975", Package: "cc8f90662d91481d", Ref: "cc8f90662d91481d"}, detail.SearchedBy)
+}
@@ -0,0 +1,75 @@
+package vex
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+)
+
+type documentFormat string
+
+const (
+	openVexFormat   documentFormat = "openvex"
+	csafFormat      documentFormat = "csaf"
+	cycloneDXFormat documentFormat = "cyclonedx"
+)
+
+// allFormats is the order in which VEX document formats are applied to results
+var allFormats = []documentFormat{
+	openVexFormat,
+	csafFormat,
+	cycloneDXFormat,
+}
+
+// sniffDocumentFormat inspects the top-level keys of a VEX document to determine which
+// implementation should process it. Documents that cannot be identified are assumed to be
+// OpenVEX, which was historically the only supported format.
+func sniffDocumentFormat(path string) (documentFormat, error) {
+	by, err := os.ReadFile(path)
+	if err != nil {
+		return "", fmt.Errorf("reading vex document %q: %w", path, err)
+	}
+
+	if trimmed := bytes.TrimSpace(by); len(trimmed) > 0 && trimmed[0] == '<' {
+		// OpenVEX and CSAF are JSON only, so the only XML document we know of is a CycloneDX BOM
+		if bytes.Contains(trimmed, []byte("cyclonedx.org/schema/bom")) {
+			return cycloneDXFormat, nil
+		}
+		return "", fmt.Errorf("unsupported xml vex document %q", path)
+	}
+
+	var doc struct {
+		BOMFormat string `json:"bomFormat"`
+		Document  *struct {
+			CSAFVersion string `json:"csaf_version"`
+		} `json:"document"`
+	}
+
+	if err := json.Unmarshal(by, &doc); err != nil {
+		return "", fmt.Errorf("decoding vex document %q: %w", path, err)
+	}
+
+	switch {
+	case doc.Document != nil && doc.Document.CSAFVersion != "":
+		return csafFormat, nil
+	case doc.BOMFormat == "CycloneDX":
+		return cycloneDXFormat, nil
+	}
+
+	return openVexFormat, nil
+}
+
+// documentsByFormat groups the given VEX documents by their detected format, preserving
+// the order in which they were provided.
+func documentsByFormat(docs []string) (map[documentFormat][]string, error) {
+	out := make(map[documentFormat][]string)
+	for _, d := range docs {
+		f, err := sniffDocumentFormat(d)
+		if err != nil {
+			return nil, err
+		}
+		out[f] = append(out[f], d)
+	}
+	return out, nil
+}
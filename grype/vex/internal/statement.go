@@ -0,0 +1,142 @@
+package internal
+
+import (
+	"slices"
+
+	openvex "github.com/openvex/go-vex/pkg/vex"
+
+	"github.com/anchore/grype/grype/match"
+)
+
+// augmentStatuses are the VEX statuses that augment results
+var augmentStatuses = []openvex.Status{
+	openvex.StatusAffected,
+	openvex.StatusUnderInvestigation,
+}
+
+// ignoreStatuses are the VEX statuses that filter matched to the ignore list
+var ignoreStatuses = []openvex.Status{
+	openvex.StatusNotAffected,
+	openvex.StatusFixed,
+}
+
+// Statement is a statement about a vulnerability read from a VEX document of any of the supported formats
+type Statement interface {
+	// VexStatus returns the status of the statement as an OpenVEX status
+	VexStatus() openvex.Status
+
+	// VexJustification returns the justification of a not_affected statement as an OpenVEX justification
+	VexJustification() openvex.Justification
+
+	// MatchesVulnerability indicates if the statement is about the vulnerability with the given ID (or alias)
+	MatchesVulnerability(id string) bool
+}
+
+// StatementFinder returns the statement that applies to the given match (or nil when there is none), along with the
+// match detail describing the statement, which is added to the match when the statement augments results.
+type StatementFinder func(m *match.Match) (Statement, *match.Detail)
+
+// FilterMatches moves any matches with a not_affected or fixed statement to the ignored list, as long as an ignore
+// rule for the status of the statement applies to the match.
+func FilterMatches(ignoreRules []match.IgnoreRule, matches *match.Matches, ignoredMatches []match.IgnoredMatch, find StatementFinder) (*match.Matches, []match.IgnoredMatch) {
+	remainingMatches := match.NewMatches()
+
+	sorted := matches.Sorted()
+	for i := range sorted {
+		statement, _ := find(&sorted[i])
+
+		// No data about this match's component. Next.
+		if statement == nil {
+			remainingMatches.Add(sorted[i])
+			continue
+		}
+
+		rule := matchingRule(ignoreRules, sorted[i], statement, ignoreStatuses)
+		if rule == nil {
+			remainingMatches.Add(sorted[i])
+			continue
+		}
+
+		ignoredMatches = append(ignoredMatches, match.IgnoredMatch{
+			Match:              sorted[i],
+			AppliedIgnoreRules: []match.IgnoreRule{*rule},
+		})
+	}
+	return &remainingMatches, ignoredMatches
+}
+
+// AugmentMatches moves any ignored matches with an affected or under_investigation statement back to the results
+// (along with the detail describing the statement), as long as an ignore rule for the status of the statement
+// applies to the match.
+func AugmentMatches(ignoreRules []match.IgnoreRule, remainingMatches *match.Matches, ignoredMatches []match.IgnoredMatch, find StatementFinder) (*match.Matches, []match.IgnoredMatch) {
+	additionalIgnoredMatches := []match.IgnoredMatch{}
+
+	for i := range ignoredMatches {
+		statement, detail := find(&ignoredMatches[i].Match)
+
+		// No data about this match's component. Next.
+		if statement == nil {
+			additionalIgnoredMatches = append(additionalIgnoredMatches, ignoredMatches[i])
+			continue
+		}
+
+		// Only match if rules to augment are configured
+		rule := matchingRule(ignoreRules, ignoredMatches[i].Match, statement, augmentStatuses)
+		if rule == nil {
+			additionalIgnoredMatches = append(additionalIgnoredMatches, ignoredMatches[i])
+			continue
+		}
+
+		newMatch := ignoredMatches[i].Match
+		newMatch.Details = append(newMatch.Details, *detail)
+
+		remainingMatches.Add(newMatch)
+	}
+
+	return remainingMatches, additionalIgnoredMatches
+}
+
+// matchingRule cycles through a set of ignore rules and returns the first
+// one that matches the statement and the match. Returns nil if none match.
+func matchingRule(ignoreRules []match.IgnoreRule, m match.Match, statement Statement, allowedStatuses []openvex.Status) *match.IgnoreRule {
+	ms := match.NewMatches()
+	ms.Add(m)
+
+	for _, rule := range ignoreRules {
+		// If the rule has more conditions than just the VEX statement, check if
+		// it applies to the current match.
+		if rule.HasConditions() {
+			r := rule
+			r.VexStatus = ""
+			if _, ignored := match.ApplyIgnoreRules(ms, []match.IgnoreRule{r}); len(ignored) == 0 {
+				continue
+			}
+		}
+
+		// If the status in the statement is not the same in the rule
+		// and the vex statement, it does not apply
+		if string(statement.VexStatus()) != rule.VexStatus {
+			continue
+		}
+
+		// If the rule has a status other than the allowed ones, skip:
+		if !slices.Contains(allowedStatuses, openvex.Status(rule.VexStatus)) {
+			continue
+		}
+
+		// If the rule applies to a VEX justification it needs to match the
+		// statement, note that justifications only apply to not_affected:
+		if statement.VexStatus() == openvex.StatusNotAffected && rule.VexJustification != "" &&
+			rule.VexJustification != string(statement.VexJustification()) {
+			continue
+		}
+
+		// If the vulnerability is blank in the rule it means we will honor
+		// any status with any vulnerability, otherwise the rule applies if it
+		// is the same in the statement and the rule.
+		if rule.Vulnerability == "" || statement.MatchesVulnerability(rule.Vulnerability) {
+			return &rule
+		}
+	}
+	return nil
+}
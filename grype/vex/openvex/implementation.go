@@ -11,6 +11,7 @@ import (
 
 	"github.com/anchore/grype/grype/match"
 	"github.com/anchore/grype/grype/pkg"
+	"github.com/anchore/grype/grype/vex/internal"
 	"github.com/anchore/packageurl-go"
 	"github.com/anchore/syft/syft/source"
 )
@@ -33,18 +34,6 @@ type SearchedBy struct {
 	Subcomponents []string
 }
 
-// augmentStatuses are the VEX statuses that augment results
-var augmentStatuses = []openvex.Status{
-	openvex.StatusAffected,
-	openvex.StatusUnderInvestigation,
-}
-
-// filterStatuses are the VEX statuses that filter matched to the ignore list
-var ignoreStatuses = []openvex.Status{
-	openvex.StatusNotAffected,
-	openvex.StatusFixed,
-}
-
 // ReadVexDocuments reads and merges VEX documents
 func (ovm *Processor) ReadVexDocuments(docs []string) (interface{}, error) {
 	// Combine all VEX documents into a single VEX document
@@ -61,16 +50,21 @@ func (ovm *Processor) ReadVexDocuments(docs []string) (interface{}, error) {
 func productIdentifiersFromContext(pkgContext *pkg.Context) ([]string, error) {
 	switch v := pkgContext.Source.Metadata.(type) {
 	case source.ImageMetadata:
-		tagIdentifiers := identifiersFromTags(v.Tags, pkgContext.Source.Name)
-		digestIdentifiers := identifiersFromDigests(v.RepoDigests)
-		identifiers := slices.Concat(tagIdentifiers, digestIdentifiers)
-		return identifiers, nil
+		return ProductIdentifiersFromImage(pkgContext.Source.Name, v), nil
 	default:
 		// Fail for now
 		return nil, errors.New("source type not supported for VEX")
 	}
 }
 
+// ProductIdentifiersFromImage returns the software identifiers (tags, digests and their
+// equivalent OCI pURLs) that identify a scanned image as a VEX product.
+func ProductIdentifiersFromImage(name string, metadata source.ImageMetadata) []string {
+	tagIdentifiers := identifiersFromTags(metadata.Tags, name)
+	digestIdentifiers := identifiersFromDigests(metadata.RepoDigests)
+	return slices.Concat(tagIdentifiers, digestIdentifiers)
+}
+
 func identifiersFromTags(tags []string, name string) []string {
 	identifiers := []string{}
 
@@ -152,6 +146,50 @@ func subcomponentIdentifiersFromMatch(m *match.Match) []string {
 	return ret
 }
 
+// statement adapts an OpenVEX statement for the rule matching shared by all VEX formats
+type statement struct {
+	openvex.Statement
+}
+
+func (s statement) VexStatus() openvex.Status {
+	return s.Status
+}
+
+func (s statement) VexJustification() openvex.Justification {
+	return s.Justification
+}
+
+func (s statement) MatchesVulnerability(id string) bool {
+	return s.Vulnerability.Matches(id)
+}
+
+// statementFinder returns the first statement in the VEX data about the vulnerability of a match for any of the
+// product's different names and the subcomponents of the match.
+func statementFinder(doc *openvex.VEX, products []string) internal.StatementFinder {
+	return func(m *match.Match) (internal.Statement, *match.Detail) {
+		subcmp := subcomponentIdentifiersFromMatch(m)
+
+		// Range through the product's different names
+		for _, product := range products {
+			if matchingStatements := doc.Matches(m.Vulnerability.ID, product, subcmp); len(matchingStatements) != 0 {
+				return statement{Statement: matchingStatements[0]}, &match.Detail{
+					Type: match.ExactDirectMatch,
+					SearchedBy: &SearchedBy{
+						Vulnerability: m.Vulnerability.ID,
+						Product:       product,
+						Subcomponents: subcmp,
+					},
+					Found: Match{
+						Statement: matchingStatements[0],
+					},
+					Matcher: match.OpenVexMatcher,
+				}
+			}
+		}
+		return nil, nil
+	}
+}
+
 // FilterMatches takes a set of scanning results and moves any results marked in
 // the VEX data as fixed or not_affected to the ignored list.
 func (ovm *Processor) FilterMatches(
@@ -162,8 +200,6 @@ func (ovm *Processor) FilterMatches(
 		return nil, nil, errors.New("unable to cast vex document as openvex")
 	}
 
-	remainingMatches := match.NewMatches()
-
 	products, err := productIdentifiersFromContext(pkgContext)
 	if err != nil {
 		return nil, nil, fmt.Errorf("reading product identifiers from context: %w", err)
@@ -172,101 +208,8 @@ func (ovm *Processor) FilterMatches(
 	// TODO(alex): should we apply the vex ignore rules to the already ignored matches?
 	// that way the end user sees all of the reasons a match was ignored in case multiple apply
 
-	// Now, let's go through grype's matches
-	sorted := matches.Sorted()
-	for i := range sorted {
-		var statement *openvex.Statement
-		subcmp := subcomponentIdentifiersFromMatch(&sorted[i])
-
-		// Range through the product's different names
-		for _, product := range products {
-			if matchingStatements := doc.Matches(sorted[i].Vulnerability.ID, product, subcmp); len(matchingStatements) != 0 {
-				statement = &matchingStatements[0]
-				break
-			}
-		}
-
-		// No data about this match's component. Next.
-		if statement == nil {
-			remainingMatches.Add(sorted[i])
-			continue
-		}
-
-		rule := matchingRule(ignoreRules, sorted[i], statement, ignoreStatuses)
-		if rule == nil {
-			remainingMatches.Add(sorted[i])
-			continue
-		}
-
-		// Filtering only applies to not_affected and fixed statuses
-		if statement.Status != openvex.StatusNotAffected && statement.Status != openvex.StatusFixed {
-			remainingMatches.Add(sorted[i])
-			continue
-		}
-
-		ignoredMatches = append(ignoredMatches, match.IgnoredMatch{
-			Match:              sorted[i],
-			AppliedIgnoreRules: []match.IgnoreRule{*rule},
-		})
-	}
-	return &remainingMatches, ignoredMatches, nil
-}
-
-// matchingRule cycles through a set of ignore rules and returns the first
-// one that matches the statement and the match. Returns nil if none match.
-func matchingRule(ignoreRules []match.IgnoreRule, m match.Match, statement *openvex.Statement, allowedStatuses []openvex.Status) *match.IgnoreRule {
-	ms := match.NewMatches()
-	ms.Add(m)
-
-	revStatuses := map[string]struct{}{}
-	for _, s := range allowedStatuses {
-		revStatuses[string(s)] = struct{}{}
-	}
-
-	for _, rule := range ignoreRules {
-		// If the rule has more conditions than just the VEX statement, check if
-		// it applies to the current match.
-		if rule.HasConditions() {
-			r := rule
-			r.VexStatus = ""
-			if _, ignored := match.ApplyIgnoreRules(ms, []match.IgnoreRule{r}); len(ignored) == 0 {
-				continue
-			}
-		}
-
-		// If the status in the statement is not the same in the rule
-		// and the vex statement, it does not apply
-		if string(statement.Status) != rule.VexStatus {
-			continue
-		}
-
-		// If the rule has a statement other than the allowed ones, skip:
-		if len(revStatuses) > 0 && rule.VexStatus != "" {
-			if _, ok := revStatuses[rule.VexStatus]; !ok {
-				continue
-			}
-		}
-
-		// If the rule applies to a VEX justification it needs to match the
-		// statement, note that justifications only apply to not_affected:
-		if statement.Status == openvex.StatusNotAffected && rule.VexJustification != "" &&
-			rule.VexJustification != string(statement.Justification) {
-			continue
-		}
-
-		// If the vulnerability is blank in the rule it means we will honor
-		// any status with any vulnerability.
-		if rule.Vulnerability == "" {
-			return &rule
-		}
-
-		// If the vulnerability is set, the rule applies if it is the same
-		// in the statement and the rule.
-		if statement.Vulnerability.Matches(rule.Vulnerability) {
-			return &rule
-		}
-	}
-	return nil
+	remainingMatches, ignoredMatches := internal.FilterMatches(ignoreRules, matches, ignoredMatches, statementFinder(doc, products))
+	return remainingMatches, ignoredMatches, nil
 }
 
 // AugmentMatches adds results to the match.Matches array when matching data
@@ -280,62 +223,11 @@ func (ovm *Processor) AugmentMatches(
 		return nil, nil, errors.New("unable to cast vex document as openvex")
 	}
 
-	additionalIgnoredMatches := []match.IgnoredMatch{}
-
 	products, err := productIdentifiersFromContext(pkgContext)
 	if err != nil {
 		return nil, nil, fmt.Errorf("reading product identifiers from context: %w", err)
 	}
 
-	// Now, let's go through grype's matches
-	for i := range ignoredMatches {
-		var statement *openvex.Statement
-		var searchedBy *SearchedBy
-		subcmp := subcomponentIdentifiersFromMatch(&ignoredMatches[i].Match)
-
-		// Range through the product's different names to see if they match the
-		// statement data
-		for _, product := range products {
-			if matchingStatements := doc.Matches(ignoredMatches[i].Vulnerability.ID, product, subcmp); len(matchingStatements) != 0 {
-				if matchingStatements[0].Status != openvex.StatusAffected &&
-					matchingStatements[0].Status != openvex.StatusUnderInvestigation {
-					break
-				}
-				statement = &matchingStatements[0]
-				searchedBy = &SearchedBy{
-					Vulnerability: ignoredMatches[i].Vulnerability.ID,
-					Product:       product,
-					Subcomponents: subcmp,
-				}
-				break
-			}
-		}
-
-		// No data about this match's component. Next.
-		if statement == nil {
-			additionalIgnoredMatches = append(additionalIgnoredMatches, ignoredMatches[i])
-			continue
-		}
-
-		// Only match if rules to augment are configured
-		rule := matchingRule(ignoreRules, ignoredMatches[i].Match, statement, augmentStatuses)
-		if rule == nil {
-			additionalIgnoredMatches = append(additionalIgnoredMatches, ignoredMatches[i])
-			continue
-		}
-
-		newMatch := ignoredMatches[i].Match
-		newMatch.Details = append(newMatch.Details, match.Detail{
-			Type:       match.ExactDirectMatch,
-			SearchedBy: searchedBy,
-			Found: Match{
-				Statement: *statement,
-			},
-			Matcher: match.OpenVexMatcher,
-		})
-
-		remainingMatches.Add(newMatch)
-	}
-
+	remainingMatches, additionalIgnoredMatches := internal.AugmentMatches(ignoreRules, remainingMatches, ignoredMatches, statementFinder(doc, products))
 	return remainingMatches, additionalIgnoredMatches, nil
 }
@@ -2,11 +2,14 @@ package vex
 
 import (
 	"fmt"
+	"time"
 
 	gopenvex "github.com/openvex/go-vex/pkg/vex"
 
 	"github.com/anchore/grype/grype/match"
 	"github.com/anchore/grype/grype/pkg"
+	"github.com/anchore/grype/grype/vex/csaf"
+	"github.com/anchore/grype/grype/vex/cyclonedx"
 	"github.com/anchore/grype/grype/vex/openvex"
 )
 
@@ -21,7 +24,7 @@ const (
 
 type Processor struct {
 	Options ProcessorOptions
-	impl    vexProcessorImplementation
+	impls   map[documentFormat]vexProcessorImplementation
 }
 
 type vexProcessorImplementation interface {
@@ -41,18 +44,28 @@ type vexProcessorImplementation interface {
 	AugmentMatches(interface{}, []match.IgnoreRule, *pkg.Context, *match.Matches, []match.IgnoredMatch) (*match.Matches, []match.IgnoredMatch, error)
 }
 
-// getVexImplementation this function returns the vex processor implementation
-// at some point it can read the options and choose a user configured implementation.
-func getVexImplementation() vexProcessorImplementation {
-	return openvex.New()
+// getVexImplementation returns the vex processor implementation for the given document format.
+func getVexImplementation(f documentFormat) vexProcessorImplementation {
+	switch f {
+	case csafFormat:
+		return csaf.New()
+	case cycloneDXFormat:
+		return cyclonedx.New()
+	default:
+		return openvex.New()
+	}
 }
 
-// NewProcessor returns a new VEX processor. For now, it defaults to the only vex
-// implementation: OpenVEX
+// NewProcessor returns a new VEX processor. The format of each document is detected
+// when the VEX data is applied, so OpenVEX, CSAF and CycloneDX documents can be mixed.
 func NewProcessor(opts ProcessorOptions) *Processor {
+	impls := make(map[documentFormat]vexProcessorImplementation)
+	for _, f := range allFormats {
+		impls[f] = getVexImplementation(f)
+	}
 	return &Processor{
 		Options: opts,
-		impl:    getVexImplementation(),
+		impls:   impls,
 	}
 }
 
@@ -66,29 +79,49 @@ type ProcessorOptions struct {
 // in the files specified in the grype invocation. Any filtered results will
 // be moved to the ignored matches slice.
 func (vm *Processor) ApplyVEX(pkgContext *pkg.Context, remainingMatches *match.Matches, ignoredMatches []match.IgnoredMatch) (*match.Matches, []match.IgnoredMatch, error) {
-	var err error
-
 	// If no VEX documents are loaded, just pass through the matches, effectivle NOOP
 	if len(vm.Options.Documents) == 0 {
 		return remainingMatches, ignoredMatches, nil
 	}
 
-	// Read VEX data from all passed documents
-	rawVexData, err := vm.impl.ReadVexDocuments(vm.Options.Documents)
+	docsByFormat, err := documentsByFormat(vm.Options.Documents)
 	if err != nil {
-		return nil, nil, fmt.Errorf("parsing vex document: %w", err)
+		return nil, nil, fmt.Errorf("detecting vex document format: %w", err)
 	}
 
 	vexRules := extractVexRules(vm.Options.IgnoreRules)
 
-	remainingMatches, ignoredMatches, err = vm.impl.FilterMatches(
+	for _, f := range allFormats {
+		docs := docsByFormat[f]
+		if len(docs) == 0 {
+			continue
+		}
+
+		remainingMatches, ignoredMatches, err = vm.apply(vm.impls[f], docs, vexRules, pkgContext, remainingMatches, ignoredMatches)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
+	return remainingMatches, ignoredMatches, nil
+}
+
+// apply reads the given documents with a single VEX implementation and filters and augments the results with them.
+func (vm *Processor) apply(impl vexProcessorImplementation, docs []string, vexRules []match.IgnoreRule, pkgContext *pkg.Context, remainingMatches *match.Matches, ignoredMatches []match.IgnoredMatch) (*match.Matches, []match.IgnoredMatch, error) {
+	// Read VEX data from all passed documents
+	rawVexData, err := impl.ReadVexDocuments(docs)
+	if err != nil {
+		return nil, nil, fmt.Errorf("parsing vex document: %w", err)
+	}
+
+	remainingMatches, ignoredMatches, err = impl.FilterMatches(
 		rawVexData, vexRules, pkgContext, remainingMatches, ignoredMatches,
 	)
 	if err != nil {
 		return nil, nil, fmt.Errorf("checking matches against VEX data: %w", err)
 	}
 
-	remainingMatches, ignoredMatches, err = vm.impl.AugmentMatches(
+	remainingMatches, ignoredMatches, err = impl.AugmentMatches(
 		rawVexData, vexRules, pkgContext, remainingMatches, ignoredMatches,
 	)
 	if err != nil {
@@ -99,11 +132,12 @@ func (vm *Processor) ApplyVEX(pkgContext *pkg.Context, remainingMatches *match.M
 }
 
 // extractVexRules is a utility function that takes a set of ignore rules and
-// extracts those that act on VEX statuses.
+// extracts those that act on VEX statuses and have not expired.
 func extractVexRules(rules []match.IgnoreRule) []match.IgnoreRule {
 	newRules := []match.IgnoreRule{}
+	now := time.Now()
 	for _, r := range rules {
-		if r.VexStatus != "" {
+		if r.VexStatus != "" && !r.IsExpired(now) {
 			newRules = append(newRules, r)
 			newRules[len(newRules)-1].Namespace = "vex"
 		}
@@ -297,6 +297,197 @@ func TestProcessor_ApplyVEX(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "csaf-demo1 - ignore by fixed status",
+			options: ProcessorOptions{
+				Documents: []string{
+					"testdata/vex-docs/csaf-demo1.json",
+				},
+				IgnoreRules: []match.IgnoreRule{
+					{
+						VexStatus: "fixed",
+					},
+				},
+			},
+			args: args{
+				pkgContext: pkgContext,
+				matches:    getSubject(),
+			},
+			wantMatches: matchesRef(libCryptoCVE_2023_3817, libCryptoCVE_2023_2975),
+			wantIgnoredMatches: []match.IgnoredMatch{
+				{
+					Match: libCryptoCVE_2023_1255,
+					AppliedIgnoreRules: []match.IgnoreRule{
+						{
+							Namespace: "vex",
+							VexStatus: "fixed",
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "csaf-demo1 - ignore by not_affected status and vulnerable_code_not_present justification",
+			options: ProcessorOptions{
+				Documents: []string{
+					"testdata/vex-docs/csaf-demo1.json",
+				},
+				IgnoreRules: []match.IgnoreRule{
+					{
+						VexStatus:        "not_affected",
+						VexJustification: "vulnerable_code_not_present",
+					},
+				},
+			},
+			args: args{
+				pkgContext: pkgContext,
+				matches:    getSubject(),
+			},
+			wantMatches: matchesRef(libCryptoCVE_2023_2975, libCryptoCVE_2023_1255),
+			wantIgnoredMatches: []match.IgnoredMatch{
+				{
+					Match: libCryptoCVE_2023_3817,
+					AppliedIgnoreRules: []match.IgnoreRule{
+						{
+							Namespace:        "vex",
+							VexStatus:        "not_affected",
+							VexJustification: "vulnerable_code_not_present",
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "openvex-demo1 and csaf-demo1 mixed - ignore by fixed and not_affected statuses",
+			options: ProcessorOptions{
+				Documents: []string{
+					"testdata/vex-docs/openvex-demo1.json",
+					"testdata/vex-docs/csaf-demo1.json",
+				},
+				IgnoreRules: []match.IgnoreRule{
+					{
+						VexStatus: "fixed",
+					},
+					{
+						VexStatus: "not_affected",
+					},
+				},
+			},
+			args: args{
+				pkgContext: pkgContext,
+				matches:    getSubject(),
+			},
+			wantMatches: matchesRef(libCryptoCVE_2023_2975),
+			wantIgnoredMatches: []match.IgnoredMatch{
+				{
+					Match: libCryptoCVE_2023_1255,
+					AppliedIgnoreRules: []match.IgnoreRule{
+						{
+							Namespace: "vex",
+							VexStatus: "fixed",
+						},
+					},
+				},
+				{
+					Match: libCryptoCVE_2023_3817,
+					AppliedIgnoreRules: []match.IgnoreRule{
+						{
+							Namespace: "vex",
+							VexStatus: "not_affected",
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "cyclonedx-demo1 - ignore by fixed status (resolved analysis)",
+			options: ProcessorOptions{
+				Documents: []string{
+					"testdata/vex-docs/cyclonedx-demo1.json",
+				},
+				IgnoreRules: []match.IgnoreRule{
+					{
+						VexStatus: "fixed",
+					},
+				},
+			},
+			args: args{
+				pkgContext: pkgContext,
+				matches:    getSubject(),
+			},
+			wantMatches: matchesRef(libCryptoCVE_2023_3817, libCryptoCVE_2023_2975),
+			wantIgnoredMatches: []match.IgnoredMatch{
+				{
+					Match: libCryptoCVE_2023_1255,
+					AppliedIgnoreRules: []match.IgnoreRule{
+						{
+							Namespace: "vex",
+							VexStatus: "fixed",
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "cyclonedx-demo1 - ignore by not_affected status and vulnerable_code_not_present justification",
+			options: ProcessorOptions{
+				Documents: []string{
+					"testdata/vex-docs/cyclonedx-demo1.json",
+				},
+				IgnoreRules: []match.IgnoreRule{
+					{
+						VexStatus:        "not_affected",
+						VexJustification: "vulnerable_code_not_present",
+					},
+				},
+			},
+			args: args{
+				pkgContext: pkgContext,
+				matches:    getSubject(),
+			},
+			wantMatches: matchesRef(libCryptoCVE_2023_2975, libCryptoCVE_2023_1255),
+			wantIgnoredMatches: []match.IgnoredMatch{
+				{
+					Match: libCryptoCVE_2023_3817,
+					AppliedIgnoreRules: []match.IgnoreRule{
+						{
+							Namespace:        "vex",
+							VexStatus:        "not_affected",
+							VexJustification: "vulnerable_code_not_present",
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "cyclonedx-demo2 (xml) - ignore by fixed status resolved against the package ID",
+			options: ProcessorOptions{
+				Documents: []string{
+					"testdata/vex-docs/cyclonedx-demo2.xml",
+				},
+				IgnoreRules: []match.IgnoreRule{
+					{
+						VexStatus: "fixed",
+					},
+				},
+			},
+			args: args{
+				pkgContext: pkgContext,
+				matches:    getSubject(),
+			},
+			wantMatches: matchesRef(libCryptoCVE_2023_3817, libCryptoCVE_2023_1255),
+			wantIgnoredMatches: []match.IgnoredMatch{
+				{
+					Match: libCryptoCVE_2023_2975,
+					AppliedIgnoreRules: []match.IgnoreRule{
+						{
+							Namespace: "vex",
+							VexStatus: "fixed",
+						},
+					},
+				},
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -0,0 +1,224 @@
+package vulnerability
+
+import (
+	"strings"
+
+	"github.com/hashicorp/go-multierror"
+
+	grypePkg "github.com/anchore/grype/grype/pkg"
+	"github.com/anchore/grype/internal/log"
+)
+
+// CompositeProvider finds vulnerabilities from several providers, such as the public database along with an internal
+// database. Providers are given in order of priority: when more than one provider finds a vulnerability with the same
+// reference (ID and namespace) only the vulnerabilities from the provider with the highest priority are kept, and
+// metadata is looked up from the provider with the highest priority that has data from the provider of the
+// vulnerability (the prefix of its namespace).
+type CompositeProvider struct {
+	providers []Provider
+
+	// sources are the names of the providers of the data within each provider (by index), or nil for providers that
+	// do not describe their data
+	sources []map[string]struct{}
+}
+
+var _ interface {
+	Provider
+	BatchProvider
+	Prefetcher
+	StoreMetadataProvider
+} = (*CompositeProvider)(nil)
+
+// NewCompositeProvider returns a provider that finds vulnerabilities from all the given providers, in order of
+// priority. The returned provider takes ownership of the given providers (closing them when closed).
+func NewCompositeProvider(providers ...Provider) *CompositeProvider {
+	return &CompositeProvider{
+		providers: providers,
+		sources:   dataSources(providers),
+	}
+}
+
+// dataSources returns the names of the providers of the data within each of the given providers
+func dataSources(providers []Provider) []map[string]struct{} {
+	out := make([]map[string]struct{}, len(providers))
+	for i, provider := range providers {
+		dpr, ok := provider.(StoreMetadataProvider)
+		if !ok {
+			continue
+		}
+		dps, err := dpr.DataProvenance()
+		if err != nil {
+			log.WithFields("error", err).Debug("unable to read the provenance of vulnerability data")
+			continue
+		}
+		out[i] = make(map[string]struct{}, len(dps))
+		for name := range dps {
+			out[i][name] = struct{}{}
+		}
+	}
+	return out
+}
+
+// Providers returns the providers that are composed, in order of priority
+func (c *CompositeProvider) Providers() []Provider {
+	return append([]Provider(nil), c.providers...)
+}
+
+func (c *CompositeProvider) PackageSearchNames(p grypePkg.Package) []string {
+	var names []string
+	seen := make(map[string]struct{})
+	for _, provider := range c.providers {
+		for _, n := range provider.PackageSearchNames(p) {
+			if _, ok := seen[n]; ok {
+				continue
+			}
+			seen[n] = struct{}{}
+			names = append(names, n)
+		}
+	}
+	return names
+}
+
+func (c *CompositeProvider) FindVulnerabilities(criteria ...Criteria) ([]Vulnerability, error) {
+	results := make([][]Vulnerability, len(c.providers))
+	for i, provider := range c.providers {
+		vulns, err := provider.FindVulnerabilities(criteria...)
+		if err != nil {
+			return nil, err
+		}
+		results[i] = vulns
+	}
+	return mergeVulnerabilities(results), nil
+}
+
+func (c *CompositeProvider) FindVulnerabilitiesBatch(queries ...[]Criteria) ([][]Vulnerability, error) {
+	batches := make([][][]Vulnerability, len(c.providers))
+	for i, provider := range c.providers {
+		batch, err := FindVulnerabilitiesBatch(provider, queries...)
+		if err != nil {
+			return nil, err
+		}
+		batches[i] = batch
+	}
+
+	out := make([][]Vulnerability, len(queries))
+	for q := range queries {
+		results := make([][]Vulnerability, len(c.providers))
+		for i, batch := range batches {
+			results[i] = batch[q]
+		}
+		out[q] = mergeVulnerabilities(results)
+	}
+	return out, nil
+}
+
+// Prefetch returns a composite of all providers with the data for the given queries already fetched (where supported)
+func (c *CompositeProvider) Prefetch(queries ...[]Criteria) (Provider, error) {
+	providers := make([]Provider, len(c.providers))
+	for i, provider := range c.providers {
+		prefetched, err := Prefetch(provider, queries...)
+		if err != nil {
+			return nil, err
+		}
+		providers[i] = prefetched
+	}
+	// prefetching does not change the data within each provider
+	return &CompositeProvider{
+		providers: providers,
+		sources:   c.sources,
+	}, nil
+}
+
+// Deprecated: vulnerability.Vulnerability objects now have metadata included
+func (c *CompositeProvider) VulnerabilityMetadata(ref Reference) (*Metadata, error) {
+	for _, provider := range c.metadataProviders(ref) {
+		meta, err := provider.VulnerabilityMetadata(ref)
+		if err != nil {
+			// another provider may have the metadata
+			log.WithFields("error", err, "id", ref.ID, "namespace", ref.Namespace).Debug("unable to fetch vulnerability metadata")
+			continue
+		}
+		if meta != nil {
+			return meta, nil
+		}
+	}
+	return nil, nil
+}
+
+// metadataProviders returns the providers to look up metadata for the given reference from, in order: those with data
+// from the provider of the vulnerability, those that do not describe their data, and finally all others.
+func (c *CompositeProvider) metadataProviders(ref Reference) []Provider {
+	name := strings.Split(ref.Namespace, ":")[0]
+
+	var owners, unknown, others []Provider
+	for i, provider := range c.providers {
+		sources := c.sources[i]
+		if sources == nil {
+			unknown = append(unknown, provider)
+			continue
+		}
+		if _, ok := sources[name]; ok {
+			owners = append(owners, provider)
+			continue
+		}
+		others = append(others, provider)
+	}
+	return append(append(owners, unknown...), others...)
+}
+
+// DataProvenance returns the provenance of the data from all providers. When more than one provider has data from the
+// same provider, the provenance from the provider with the highest priority is returned.
+func (c *CompositeProvider) DataProvenance() (map[string]DataProvenance, error) {
+	out := make(map[string]DataProvenance)
+	for i := len(c.providers) - 1; i >= 0; i-- {
+		dpr, ok := c.providers[i].(StoreMetadataProvider)
+		if !ok {
+			continue
+		}
+		dps, err := dpr.DataProvenance()
+		if err != nil {
+			return nil, err
+		}
+		for k, v := range dps {
+			out[k] = v
+		}
+	}
+	return out, nil
+}
+
+func (c *CompositeProvider) Close() error {
+	var errs error
+	for _, provider := range c.providers {
+		if err := provider.Close(); err != nil {
+			errs = multierror.Append(errs, err)
+		}
+	}
+	return errs
+}
+
+// mergeVulnerabilities combines the vulnerabilities found by each provider (in order of priority), dropping any
+// vulnerability with a reference that was already found by a provider with a higher priority
+func mergeVulnerabilities(results [][]Vulnerability) []Vulnerability {
+	type key struct {
+		id, namespace string
+	}
+
+	var out []Vulnerability
+	found := make(map[key]struct{})
+	for _, vulns := range results {
+		// a single provider may find several vulnerabilities with the same reference (e.g. one per affected range)
+		var keys []key
+		for _, v := range vulns {
+			k := key{id: v.ID, namespace: v.Namespace}
+			if _, ok := found[k]; ok {
+				continue
+			}
+			out = append(out, v)
+			keys = append(keys, k)
+		}
+		for _, k := range keys {
+			found[k] = struct{}{}
+		}
+	}
+	return out
+}
@@ -0,0 +1,155 @@
+package vulnerability_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	grypePkg "github.com/anchore/grype/grype/pkg"
+	"github.com/anchore/grype/grype/search"
+	"github.com/anchore/grype/grype/version"
+	"github.com/anchore/grype/grype/vulnerability"
+	"github.com/anchore/grype/grype/vulnerability/mock"
+	syftPkg "github.com/anchore/syft/syft/pkg"
+)
+
+// provenanceProvider is a mock provider reporting data from the given providers
+type provenanceProvider struct {
+	vulnerability.Provider
+	provenance map[string]vulnerability.DataProvenance
+}
+
+func (p provenanceProvider) DataProvenance() (map[string]vulnerability.DataProvenance, error) {
+	return p.provenance, nil
+}
+
+func newProvider(provenance map[string]vulnerability.DataProvenance, vulns ...vulnerability.Vulnerability) vulnerability.Provider {
+	return provenanceProvider{
+		Provider:   mock.VulnerabilityProvider(vulns...),
+		provenance: provenance,
+	}
+}
+
+func vuln(id, namespace, constraint, severity string) vulnerability.Vulnerability {
+	return vulnerability.Vulnerability{
+		Reference: vulnerability.Reference{
+			ID:        id,
+			Namespace: namespace,
+			Internal:  vulnerability.Metadata{Severity: severity},
+		},
+		PackageName: "acme-widget",
+		Constraint:  version.MustGetConstraint(constraint, version.UnknownFormat),
+	}
+}
+
+func TestCompositeProvider(t *testing.T) {
+	internal := newProvider(
+		map[string]vulnerability.DataProvenance{"github": {InputDigest: "internal"}, "acme": {InputDigest: "acme"}},
+		vuln("GHSA-shared", "github:language:javascript", "< 3.0.0", "Critical"),
+		vuln("ACME-2025-0001", "acme:language:javascript", "< 2.0.0", "High"),
+	)
+	public := newProvider(
+		map[string]vulnerability.DataProvenance{"github": {InputDigest: "public"}, "nvd": {InputDigest: "nvd"}},
+		vuln("GHSA-shared", "github:language:javascript", "< 1.5.0", "Low"),
+		vuln("GHSA-shared", "github:language:javascript", ">= 2.0.0, < 2.1.0", "Low"),
+		vuln("GHSA-public", "github:language:javascript", "< 2.0.0", "Medium"),
+	)
+
+	c := vulnerability.NewCompositeProvider(internal, public)
+	t.Cleanup(func() { require.NoError(t, c.Close()) })
+	assert.Len(t, c.Providers(), 2)
+
+	criteria := []vulnerability.Criteria{
+		search.ByPackageName("acme-widget"),
+		search.ByEcosystem(syftPkg.JavaScript, syftPkg.NpmPkg),
+		search.ByVersion(*version.NewVersion("1.3.0", version.UnknownFormat)),
+	}
+
+	vulns, err := c.FindVulnerabilities(criteria...)
+	require.NoError(t, err)
+
+	// the shared vulnerability is only kept from the provider with the highest priority
+	var found []string
+	for _, v := range vulns {
+		found = append(found, v.ID+"@"+v.Constraint.String())
+	}
+	assert.Equal(t, []string{
+		"GHSA-shared@< 3.0.0 (unknown)",
+		"ACME-2025-0001@< 2.0.0 (unknown)",
+		"GHSA-public@< 2.0.0 (unknown)",
+	}, found)
+
+	batch, err := c.FindVulnerabilitiesBatch(criteria, []vulnerability.Criteria{search.ByID("GHSA-shared")})
+	require.NoError(t, err)
+	require.Len(t, batch, 2)
+	assert.Equal(t, vulns, batch[0])
+	require.Len(t, batch[1], 1)
+	assert.Equal(t, "< 3.0.0 (unknown)", batch[1][0].Constraint.String())
+
+	// metadata conflicts are resolved by priority, and otherwise looked up from the provider with the data
+	meta, err := c.VulnerabilityMetadata(vulnerability.Reference{ID: "GHSA-shared", Namespace: "github:language:javascript"})
+	require.NoError(t, err)
+	assert.Equal(t, "Critical", meta.Severity)
+
+	meta, err = c.VulnerabilityMetadata(vulnerability.Reference{ID: "GHSA-public", Namespace: "github:language:javascript"})
+	require.NoError(t, err)
+	assert.Equal(t, "Medium", meta.Severity)
+
+	meta, err = c.VulnerabilityMetadata(vulnerability.Reference{ID: "GHSA-missing", Namespace: "github:language:javascript"})
+	require.NoError(t, err)
+	assert.Nil(t, meta)
+
+	provenance, err := c.DataProvenance()
+	require.NoError(t, err)
+	assert.Equal(t, map[string]vulnerability.DataProvenance{
+		"github": {InputDigest: "internal"},
+		"acme":   {InputDigest: "acme"},
+		"nvd":    {InputDigest: "nvd"},
+	}, provenance)
+
+	names := c.PackageSearchNames(grypePkg.Package{Name: "acme-widget", Type: syftPkg.NpmPkg})
+	assert.Equal(t, []string{"acme-widget"}, names)
+}
+
+// failingProvider is a mock provider that fails to look up metadata, counting the reads of its provenance
+type failingProvider struct {
+	provenanceProvider
+	provenanceReads int
+}
+
+func (p *failingProvider) DataProvenance() (map[string]vulnerability.DataProvenance, error) {
+	p.provenanceReads++
+	return p.provenanceProvider.DataProvenance()
+}
+
+func (p *failingProvider) VulnerabilityMetadata(vulnerability.Reference) (*vulnerability.Metadata, error) {
+	return nil, errors.New("unable to read metadata")
+}
+
+func TestCompositeProvider_VulnerabilityMetadata_fallsThroughErrors(t *testing.T) {
+	failing := &failingProvider{
+		provenanceProvider: provenanceProvider{
+			Provider:   mock.VulnerabilityProvider(),
+			provenance: map[string]vulnerability.DataProvenance{"github": {InputDigest: "failing"}},
+		},
+	}
+	public := newProvider(
+		map[string]vulnerability.DataProvenance{"github": {InputDigest: "public"}},
+		vuln("GHSA-public", "github:language:javascript", "< 2.0.0", "Medium"),
+	)
+
+	c := vulnerability.NewCompositeProvider(failing, public)
+
+	// the provider with the data is tried first, but the metadata is found by the next provider
+	for range 3 {
+		meta, err := c.VulnerabilityMetadata(vulnerability.Reference{ID: "GHSA-public", Namespace: "github:language:javascript"})
+		require.NoError(t, err)
+		require.NotNil(t, meta)
+		assert.Equal(t, "Medium", meta.Severity)
+	}
+
+	// the provenance is only read when the composite is created
+	assert.Equal(t, 1, failing.provenanceReads)
+}
@@ -1,5 +1,7 @@
 package vulnerability
 
+import "time"
+
 type FixState string
 
 const (
@@ -21,6 +23,8 @@ func AllFixStates() []FixState {
 type Fix struct {
 	Versions []string
 	State    FixState
+	// Date is the earliest known date a fix was made available (if known)
+	Date *time.Time
 }
 
 func (f FixState) String() string {
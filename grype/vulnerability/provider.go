@@ -33,6 +33,50 @@ type Provider interface {
 	io.Closer
 }
 
+// BatchProvider is implemented by providers that can search for the vulnerabilities of many packages at once, which
+// can be significantly faster than calling FindVulnerabilities for each package (e.g. to prefetch vulnerabilities for
+// all packages in an ecosystem).
+type BatchProvider interface {
+	// FindVulnerabilitiesBatch returns the vulnerabilities matching all criteria within each of the given queries. The
+	// results are in the same order as the queries and are the same as calling FindVulnerabilities for each query.
+	FindVulnerabilitiesBatch(queries ...[]Criteria) ([][]Vulnerability, error)
+}
+
+// FindVulnerabilitiesBatch returns the vulnerabilities for each of the given queries, using a single batch search when
+// the provider supports it and otherwise searching for each query in turn.
+func FindVulnerabilitiesBatch(provider Provider, queries ...[]Criteria) ([][]Vulnerability, error) {
+	if batchProvider, ok := provider.(BatchProvider); ok {
+		return batchProvider.FindVulnerabilitiesBatch(queries...)
+	}
+
+	out := make([][]Vulnerability, len(queries))
+	for i, criteria := range queries {
+		vulns, err := provider.FindVulnerabilities(criteria...)
+		if err != nil {
+			return nil, err
+		}
+		out[i] = vulns
+	}
+	return out, nil
+}
+
+// Prefetcher is implemented by providers that can fetch the data needed to search for many packages up front (e.g. for
+// a chunk of the packages being matched), which can be significantly faster than searching for each package in turn.
+type Prefetcher interface {
+	// Prefetch returns a provider with the data for the given queries already fetched. The returned provider finds the
+	// same vulnerabilities as this provider for any criteria, and shares its underlying resources, so is not to be closed.
+	Prefetch(queries ...[]Criteria) (Provider, error)
+}
+
+// Prefetch returns a provider with the data for the given queries already fetched when the provider supports it, and
+// otherwise returns the provider unchanged.
+func Prefetch(provider Provider, queries ...[]Criteria) (Provider, error) {
+	if prefetcher, ok := provider.(Prefetcher); ok {
+		return prefetcher.Prefetch(queries...)
+	}
+	return provider, nil
+}
+
 type StoreMetadataProvider interface {
 	DataProvenance() (map[string]DataProvenance, error)
 }
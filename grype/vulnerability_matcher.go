@@ -6,6 +6,9 @@ import (
 	"runtime/debug"
 	"slices"
 	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
 
 	"github.com/wagoodman/go-partybus"
 	"github.com/wagoodman/go-progress"
@@ -17,6 +20,7 @@ import (
 	"github.com/anchore/grype/grype/match"
 	"github.com/anchore/grype/grype/matcher/stock"
 	"github.com/anchore/grype/grype/pkg"
+	"github.com/anchore/grype/grype/search"
 	"github.com/anchore/grype/grype/vex"
 	"github.com/anchore/grype/grype/vulnerability"
 	"github.com/anchore/grype/internal/bus"
@@ -27,6 +31,9 @@ import (
 const (
 	branch = "├──"
 	leaf   = "└──"
+
+	// prefetchChunkSize is the largest number of packages to prefetch vulnerability data for at once
+	prefetchChunkSize = 100
 )
 
 type VulnerabilityMatcher struct {
@@ -34,9 +41,14 @@ type VulnerabilityMatcher struct {
 	ExclusionProvider     match.ExclusionProvider
 	Matchers              []match.Matcher
 	IgnoreRules           []match.IgnoreRule
+	IgnoreFilters         []match.IgnoreFilter // additional filters applied alongside the ignore rules (e.g. a baseline of known findings)
 	FailSeverity          *vulnerability.Severity
+	FailRisk              *float64 // fail when a match has a risk score (0-100) at or above this value
+	FailKEV               bool     // fail when a match is a known exploited vulnerability
+	FailEPSSPercentile    *float64 // fail when a match has an EPSS percentile (0-1) at or above this value
 	NormalizeByCVE        bool
 	VexProcessor          *vex.Processor
+	Concurrency           int // the number of packages to search for matches in parallel (values less than 2 search one package at a time)
 }
 
 func (m *VulnerabilityMatcher) FailAtOrAboveSeverity(severity *vulnerability.Severity) *VulnerabilityMatcher {
@@ -44,6 +56,26 @@ func (m *VulnerabilityMatcher) FailAtOrAboveSeverity(severity *vulnerability.Sev
 	return m
 }
 
+func (m *VulnerabilityMatcher) FailAtOrAboveRisk(risk *float64) *VulnerabilityMatcher {
+	m.FailRisk = risk
+	return m
+}
+
+func (m *VulnerabilityMatcher) FailOnKEV(kev bool) *VulnerabilityMatcher {
+	m.FailKEV = kev
+	return m
+}
+
+func (m *VulnerabilityMatcher) FailAtOrAboveEPSSPercentile(percentile *float64) *VulnerabilityMatcher {
+	m.FailEPSSPercentile = percentile
+	return m
+}
+
+func (m *VulnerabilityMatcher) WithConcurrency(concurrency int) *VulnerabilityMatcher {
+	m.Concurrency = concurrency
+	return m
+}
+
 func (m *VulnerabilityMatcher) WithMatchers(matchers []match.Matcher) *VulnerabilityMatcher {
 	m.Matchers = matchers
 	return m
@@ -54,9 +86,16 @@ func (m *VulnerabilityMatcher) WithIgnoreRules(ignoreRules []match.IgnoreRule) *
 	return m
 }
 
+func (m *VulnerabilityMatcher) WithIgnoreFilters(filters ...match.IgnoreFilter) *VulnerabilityMatcher {
+	m.IgnoreFilters = filters
+	return m
+}
+
 func (m *VulnerabilityMatcher) FindMatches(pkgs []pkg.Package, context pkg.Context) (remainingMatches *match.Matches, ignoredMatches []match.IgnoredMatch, err error) {
 	progressMonitor := trackMatcher(len(pkgs))
 
+	m.notifyExpiredIgnoreRules()
+
 	defer func() {
 		progressMonitor.Ignored.Set(int64(len(ignoredMatches)))
 		progressMonitor.SetCompleted()
@@ -77,8 +116,7 @@ func (m *VulnerabilityMatcher) FindMatches(pkgs []pkg.Package, context pkg.Conte
 		return remainingMatches, ignoredMatches, err
 	}
 
-	if m.FailSeverity != nil && hasSeverityAtOrAbove(m.VulnerabilityProvider, *m.FailSeverity, *remainingMatches) {
-		err = grypeerr.ErrAboveSeverityThreshold
+	if err = m.checkFailThresholds(*remainingMatches); err != nil {
 		return remainingMatches, ignoredMatches, err
 	}
 
@@ -151,51 +189,60 @@ func (m *VulnerabilityMatcher) searchDBForMatches(
 		defaultMatcher = stock.NewStockMatcher(stock.MatcherConfig{UseCPEs: true})
 	}
 
-	var matcherErrs []error
-	for _, p := range packages {
-		progressMonitor.PackagesProcessed.Increment()
-		log.WithFields("package", displayPackage(p)).Trace("searching for vulnerability matches")
-
-		// if there is no distro set, default to global distro
-		orig := p.Distro
-		if orig == nil {
-			p.Distro = d
-		}
-
-		matchAgainst, ok := matcherIndex[p.Type]
-		if !ok {
-			matchAgainst = []match.Matcher{defaultMatcher}
-		}
-		for _, theMatcher := range matchAgainst {
-			matches, ignorers, err := callMatcherSafely(theMatcher, m.VulnerabilityProvider, p)
-			if err != nil {
-				if match.IsFatalError(err) {
-					return match.Matches{}, err
+	// results are collected by package index so that the output is the same regardless of the order
+	// in which the workers complete
+	results := make([]packageMatchResult, len(packages))
+
+	// packages are searched in chunks so that the vulnerability data for all packages within a chunk is prefetched
+	// at once, while still spreading the packages across all workers
+	workers := min(max(m.Concurrency, 1), len(packages))
+	chunks := make(chan []int)
+	var fatal atomic.Bool
+	var wg sync.WaitGroup
+	for range workers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for chunk := range chunks {
+				if fatal.Load() {
+					continue
+				}
+				provider := m.prefetch(d, packages, chunk)
+				for _, idx := range chunk {
+					if fatal.Load() {
+						break
+					}
+					results[idx] = m.searchDBForPackageMatches(d, packages[idx], provider, matcherIndex, defaultMatcher, progressMonitor)
+					if results[idx].fatalErr != nil {
+						fatal.Store(true)
+					}
 				}
-
-				log.WithFields("error", err, "package", displayPackage(p)).Warn("matcher returned error")
-				matcherErrs = append(matcherErrs, err)
 			}
+		}()
+	}
 
-			allIgnorers = append(allIgnorers, ignorers...)
-
-			// Filter out matches based on records in the database exclusion table and hard-coded rules
-			filtered, dropped := match.ApplyExplicitIgnoreRules(m.ExclusionProvider, match.NewMatches(matches...))
-
-			additionalMatches := filtered.Sorted()
-			logPackageMatches(p, additionalMatches)
-			logExplicitDroppedPackageMatches(p, dropped)
-			allMatches = append(allMatches, additionalMatches...)
-
-			progressMonitor.MatchesDiscovered.Add(int64(len(additionalMatches)))
-
-			// note: there is a difference between "ignore" and "dropped" matches.
-			// ignored: matches that are filtered out due to user-provided ignore rules
-			// dropped: matches that are filtered out due to hard-coded rules
-			updateVulnerabilityList(progressMonitor, additionalMatches, nil, dropped, m.VulnerabilityProvider)
+	chunkSize := min(prefetchChunkSize, max(len(packages)/max(workers, 1), 1))
+	for start := 0; start < len(packages); start += chunkSize {
+		if fatal.Load() {
+			break
 		}
+		var chunk []int
+		for idx := start; idx < min(start+chunkSize, len(packages)); idx++ {
+			chunk = append(chunk, idx)
+		}
+		chunks <- chunk
+	}
+	close(chunks)
+	wg.Wait()
 
-		p.Distro = orig
+	var matcherErrs []error
+	for _, r := range results {
+		if r.fatalErr != nil {
+			return match.Matches{}, r.fatalErr
+		}
+		matcherErrs = append(matcherErrs, r.errs...)
+		allIgnorers = append(allIgnorers, r.ignorers...)
+		allMatches = append(allMatches, r.matches...)
 	}
 
 	// apply ignores based on matchers returning ignore rules
@@ -211,6 +258,105 @@ func (m *VulnerabilityMatcher) searchDBForMatches(
 	return res, errors.Join(matcherErrs...)
 }
 
+// packageMatchResult is the outcome of searching for matches for a single package
+type packageMatchResult struct {
+	matches  []match.Match
+	ignorers []match.IgnoreFilter
+	errs     []error
+	fatalErr error
+}
+
+// prefetch returns a provider with the vulnerability data already fetched for the searches that matchers commonly make
+// for the given packages (by name within the distro of each package and its upstream packages, and by name within the
+// ecosystem of each package). Any errors are logged and the provider is used as-is, as prefetching is only an
+// optimization.
+func (m *VulnerabilityMatcher) prefetch(d *distro.Distro, packages []pkg.Package, chunk []int) vulnerability.Provider {
+	if m.VulnerabilityProvider == nil {
+		return nil
+	}
+
+	var queries [][]vulnerability.Criteria
+	for _, idx := range chunk {
+		p := packages[idx]
+		if p.Distro == nil {
+			p.Distro = d
+		}
+		if p.Distro != nil {
+			for _, searchPkg := range append([]pkg.Package{p}, pkg.UpstreamPackages(p)...) {
+				queries = append(queries, []vulnerability.Criteria{search.ByPackageName(searchPkg.Name), search.ByDistro(*p.Distro)})
+			}
+		}
+		for _, name := range m.VulnerabilityProvider.PackageSearchNames(p) {
+			queries = append(queries, []vulnerability.Criteria{search.ByEcosystem(p.Language, p.Type), search.ByPackageName(name)})
+		}
+	}
+
+	provider, err := vulnerability.Prefetch(m.VulnerabilityProvider, queries...)
+	if err != nil {
+		log.WithFields("error", err, "packages", len(chunk)).Debug("unable to prefetch vulnerabilities")
+		return m.VulnerabilityProvider
+	}
+	return provider
+}
+
+// searchDBForPackageMatches runs all matchers for the given package, searching the given provider (which may have
+// prefetched data for the package). This may be called concurrently for different packages, so only the (atomic)
+// progress monitor and read-only state may be shared.
+func (m *VulnerabilityMatcher) searchDBForPackageMatches(
+	d *distro.Distro,
+	p pkg.Package,
+	provider vulnerability.Provider,
+	matcherIndex map[syftPkg.Type][]match.Matcher,
+	defaultMatcher match.Matcher,
+	progressMonitor *monitorWriter,
+) packageMatchResult {
+	var result packageMatchResult
+
+	progressMonitor.PackagesProcessed.Increment()
+	log.WithFields("package", displayPackage(p)).Trace("searching for vulnerability matches")
+
+	// if there is no distro set, default to global distro
+	if p.Distro == nil {
+		p.Distro = d
+	}
+
+	matchAgainst, ok := matcherIndex[p.Type]
+	if !ok {
+		matchAgainst = []match.Matcher{defaultMatcher}
+	}
+	for _, theMatcher := range matchAgainst {
+		matches, ignorers, err := callMatcherSafely(theMatcher, provider, p)
+		if err != nil {
+			if match.IsFatalError(err) {
+				result.fatalErr = err
+				return result
+			}
+
+			log.WithFields("error", err, "package", displayPackage(p)).Warn("matcher returned error")
+			result.errs = append(result.errs, err)
+		}
+
+		result.ignorers = append(result.ignorers, ignorers...)
+
+		// Filter out matches based on records in the database exclusion table and hard-coded rules
+		filtered, dropped := match.ApplyExplicitIgnoreRules(m.ExclusionProvider, match.NewMatches(matches...))
+
+		additionalMatches := filtered.Sorted()
+		logPackageMatches(p, additionalMatches)
+		logExplicitDroppedPackageMatches(p, dropped)
+		result.matches = append(result.matches, additionalMatches...)
+
+		progressMonitor.MatchesDiscovered.Add(int64(len(additionalMatches)))
+
+		// note: there is a difference between "ignore" and "dropped" matches.
+		// ignored: matches that are filtered out due to user-provided ignore rules
+		// dropped: matches that are filtered out due to hard-coded rules
+		updateVulnerabilityList(progressMonitor, additionalMatches, nil, dropped, m.VulnerabilityProvider)
+	}
+
+	return result
+}
+
 func callMatcherSafely(m match.Matcher, vp vulnerability.Provider, p pkg.Package) (matches []match.Match, ignoredMatches []match.IgnoreFilter, err error) {
 	// handle individual matcher panics
 	defer func() {
@@ -242,14 +388,70 @@ func (m *VulnerabilityMatcher) findVEXMatches(context pkg.Context, remainingMatc
 	return matchesAfterVex, ignoredMatchesAfterVex, nil
 }
 
+// notifyExpiredIgnoreRules warns about user-provided ignore rules that have expired and are no longer applied
+func (m *VulnerabilityMatcher) notifyExpiredIgnoreRules() {
+	now := time.Now()
+	for _, r := range m.IgnoreRules {
+		if !r.IsExpired(now) {
+			continue
+		}
+
+		var details []string
+		if r.Owner != "" {
+			details = append(details, fmt.Sprintf("owner: %s", r.Owner))
+		}
+		if r.Ticket != "" {
+			details = append(details, fmt.Sprintf("ticket: %s", r.Ticket))
+		}
+
+		msg := fmt.Sprintf("ignore rule %s expired on %s and is no longer applied", describeIgnoreRule(r), r.Expires)
+		if len(details) > 0 {
+			msg = fmt.Sprintf("%s (%s)", msg, strings.Join(details, ", "))
+		}
+
+		log.WithFields("vulnerability", r.Vulnerability, "package", r.Package.Name, "expires", r.Expires).Debug("ignore rule expired")
+		bus.Notify(msg)
+	}
+}
+
+func describeIgnoreRule(r match.IgnoreRule) string {
+	var parts []string
+	if r.Vulnerability != "" {
+		parts = append(parts, r.Vulnerability)
+	}
+	if r.Package.Name != "" {
+		parts = append(parts, fmt.Sprintf("package=%s", r.Package.Name))
+	}
+	if r.Package.Version != "" {
+		parts = append(parts, fmt.Sprintf("version=%s", r.Package.Version))
+	}
+	if r.FixState != "" {
+		parts = append(parts, fmt.Sprintf("fix-state=%s", r.FixState))
+	}
+	if r.VexStatus != "" {
+		parts = append(parts, fmt.Sprintf("vex-status=%s", r.VexStatus))
+	}
+	if len(parts) == 0 {
+		return "(no criteria)"
+	}
+	return fmt.Sprintf("[%s]", strings.Join(parts, " "))
+}
+
 // applyIgnoreRules applies the user-provided ignore rules, splitting ignored matches into a separate set
 func (m *VulnerabilityMatcher) applyIgnoreRules(matches match.Matches) (match.Matches, []match.IgnoredMatch) {
 	var ignoredMatches []match.IgnoredMatch
-	if len(m.IgnoreRules) == 0 {
+	if len(m.IgnoreRules) == 0 && len(m.IgnoreFilters) == 0 {
 		return matches, ignoredMatches
 	}
 
-	matches, ignoredMatches = match.ApplyIgnoreRules(matches, m.IgnoreRules)
+	filters := make([]match.IgnoreFilter, 0, len(m.IgnoreRules)+len(m.IgnoreFilters))
+	for _, r := range m.IgnoreRules {
+		filters = append(filters, r)
+	}
+	filters = append(filters, m.IgnoreFilters...)
+
+	remaining, ignoredMatches := match.ApplyIgnoreFilters(matches.Sorted(), filters...)
+	matches = match.NewMatches(remaining...)
 
 	if count := len(ignoredMatches); count > 0 {
 		log.Infof("ignoring %d matches due to user-provided ignore rules", count)
@@ -408,6 +610,74 @@ func isCVE(id string) bool {
 	return strings.HasPrefix(strings.ToLower(id), "cve-")
 }
 
+// maxFailSummaryMatches is the number of matches listed in the error when a fail threshold is tripped
+const maxFailSummaryMatches = 10
+
+// checkFailThresholds returns an expected error for each configured fail threshold that any of the remaining
+// matches meets, summarizing the matches responsible
+func (m *VulnerabilityMatcher) checkFailThresholds(matches match.Matches) error {
+	var errs []error
+	if m.FailSeverity != nil && hasSeverityAtOrAbove(m.VulnerabilityProvider, *m.FailSeverity, matches) {
+		errs = append(errs, grypeerr.ErrAboveSeverityThreshold)
+	}
+
+	if m.FailRisk != nil || m.FailKEV || m.FailEPSSPercentile != nil {
+		var risky, exploited, likely []string
+		for _, mt := range matches.Sorted() {
+			metadata, err := m.VulnerabilityProvider.VulnerabilityMetadata(mt.Vulnerability.Reference)
+			if err != nil || metadata == nil {
+				continue
+			}
+
+			if m.FailRisk != nil && metadata.RiskScore() >= *m.FailRisk {
+				risky = append(risky, fmt.Sprintf("%s (risk %.1f)", describeMatch(mt), metadata.RiskScore()))
+			}
+
+			if m.FailKEV && len(metadata.KnownExploited) > 0 {
+				exploited = append(exploited, describeMatch(mt))
+			}
+
+			if m.FailEPSSPercentile != nil {
+				if percentile := maxEPSSPercentile(*metadata); percentile >= *m.FailEPSSPercentile {
+					likely = append(likely, fmt.Sprintf("%s (EPSS percentile %.3f)", describeMatch(mt), percentile))
+				}
+			}
+		}
+
+		errs = appendFailThresholdErr(errs, grypeerr.ErrAboveRiskThreshold, risky)
+		errs = appendFailThresholdErr(errs, grypeerr.ErrKnownExploited, exploited)
+		errs = appendFailThresholdErr(errs, grypeerr.ErrAboveEPSSPercentileThreshold, likely)
+	}
+
+	if len(errs) == 1 {
+		return errs[0]
+	}
+	return errors.Join(errs...)
+}
+
+func appendFailThresholdErr(errs []error, err error, tripped []string) []error {
+	if len(tripped) == 0 {
+		return errs
+	}
+	summary := tripped
+	if len(tripped) > maxFailSummaryMatches {
+		summary = append(slices.Clone(tripped[:maxFailSummaryMatches]), fmt.Sprintf("and %d more", len(tripped)-maxFailSummaryMatches))
+	}
+	return append(errs, fmt.Errorf("%w: %s", err, strings.Join(summary, ", ")))
+}
+
+func describeMatch(m match.Match) string {
+	return fmt.Sprintf("%s in %s@%s", m.Vulnerability.ID, m.Package.Name, m.Package.Version)
+}
+
+func maxEPSSPercentile(metadata vulnerability.Metadata) float64 {
+	var percentile float64
+	for _, e := range metadata.EPSS {
+		percentile = max(percentile, e.Percentile)
+	}
+	return percentile
+}
+
 func hasSeverityAtOrAbove(store vulnerability.MetadataProvider, severity vulnerability.Severity, matches match.Matches) bool {
 	if severity == vulnerability.UnknownSeverity {
 		return false
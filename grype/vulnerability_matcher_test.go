@@ -2,6 +2,9 @@ package grype
 
 import (
 	"errors"
+	"fmt"
+	"strings"
+	"sync"
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
@@ -22,6 +25,7 @@ import (
 	"github.com/anchore/grype/grype/matcher/ruby"
 	"github.com/anchore/grype/grype/pkg"
 	"github.com/anchore/grype/grype/pkg/qualifier"
+	"github.com/anchore/grype/grype/search"
 	"github.com/anchore/grype/grype/version"
 	"github.com/anchore/grype/grype/vex"
 	"github.com/anchore/grype/grype/vulnerability"
@@ -199,6 +203,145 @@ func Test_HasSeverityAtOrAbove(t *testing.T) {
 	}
 }
 
+func TestVulnerabilityMatcher_checkFailThresholds(t *testing.T) {
+	thePkg := pkg.Package{
+		ID:      pkg.ID(uuid.NewString()),
+		Name:    "the-package",
+		Version: "v0.1",
+		Type:    syftPkg.DebPkg,
+	}
+
+	newMatch := func(id string) match.Match {
+		return match.Match{
+			Vulnerability: vulnerability.Vulnerability{
+				Reference: vulnerability.Reference{ID: id, Namespace: "test"},
+			},
+			Package: thePkg,
+			Details: match.Details{{Type: match.ExactDirectMatch}},
+		}
+	}
+
+	provider := mock.VulnerabilityProvider(
+		vulnerability.Vulnerability{
+			Reference: vulnerability.Reference{
+				ID:        "CVE-exploited",
+				Namespace: "test",
+				Internal: vulnerability.Metadata{
+					Severity:       "high",
+					Cvss:           []vulnerability.Cvss{{Metrics: vulnerability.CvssMetrics{BaseScore: 9.8}}},
+					KnownExploited: []vulnerability.KnownExploited{{CVE: "CVE-exploited"}},
+					EPSS:           []vulnerability.EPSS{{CVE: "CVE-exploited", EPSS: 0.9, Percentile: 0.99}},
+				},
+			},
+		},
+		vulnerability.Vulnerability{
+			Reference: vulnerability.Reference{
+				ID:        "CVE-unlikely",
+				Namespace: "test",
+				Internal: vulnerability.Metadata{
+					Severity: "low",
+					EPSS:     []vulnerability.EPSS{{CVE: "CVE-unlikely", EPSS: 0.001, Percentile: 0.2}},
+				},
+			},
+		},
+	)
+
+	matches := match.NewMatches(newMatch("CVE-exploited"), newMatch("CVE-unlikely"))
+	unlikelyOnly := match.NewMatches(newMatch("CVE-unlikely"))
+
+	ptr := func(f float64) *float64 { return &f }
+
+	tests := []struct {
+		name         string
+		matcher      VulnerabilityMatcher
+		matches      match.Matches
+		wantErrs     []error
+		wantMessages []string
+	}{
+		{
+			name:    "no thresholds",
+			matches: matches,
+		},
+		{
+			name:     "risk at or above threshold",
+			matcher:  VulnerabilityMatcher{FailRisk: ptr(50)},
+			matches:  matches,
+			wantErrs: []error{grypeerr.ErrAboveRiskThreshold},
+			wantMessages: []string{
+				"discovered vulnerabilities at or above the risk threshold: CVE-exploited in the-package@v0.1 (risk ",
+			},
+		},
+		{
+			name:    "risk below threshold",
+			matcher: VulnerabilityMatcher{FailRisk: ptr(50)},
+			matches: unlikelyOnly,
+		},
+		{
+			name:     "known exploited",
+			matcher:  VulnerabilityMatcher{FailKEV: true},
+			matches:  matches,
+			wantErrs: []error{grypeerr.ErrKnownExploited},
+			wantMessages: []string{
+				"discovered known exploited vulnerabilities: CVE-exploited in the-package@v0.1",
+			},
+		},
+		{
+			name:    "no known exploited",
+			matcher: VulnerabilityMatcher{FailKEV: true},
+			matches: unlikelyOnly,
+		},
+		{
+			name:     "epss percentile",
+			matcher:  VulnerabilityMatcher{FailEPSSPercentile: ptr(0.1)},
+			matches:  matches,
+			wantErrs: []error{grypeerr.ErrAboveEPSSPercentileThreshold},
+			wantMessages: []string{
+				"CVE-exploited in the-package@v0.1 (EPSS percentile 0.990), CVE-unlikely in the-package@v0.1 (EPSS percentile 0.200)",
+			},
+		},
+		{
+			name: "multiple thresholds",
+			matcher: VulnerabilityMatcher{
+				FailSeverity: func() *vulnerability.Severity {
+					sev := vulnerability.HighSeverity
+					return &sev
+				}(),
+				FailKEV: true,
+			},
+			matches:  matches,
+			wantErrs: []error{grypeerr.ErrAboveSeverityThreshold, grypeerr.ErrKnownExploited},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.matcher.VulnerabilityProvider = provider
+			err := tt.matcher.checkFailThresholds(tt.matches)
+			if len(tt.wantErrs) == 0 {
+				require.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			assert.True(t, grypeerr.IsAboveFailThreshold(err))
+			for _, want := range tt.wantErrs {
+				assert.ErrorIs(t, err, want)
+			}
+			for _, msg := range tt.wantMessages {
+				assert.Contains(t, err.Error(), msg)
+			}
+		})
+	}
+}
+
+func Test_appendFailThresholdErr_summarizesManyMatches(t *testing.T) {
+	var tripped []string
+	for i := 0; i < maxFailSummaryMatches+3; i++ {
+		tripped = append(tripped, "CVE")
+	}
+	errs := appendFailThresholdErr(nil, grypeerr.ErrKnownExploited, tripped)
+	require.Len(t, errs, 1)
+	assert.True(t, strings.HasSuffix(errs[0].Error(), ", and 3 more"))
+}
+
 func TestVulnerabilityMatcher_FindMatches(t *testing.T) {
 	vp := mock.VulnerabilityProvider(testVulnerabilities()...)
 
@@ -510,7 +653,7 @@ func TestVulnerabilityMatcher_FindMatches(t *testing.T) {
 								},
 							},
 							Matcher:    "ruby-gem-matcher",
-							Confidence: 0.9,
+							Confidence: 0.85,
 						},
 					},
 				},
@@ -629,7 +772,7 @@ func TestVulnerabilityMatcher_FindMatches(t *testing.T) {
 								},
 							},
 							Matcher:    "ruby-gem-matcher",
-							Confidence: 0.9,
+							Confidence: 0.85,
 						},
 					},
 				},
@@ -697,7 +840,7 @@ func TestVulnerabilityMatcher_FindMatches(t *testing.T) {
 								},
 							},
 							Matcher:    "ruby-gem-matcher",
-							Confidence: 0.9,
+							Confidence: 0.85,
 						},
 					},
 				},
@@ -812,7 +955,7 @@ func TestVulnerabilityMatcher_FindMatches(t *testing.T) {
 									},
 								},
 								Matcher:    "ruby-gem-matcher",
-								Confidence: 0.9,
+								Confidence: 0.85,
 							},
 						},
 					},
@@ -968,7 +1111,7 @@ func TestVulnerabilityMatcher_FindMatches(t *testing.T) {
 									},
 								},
 								Matcher:    "ruby-gem-matcher",
-								Confidence: 0.9,
+								Confidence: 0.85,
 							},
 						},
 					},
@@ -1430,6 +1573,77 @@ func Test_ignoredMatchFilter(t *testing.T) {
 	require.ElementsMatch(t, []string{"test-location-ignore-rule", "test-filtered"}, ignoredReasons)
 }
 
+func TestVulnerabilityMatcher_applyIgnoreRulesWithFilters(t *testing.T) {
+	newMatch := func(id string) match.Match {
+		return match.Match{
+			Vulnerability: vulnerability.Vulnerability{
+				Reference: vulnerability.Reference{ID: id},
+			},
+			Package: pkg.Package{ID: pkg.ID(id), Name: "thing"},
+		}
+	}
+
+	m := &VulnerabilityMatcher{
+		IgnoreRules: []match.IgnoreRule{{Vulnerability: "CVE-123", Reason: "user-rule"}},
+		IgnoreFilters: []match.IgnoreFilter{
+			testIgnoreFilter{
+				f: func(m match.Match) bool {
+					return m.Vulnerability.ID != "CVE-789"
+				},
+			},
+		},
+	}
+
+	remaining, ignored := m.applyIgnoreRules(match.NewMatches(newMatch("CVE-123"), newMatch("CVE-456"), newMatch("CVE-789")))
+
+	var remainingIDs []string
+	for _, r := range remaining.Sorted() {
+		remainingIDs = append(remainingIDs, r.Vulnerability.ID)
+	}
+	assert.Equal(t, []string{"CVE-789"}, remainingIDs)
+
+	reasons := map[string][]string{}
+	for _, i := range ignored {
+		for _, r := range i.AppliedIgnoreRules {
+			reasons[i.Vulnerability.ID] = append(reasons[i.Vulnerability.ID], r.Reason)
+		}
+	}
+	assert.Equal(t, map[string][]string{
+		"CVE-123": {"user-rule", "test-filtered"},
+		"CVE-456": {"test-filtered"},
+	}, reasons)
+}
+
+func TestVulnerabilityMatcher_notifyExpiredIgnoreRules(t *testing.T) {
+	listener := &notificationListener{}
+	bus.Set(listener)
+	defer bus.Set(nil)
+
+	m := &VulnerabilityMatcher{
+		IgnoreRules: []match.IgnoreRule{
+			{Vulnerability: "CVE-123", Expires: "2020-01-01", Owner: "alice", Ticket: "SEC-1"},
+			{Vulnerability: "CVE-456", Expires: "2999-01-01"},
+			{Package: match.IgnoreRulePackage{Name: "thing"}},
+		},
+	}
+
+	m.notifyExpiredIgnoreRules()
+
+	assert.Equal(t, []string{
+		"ignore rule [CVE-123] expired on 2020-01-01 and is no longer applied (owner: alice, ticket: SEC-1)",
+	}, listener.notifications)
+}
+
+type notificationListener struct {
+	notifications []string
+}
+
+func (n *notificationListener) Publish(e partybus.Event) {
+	if e.Type == event.CLINotification {
+		n.notifications = append(n.notifications, e.Value.(string))
+	}
+}
+
 type testIgnoreFilter struct {
 	f func(match.Match) bool
 }
@@ -1486,3 +1700,168 @@ func (b *busListener) Publish(e partybus.Event) {
 }
 
 var _ partybus.Publisher = (*busListener)(nil)
+
+func TestVulnerabilityMatcher_searchDBForMatches_concurrency(t *testing.T) {
+	var packages []pkg.Package
+	for i := range 200 {
+		packages = append(packages, pkg.Package{
+			ID:      pkg.ID(fmt.Sprintf("pkg-%d", i)),
+			Name:    fmt.Sprintf("pkg-%d", i),
+			Version: "1.0.0",
+			Type:    syftPkg.NpmPkg,
+		})
+	}
+
+	npmMatcher := matcherMock.New(syftPkg.NpmPkg, func(_ vulnerability.Provider, p pkg.Package) ([]match.Match, []match.IgnoreFilter, error) {
+		var idx int
+		_, err := fmt.Sscanf(p.Name, "pkg-%d", &idx)
+		require.NoError(t, err)
+
+		if idx%50 == 0 {
+			return nil, nil, fmt.Errorf("unable to match %s", p.Name)
+		}
+
+		var matches []match.Match
+		for v := range idx % 3 {
+			matches = append(matches, match.Match{
+				Vulnerability: vulnerability.Vulnerability{
+					Reference: vulnerability.Reference{ID: fmt.Sprintf("CVE-%d-%d", idx, v), Namespace: "test"},
+				},
+				Package: p,
+				Details: match.Details{{Type: match.ExactDirectMatch, Matcher: match.StockMatcher}},
+			})
+		}
+		return matches, nil, nil
+	})
+
+	search := func(concurrency int) (match.Matches, *monitorWriter, error) {
+		m := VulnerabilityMatcher{
+			VulnerabilityProvider: mock.VulnerabilityProvider(),
+			Matchers:              []match.Matcher{npmMatcher},
+			Concurrency:           concurrency,
+		}
+		mon, _ := newMonitor(len(packages))
+		res, err := m.searchDBForMatches(nil, packages, &mon)
+		return res, &mon, err
+	}
+
+	expected, _, expectedErr := search(1)
+	require.Error(t, expectedErr)
+	require.NotZero(t, expected.Count())
+
+	for _, concurrency := range []int{0, 4, 16, 500} {
+		t.Run(fmt.Sprintf("concurrency=%d", concurrency), func(t *testing.T) {
+			actual, mon, err := search(concurrency)
+			require.Error(t, err)
+			assert.Equal(t, expectedErr.Error(), err.Error())
+			assert.Equal(t, matchIDs(expected), matchIDs(actual))
+			assert.Equal(t, int64(len(packages)), mon.PackagesProcessed.Current())
+			assert.Equal(t, int64(expected.Count()), mon.MatchesDiscovered.Current())
+		})
+	}
+}
+
+func matchIDs(matches match.Matches) []string {
+	var ids []string
+	for _, m := range matches.Sorted() {
+		ids = append(ids, m.Vulnerability.ID+"@"+m.Package.Name)
+	}
+	return ids
+}
+
+func TestVulnerabilityMatcher_searchDBForMatches_concurrentPanic(t *testing.T) {
+	var packages []pkg.Package
+	for i := range 50 {
+		packages = append(packages, pkg.Package{
+			ID:      pkg.ID(fmt.Sprintf("pkg-%d", i)),
+			Name:    fmt.Sprintf("pkg-%d", i),
+			Version: "1.0.0",
+			Type:    syftPkg.NpmPkg,
+		})
+	}
+
+	m := VulnerabilityMatcher{
+		VulnerabilityProvider: mock.VulnerabilityProvider(),
+		Matchers: []match.Matcher{
+			matcherMock.New(syftPkg.NpmPkg, func(_ vulnerability.Provider, p pkg.Package) ([]match.Match, []match.IgnoreFilter, error) {
+				if p.Name == "pkg-25" {
+					panic("test panic message")
+				}
+				return nil, nil, nil
+			}),
+		},
+		Concurrency: 8,
+	}
+
+	mon, _ := newMonitor(len(packages))
+	_, err := m.searchDBForMatches(nil, packages, &mon)
+	require.Error(t, err)
+	assert.True(t, match.IsFatalError(err))
+	assert.Contains(t, err.Error(), "test panic message")
+}
+
+// prefetchingProvider records the queries prefetched, returning a distinct provider for each prefetch
+type prefetchingProvider struct {
+	vulnerability.Provider
+	mu      sync.Mutex
+	queries [][]vulnerability.Criteria
+}
+
+func (p *prefetchingProvider) Prefetch(queries ...[]vulnerability.Criteria) (vulnerability.Provider, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.queries = append(p.queries, queries...)
+	return &prefetchedProvider{Provider: p.Provider}, nil
+}
+
+type prefetchedProvider struct {
+	vulnerability.Provider
+}
+
+func TestVulnerabilityMatcher_searchDBForMatches_prefetch(t *testing.T) {
+	d := distro.New(distro.Debian, "8", "")
+	var packages []pkg.Package
+	for i := range 250 {
+		packages = append(packages, pkg.Package{
+			ID:       pkg.ID(fmt.Sprintf("pkg-%d", i)),
+			Name:     fmt.Sprintf("pkg-%d", i),
+			Version:  "1.0.0",
+			Language: syftPkg.JavaScript,
+			Type:     syftPkg.NpmPkg,
+		})
+	}
+	packages[0].Upstreams = []pkg.UpstreamPackage{{Name: "upstream"}}
+
+	var mu sync.Mutex
+	var searched []string
+	npmMatcher := matcherMock.New(syftPkg.NpmPkg, func(provider vulnerability.Provider, p pkg.Package) ([]match.Match, []match.IgnoreFilter, error) {
+		mu.Lock()
+		defer mu.Unlock()
+		// matchers are given the provider with the data for the package already fetched
+		assert.IsType(t, &prefetchedProvider{}, provider)
+		searched = append(searched, p.Name)
+		return nil, nil, nil
+	})
+
+	for _, concurrency := range []int{1, 4} {
+		t.Run(fmt.Sprintf("concurrency=%d", concurrency), func(t *testing.T) {
+			searched = nil
+			provider := &prefetchingProvider{Provider: mock.VulnerabilityProvider()}
+			m := VulnerabilityMatcher{
+				VulnerabilityProvider: provider,
+				Matchers:              []match.Matcher{npmMatcher},
+				Concurrency:           concurrency,
+			}
+			mon, _ := newMonitor(len(packages))
+			_, err := m.searchDBForMatches(d, packages, &mon)
+			require.NoError(t, err)
+			assert.Len(t, searched, len(packages))
+
+			// each package is prefetched by name within its distro and ecosystem, as are upstream packages
+			assert.Len(t, provider.queries, 2*len(packages)+1)
+			assert.Contains(t, provider.queries, []vulnerability.Criteria{search.ByPackageName("pkg-0"), search.ByDistro(*d)})
+			assert.Contains(t, provider.queries, []vulnerability.Criteria{search.ByPackageName("upstream"), search.ByDistro(*d)})
+			assert.Contains(t, provider.queries, []vulnerability.Criteria{search.ByEcosystem(syftPkg.JavaScript, syftPkg.NpmPkg), search.ByPackageName("pkg-249")})
+		})
+	}
+}
@@ -0,0 +1,110 @@
+package grype
+
+import (
+	"strings"
+
+	"github.com/anchore/grype/grype/match"
+	"github.com/anchore/grype/grype/matcher/stock"
+	"github.com/anchore/grype/grype/pkg"
+)
+
+// MatcherTrace is what a single matcher found for a traced vulnerability
+type MatcherTrace struct {
+	Matcher           match.MatcherType
+	Matches           []match.Match        // matches for the vulnerability kept after explicit and matcher-provided ignore rules
+	ExplicitlyIgnored []match.IgnoredMatch // matches for the vulnerability dropped by hard-coded rules or DB exclusions
+	MatcherIgnored    []match.IgnoredMatch // matches for the vulnerability dropped by ignore rules provided by the matchers
+	Err               error
+}
+
+// MatchTrace describes how a single vulnerability was (or was not) matched to a package
+type MatchTrace struct {
+	Matchers []MatcherTrace       // every matcher that searched for matches for the package
+	Matches  []match.Match        // the matches for the vulnerability that would be reported
+	Ignored  []match.IgnoredMatch // the matches for the vulnerability ignored by user-provided rules, filters, or VEX
+}
+
+// Matched indicates if the vulnerability would be reported for the package
+func (t MatchTrace) Matched() bool {
+	return len(t.Matches) > 0
+}
+
+// TraceMatches runs the matchers for the package, keeping what each matcher found for the given vulnerability (by ID
+// or by a related vulnerability ID) and which ignore rules were applied at each stage of the matching process.
+func (m *VulnerabilityMatcher) TraceMatches(p pkg.Package, context pkg.Context, vulnID string) (*MatchTrace, error) {
+	matcherIndex, defaultMatcher := newMatcherIndex(m.Matchers)
+	if defaultMatcher == nil {
+		defaultMatcher = stock.NewStockMatcher(stock.MatcherConfig{UseCPEs: true})
+	}
+
+	// the package is matched the same way as when searching many packages, which defaults to the global distro
+	searchPkg := p
+	if searchPkg.Distro == nil {
+		searchPkg.Distro = context.Distro
+	}
+
+	matchAgainst, ok := matcherIndex[searchPkg.Type]
+	if !ok {
+		matchAgainst = []match.Matcher{defaultMatcher}
+	}
+
+	trace := &MatchTrace{}
+	var ignorers []match.IgnoreFilter
+	for _, theMatcher := range matchAgainst {
+		matches, matcherIgnorers, err := callMatcherSafely(theMatcher, m.VulnerabilityProvider, searchPkg)
+		if err != nil && match.IsFatalError(err) {
+			return nil, err
+		}
+		ignorers = append(ignorers, matcherIgnorers...)
+
+		filtered, dropped := match.ApplyExplicitIgnoreRules(m.ExclusionProvider, match.NewMatches(matchesForVulnerability(matches, vulnID)...))
+		trace.Matchers = append(trace.Matchers, MatcherTrace{
+			Matcher:           theMatcher.Type(),
+			Matches:           filtered.Sorted(),
+			ExplicitlyIgnored: dropped,
+			Err:               err,
+		})
+	}
+
+	// ignore rules provided by matchers apply to the matches of all matchers
+	matcherIgnores := ignoredMatchFilter(ignorers)
+	for idx := range trace.Matchers {
+		trace.Matchers[idx].Matches, trace.Matchers[idx].MatcherIgnored = match.ApplyIgnoreFilters(trace.Matchers[idx].Matches, matcherIgnores)
+	}
+
+	// the outcome is taken from the complete matching process, including user-provided ignore rules and VEX
+	remaining, ignored, err := m.FindMatches([]pkg.Package{p}, context)
+	if err != nil {
+		return nil, err
+	}
+	trace.Matches = matchesForVulnerability(remaining.Sorted(), vulnID)
+	for _, i := range ignored {
+		if isMatchForVulnerability(i.Match, vulnID) {
+			trace.Ignored = append(trace.Ignored, i)
+		}
+	}
+
+	return trace, nil
+}
+
+func matchesForVulnerability(matches []match.Match, vulnID string) []match.Match {
+	var out []match.Match
+	for _, m := range matches {
+		if isMatchForVulnerability(m, vulnID) {
+			out = append(out, m)
+		}
+	}
+	return out
+}
+
+func isMatchForVulnerability(m match.Match, vulnID string) bool {
+	if strings.EqualFold(m.Vulnerability.ID, vulnID) {
+		return true
+	}
+	for _, r := range m.Vulnerability.RelatedVulnerabilities {
+		if strings.EqualFold(r.ID, vulnID) {
+			return true
+		}
+	}
+	return false
+}
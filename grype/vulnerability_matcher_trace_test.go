@@ -0,0 +1,118 @@
+package grype
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/anchore/grype/grype/distro"
+	"github.com/anchore/grype/grype/match"
+	"github.com/anchore/grype/grype/matcher"
+	"github.com/anchore/grype/grype/matcher/ruby"
+	"github.com/anchore/grype/grype/pkg"
+	"github.com/anchore/grype/grype/vulnerability/mock"
+	"github.com/anchore/syft/syft/cpe"
+	syftPkg "github.com/anchore/syft/syft/pkg"
+)
+
+func TestVulnerabilityMatcher_TraceMatches(t *testing.T) {
+	neutronPkg := pkg.Package{
+		ID:      pkg.ID(uuid.NewString()),
+		Name:    "neutron",
+		Version: "2013.1.1-1",
+		Type:    syftPkg.DebPkg,
+	}
+
+	activerecordPkg := pkg.Package{
+		ID:       pkg.ID(uuid.NewString()),
+		Name:     "activerecord",
+		Version:  "3.7.5",
+		CPEs:     []cpe.CPE{cpe.Must("cpe:2.3:*:activerecord:activerecord:*:*:*:*:*:rails:*:*", "")},
+		Type:     syftPkg.GemPkg,
+		Language: syftPkg.Ruby,
+	}
+
+	debianContext := pkg.Context{Distro: &distro.Distro{Type: "debian", Version: "8"}}
+
+	tests := []struct {
+		name          string
+		pkg           pkg.Package
+		context       pkg.Context
+		vulnID        string
+		ignoreRules   []match.IgnoreRule
+		wantMatched   bool
+		wantMatchers  []match.MatcherType
+		wantMatchIDs  map[match.MatcherType][]string
+		wantIgnoreIDs []string
+	}{
+		{
+			name:         "matched by the distro",
+			pkg:          neutronPkg,
+			context:      debianContext,
+			vulnID:       "CVE-2014-fake-1",
+			wantMatched:  true,
+			wantMatchers: []match.MatcherType{match.DpkgMatcher},
+			wantMatchIDs: map[match.MatcherType][]string{match.DpkgMatcher: {"CVE-2014-fake-1"}},
+		},
+		{
+			name:         "other vulnerabilities for the package are not traced",
+			pkg:          neutronPkg,
+			context:      debianContext,
+			vulnID:       "CVE-2013-fake-2",
+			wantMatchers: []match.MatcherType{match.DpkgMatcher},
+			wantMatchIDs: map[match.MatcherType][]string{},
+		},
+		{
+			name:          "ignored by the user",
+			pkg:           neutronPkg,
+			context:       debianContext,
+			vulnID:        "CVE-2014-fake-1",
+			ignoreRules:   []match.IgnoreRule{{Vulnerability: "CVE-2014-fake-1"}},
+			wantMatchers:  []match.MatcherType{match.DpkgMatcher},
+			wantMatchIDs:  map[match.MatcherType][]string{match.DpkgMatcher: {"CVE-2014-fake-1"}},
+			wantIgnoreIDs: []string{"CVE-2014-fake-1"},
+		},
+		{
+			name:         "traced by a related vulnerability",
+			pkg:          activerecordPkg,
+			vulnID:       "cve-2014-fake-3",
+			wantMatched:  true,
+			wantMatchers: []match.MatcherType{match.RubyGemMatcher},
+			wantMatchIDs: map[match.MatcherType][]string{match.RubyGemMatcher: {"CVE-2014-fake-3", "GHSA-2014-fake-3"}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := &VulnerabilityMatcher{
+				VulnerabilityProvider: mock.VulnerabilityProvider(testVulnerabilities()...),
+				Matchers:              matcher.NewDefaultMatchers(matcher.Config{Ruby: ruby.MatcherConfig{UseCPEs: true}}),
+				IgnoreRules:           tt.ignoreRules,
+			}
+
+			trace, err := m.TraceMatches(tt.pkg, tt.context, tt.vulnID)
+			require.NoError(t, err)
+
+			assert.Equal(t, tt.wantMatched, trace.Matched())
+
+			var matchers []match.MatcherType
+			matchIDs := make(map[match.MatcherType][]string)
+			for _, mt := range trace.Matchers {
+				require.NoError(t, mt.Err)
+				matchers = append(matchers, mt.Matcher)
+				for _, found := range mt.Matches {
+					matchIDs[mt.Matcher] = append(matchIDs[mt.Matcher], found.Vulnerability.ID)
+				}
+			}
+			assert.Equal(t, tt.wantMatchers, matchers)
+			assert.Equal(t, tt.wantMatchIDs, matchIDs)
+
+			var ignoreIDs []string
+			for _, i := range trace.Ignored {
+				ignoreIDs = append(ignoreIDs, i.Vulnerability.ID)
+			}
+			assert.Equal(t, tt.wantIgnoreIDs, ignoreIDs)
+		})
+	}
+}
@@ -94,7 +94,9 @@ func getterClient(dst, src string, dir bool, httpGetter getter.HttpGetter, monit
 			// note: these are the default getters from https://github.com/hashicorp/go-getter/blob/v1.5.9/get.go#L68-L74
 			// it is possible that other implementations need to account for custom httpclient injection, however,
 			// that has not been accounted for at this time.
-			"file": new(getter.FileGetter),
+			// files are copied rather than symlinked, so that local sources (such as a DB mirror) can change
+			// independently of what was downloaded from them
+			"file": &getter.FileGetter{Copy: true},
 			"git":  new(getter.GitGetter),
 			"gcs":  new(getter.GCSGetter),
 			"hg":   new(getter.HgGetter),
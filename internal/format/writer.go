@@ -20,7 +20,10 @@ type ScanResultWriter interface {
 	Write(result models.PresenterConfig) error
 }
 
-var _ ScanResultWriter = (*scanResultMultiWriter)(nil)
+var _ interface {
+	io.Closer
+	ScanResultWriter
+} = (*scanResultMultiWriter)(nil)
 
 var _ interface {
 	io.Closer
@@ -176,6 +179,18 @@ func (m *scanResultMultiWriter) Write(s models.PresenterConfig) (errs error) {
 	return errs
 }
 
+// Close closes all writers that hold resources, such as open files
+func (m *scanResultMultiWriter) Close() (errs error) {
+	for _, w := range m.writers {
+		if closer, ok := w.(io.Closer); ok {
+			if err := closer.Close(); err != nil {
+				errs = multierror.Append(errs, err)
+			}
+		}
+	}
+	return errs
+}
+
 // scanResultStreamWriter implements ScanResultWriter for a given format and io.Writer, also providing a close function for cleanup
 type scanResultStreamWriter struct {
 	format Format
@@ -854,8 +854,10 @@ func TestMatchByImage(t *testing.T) {
 	observedMatchers.Remove(string(match.StockMatcher))
 	definedMatchers.Remove(string(match.StockMatcher))
 	definedMatchers.Remove(string(match.MsrcMatcher))
-	definedMatchers.Remove(string(match.PortageMatcher)) // TODO: add this back in when #744 is complete
-	definedMatchers.Remove(string(match.BitnamiMatcher)) // bitnami will be tested via quality gate
+	definedMatchers.Remove(string(match.PortageMatcher))      // TODO: add this back in when #744 is complete
+	definedMatchers.Remove(string(match.BitnamiMatcher))      // bitnami will be tested via quality gate
+	definedMatchers.Remove(string(match.CsafVexMatcher))      // CSAF VEX is tested via the vex processor unit tests
+	definedMatchers.Remove(string(match.CycloneDXVexMatcher)) // CycloneDX VEX is tested via the vex processor unit tests
 
 	if len(observedMatchers) != len(definedMatchers) {
 		t.Errorf("matcher coverage incomplete (matchers=%d, coverage=%d)", len(definedMatchers), len(observedMatchers))